@@ -67,6 +67,14 @@ func (s *Server) createAccount(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
+	// a user who signed up without a base currency takes the currency of their first account
+	err = s.repo.SetDefaultBaseCurrency(ctx.Context(), db.UpdateBaseCurrencyParams{
+		UserID:       userID,
+		BaseCurrency: account.Currency,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn("cannot set the default base currency")
+	}
 	s.logs.Info("account returned successfully")
 	return ctx.Status(http.StatusCreated).JSON(account)
 }
@@ -98,18 +106,22 @@ func (s *Server) getAccount(ctx *fiber.Ctx) error {
 }
 
 type accountBalanceResponse struct {
-	AccountID model.AccountID    `json:"account_id"`
-	UserID    model.UserID       `json:"user_id"`
-	Balance   int64              `json:"balance"`
-	Currency  utils.CurrencyCode `json:"currency"`
-	Type      model.AccountType  `json:"type"`
+	AccountID model.AccountID `json:"account_id"`
+	UserID    model.UserID    `json:"user_id"`
+	// Balance is the total balance, ClearedBalance the balance without the transactions still pending
+	Balance        utils.Money        `json:"balance"`
+	ClearedBalance utils.Money        `json:"cleared_balance"`
+	Currency       utils.CurrencyCode `json:"currency"`
+	Type           model.AccountType  `json:"type"`
+	// Converted is the balance in the base currency of the user at today's rate
+	Converted *model.Conversion `json:"converted,omitempty"`
 }
 
 func newBalanceResponse(account model.Account) accountBalanceResponse {
 	return accountBalanceResponse{
 		AccountID: account.AccountID,
 		UserID:    account.UserID,
-		Balance:   account.Balance,
+		Balance:   utils.NewMoney(account.Balance, account.Currency),
 		Currency:  account.Currency,
 		Type:      account.Type,
 	}
@@ -135,14 +147,40 @@ func (s *Server) accountBalance(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	s.logs.Info("account balance returned successfully")
 	response := newBalanceResponse(account)
+	pending, err := s.repo.GetPendingAmount(ctx.Context(), account.AccountID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response.ClearedBalance = utils.NewMoney(account.Balance-pending, account.Currency)
+	base, err := s.baseCurrency(ctx.Context(), account.UserID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	conversion, err := s.repo.ConvertAmount(ctx.Context(), db.ConvertAmountParams{
+		Amount: account.Balance,
+		From:   account.Currency,
+		To:     base,
+		Date:   time.Now(),
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response.Converted = &conversion
+	s.logs.Info("account balance returned successfully")
 	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 type listAccountsRequest struct {
-	PageID   int32 `query:"page_id" validate:"required,min=1"`
-	PageSize int32 `query:"page_size" validate:"required,min=5,max=10"`
+	// Cursor is the next_cursor of the previous page, the first page is returned without it
+	Cursor   string `query:"cursor"`
+	PageSize int32  `query:"page_size" validate:"omitempty,min=1,max=100"`
 }
 
 func (s *Server) listAccounts(ctx *fiber.Ctx) error {
@@ -160,11 +198,16 @@ func (s *Server) listAccounts(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
 	args := db.ListAccountParams{
 		UserID: userID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  size + 1,
+		Cursor: cursor,
 	}
 	accounts, err := s.repo.ListAccounts(ctx.Context(), args)
 	if err != nil {
@@ -172,12 +215,24 @@ func (s *Server) listAccounts(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	if len(accounts) == 0 {
-		status = http.StatusNotFound
-		return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+	total, err := s.repo.CountAccounts(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
+	response := page{Total: total}
+	if len(accounts) > int(size) {
+		accounts = accounts[:size]
+		last := accounts[size-1]
+		response.NextCursor = model.Cursor{Date: last.CreatedAt, ID: string(last.AccountID)}.Encode()
+	}
+	if accounts == nil {
+		accounts = []model.Account{}
+	}
+	response.Items = accounts
 	s.logs.Info("accounts returned successfully")
-	return ctx.Status(http.StatusOK).JSON(accounts)
+	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 func (s *Server) deleteAccount(ctx *fiber.Ctx) error {
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"FiberFinanceAPI/backup"
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"time"
+)
+
+// backupUserData streams a backup archive of everything the user has, it is read back by restoreBackup
+func (s *Server) backupUserData(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "backup.go -> backupUserData()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	manifest := backup.Manifest{CreatedAt: time.Now().UTC(), UserID: userID}
+
+	data, err := s.backupData(ctx.Context(), userID)
+	var page []model.Transaction
+	if err == nil {
+		page, err = s.exportPage(ctx.Context(), db.ExportTransactionsParams{UserID: userID, Limit: exportPageSize})
+	}
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/zip")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="backup-%s.zip"`,
+		manifest.CreatedAt.Format("20060102T150405Z")))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// the request context is recycled once the handler returns, the stream runs after that
+		if err := s.writeBackup(context.Background(), w, manifest, data, page); err != nil {
+			s.logs.WithError(err).Error("backup cut short")
+		}
+		if err := w.Flush(); err != nil {
+			s.logs.WithError(err).Warn()
+		}
+	})
+	return nil
+}
+
+// backupData reads everything of the user that goes into a backup besides the transactions
+func (s *Server) backupData(ctx context.Context, userID model.UserID) (backup.Data, error) {
+	var data backup.Data
+	var err error
+	if data.Accounts, err = s.repo.ExportAccounts(ctx, userID); err != nil {
+		return data, err
+	}
+	if data.Categories, err = s.repo.ExportCategories(ctx, userID); err != nil {
+		return data, err
+	}
+	if data.Merchants, err = s.repo.ExportMerchants(ctx, userID); err != nil {
+		return data, err
+	}
+	if data.Transfers, err = s.repo.ExportTransfers(ctx, userID); err != nil {
+		return data, err
+	}
+	if data.Budgets, err = s.repo.ExportBudgets(ctx, userID); err != nil {
+		return data, err
+	}
+	if data.Recurring, err = s.repo.ExportRecurring(ctx, userID); err != nil {
+		return data, err
+	}
+	data.ImportMappings, err = s.repo.ListImportMappings(ctx, userID)
+	return data, err
+}
+
+// writeBackup writes the archive, page is the first page of transactions and the rest is read as it goes
+func (s *Server) writeBackup(ctx context.Context, w *bufio.Writer, manifest backup.Manifest, data backup.Data, page []model.Transaction) error {
+	writer := backup.NewWriter(w)
+	if err := writer.Begin(manifest, data); err != nil {
+		return err
+	}
+	for len(page) > 0 {
+		if err := writer.Transactions(page); err != nil {
+			return err
+		}
+		if len(page) < exportPageSize {
+			break
+		}
+		last := page[len(page)-1]
+		var err error
+		page, err = s.exportPage(ctx, db.ExportTransactionsParams{
+			UserID:    last.UserID,
+			AfterDate: last.Date,
+			AfterID:   last.ID,
+			Limit:     exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+type restoreResponse struct {
+	Manifest backup.Manifest `json:"manifest"`
+	model.RestoreResult
+}
+
+// restoreBackup restores the backup archive uploaded as file for the user, who need not be the one it was
+// taken from. Restoring the same archive again only brings back what was deleted since
+func (s *Server) restoreBackup(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "backup.go -> restoreBackup()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		s.logs.WithError(err).Warn("backup file not provided")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	file, err := header.Open()
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			s.logs.WithError(closeErr).Warn("could not close the uploaded file")
+		}
+	}()
+	archive, err := backup.Read(file, header.Size)
+	if err != nil {
+		s.logs.WithError(err).Warn("cannot read backup")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+
+	args := db.RestoreBackupParams{
+		UserID:         userID,
+		Accounts:       archive.Data.Accounts,
+		Categories:     archive.Data.Categories,
+		Merchants:      archive.Data.Merchants,
+		Transfers:      archive.Data.Transfers,
+		Transactions:   archive.Transactions,
+		Budgets:        archive.Data.Budgets,
+		Recurring:      archive.Data.Recurring,
+		ImportMappings: archive.Data.ImportMappings,
+	}
+	result, err := s.repo.RestoreBackupTx(ctx.Context(), args)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrCurrencyMismatch), errors.Is(err, db.ErrSplitsUnbalanced):
+			s.logs.WithError(err).Warn()
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn("could not restore backup")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("backup restored successfully")
+	return ctx.Status(http.StatusOK).JSON(restoreResponse{
+		Manifest:      archive.Manifest,
+		RestoreResult: result,
+	})
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+var (
+	budgetNotFound   = errors.New("budget(s) not found or deleted")
+	budgetDeletedMSG = "budget successfully deleted at %s"
+	invalidMonth     = errors.New("month must be given as YYYY-MM")
+	budgetCurrency   = errors.New("budget amounts must be given in the base currency")
+)
+
+type createBudgetRequest struct {
+	CategoryID model.CategoryID `json:"category_id" validate:"required"`
+	// Month is given as YYYY-MM
+	Month string `json:"month" validate:"required,datetime=2006-01"`
+	// Amount is in minor units or Money of the base currency
+	Amount   utils.Money `json:"amount" validate:"min=0"`
+	Rollover bool        `json:"rollover"`
+}
+
+func (s *Server) createBudget(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "budgets.go -> createBudget()").Debug()
+	var req createBudgetRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if err := s.checkBudgetCurrency(ctx.Context(), userID, req.Amount); err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		if errors.Is(err, budgetCurrency) {
+			status = http.StatusBadRequest
+		}
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	month, _ := time.Parse(model.BudgetMonthLayout, req.Month)
+
+	category, err := s.repo.GetCategoryByID(ctx.Context(), req.CategoryID)
+	if err == nil && category.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+
+	args := db.CreateBudgetParams{
+		UserID:     userID,
+		CategoryID: req.CategoryID,
+		Month:      month,
+		Amount:     req.Amount.Amount,
+		Rollover:   req.Rollover,
+	}
+	budget, err := s.repo.CreateBudget(ctx.Context(), args)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			s.logs.WithField(string(pqErr.Code), pqErr.Code.Name()).Debug("postgres error codes")
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				status = http.StatusForbidden
+				return ctx.Status(status).JSON(errorResponse(status, err))
+			}
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Budget created successfully")
+	return ctx.Status(http.StatusCreated).JSON(budget)
+}
+
+type updateBudgetRequest struct {
+	// Amount is in minor units or Money of the base currency
+	Amount   utils.Money `json:"amount" validate:"min=0"`
+	Rollover bool        `json:"rollover"`
+}
+
+func (s *Server) updateBudget(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "budgets.go -> updateBudget()").Debug()
+	var req updateBudgetRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	budgetID := model.BudgetID(ctx.Params("budgetID"))
+	if budgetID == "" {
+		s.logs.WithField("budgetID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("budgetID not provided")))
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if err := s.checkBudgetCurrency(ctx.Context(), userID, req.Amount); err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		if errors.Is(err, budgetCurrency) {
+			status = http.StatusBadRequest
+		}
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	args := db.UpdateBudgetParams{
+		BudgetID: budgetID,
+		UserID:   userID,
+		Amount:   req.Amount.Amount,
+		Rollover: req.Rollover,
+	}
+	budget, err := s.repo.UpdateBudget(ctx.Context(), args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, budgetNotFound))
+		}
+		s.logs.WithError(err).Warn("could not update budget")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Budget updated successfully")
+	return ctx.Status(http.StatusOK).JSON(budget)
+}
+
+// budgetMonth returns what was budgeted, spent and is remaining in every budget of the user for a month
+func (s *Server) budgetMonth(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "budgets.go -> budgetMonth()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	month, err := time.Parse(model.BudgetMonthLayout, ctx.Params("month"))
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, invalidMonth))
+	}
+	base, err := s.baseCurrency(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	args := db.ListBudgetHistoryParams{
+		UserID:       userID,
+		Month:        month,
+		BaseCurrency: base,
+	}
+	history, err := s.repo.ListBudgetHistory(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	budgets := model.CarryOver(history, month)
+	if len(budgets) == 0 {
+		status = http.StatusNotFound
+		return ctx.Status(status).JSON(errorResponse(status, budgetNotFound))
+	}
+	s.logs.Info("budgets returned successfully")
+
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{
+		"month":   month.Format(model.BudgetMonthLayout),
+		"budgets": budgets,
+	})
+}
+
+func (s *Server) deleteBudget(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "budgets.go -> deleteBudget()").Debug()
+	budgetID := model.BudgetID(ctx.Params("budgetID"))
+	if budgetID == "" {
+		s.logs.WithField("budgetID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("budgetID not provided")))
+	}
+
+	userID := ctx.Locals("userID").(model.UserID)
+	args := db.DeleteBudgetParams{
+		BudgetID: budgetID,
+		UserID:   userID,
+	}
+	deletedAt, err := s.repo.DeleteBudget(ctx.Context(), args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, budgetNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("budget deleted successfully")
+
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(budgetDeletedMSG, deletedAt.Format(time.ANSIC))})
+}
+
+// checkBudgetCurrency checks a budget amount given as Money is in the base currency of the user, which budgets and
+// the spending under them are kept in
+func (s *Server) checkBudgetCurrency(ctx context.Context, userID model.UserID, amount utils.Money) error {
+	if amount.Currency == "" {
+		return nil
+	}
+	base, err := s.baseCurrency(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if amount.Currency != base {
+		return budgetCurrency
+	}
+	return nil
+}
@@ -15,11 +15,12 @@ import (
 
 var (
 	categoryNotFound   = errors.New("category does not exist or deleted")
+	categoryExists     = errors.New("a category with that name already exists under the parent")
 	categoryDeletedMSG = "category successfully deleted at %s"
 )
 
 type createCategoryRequest struct {
-	ParentID model.CategoryID `json:"parent_id"`
+	ParentID model.CategoryID `json:"parent_id" validate:"omitempty,uuid"`
 	Name     string           `json:"name" validate:"required"`
 }
 
@@ -37,7 +38,22 @@ func (s *Server) createCategory(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	//s.logs.Debug(reflect.DeepEqual(req.ParentID, nil))
+	if req.ParentID != "" {
+		parent, err := s.repo.GetCategoryByID(ctx.Context(), req.ParentID)
+		if err == nil && parent.UserID != userID {
+			err = sql.ErrNoRows
+		}
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.logs.WithError(err).Warn()
+				status = http.StatusNotFound
+				return ctx.Status(status).JSON(errorResponse(status, db.ErrCategoryNotFound))
+			}
+			s.logs.WithError(err).Warn()
+			status = http.StatusInternalServerError
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+	}
 	args := db.CreateCategoryParams{
 		ParentID: req.ParentID,
 		UserID:   userID,
@@ -86,9 +102,9 @@ func (s *Server) getCategory(ctx *fiber.Ctx) error {
 	return ctx.Status(http.StatusOK).JSON(category)
 }
 
+// updateCategoryRequest renames a category, it is moved under another parent with moveCategory
 type updateCategoryRequest struct {
-	ParentID model.CategoryID `json:"parent_id" validate:"required"`
-	Name     string           `json:"name" validate:"required"`
+	Name string `json:"name" validate:"required"`
 }
 
 func (s *Server) updateCategory(ctx *fiber.Ctx) error {
@@ -111,18 +127,27 @@ func (s *Server) updateCategory(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	args := db.UpdateCategoryParams{
-		CategoryID: model.CategoryID(categoryID),
-		ParentID:   req.ParentID,
-		Name:       req.Name,
+	category, err := s.repo.GetCategoryByID(ctx.Context(), model.CategoryID(categoryID))
+	if err == nil && category.UserID != ctx.Locals("userID").(model.UserID) {
+		err = sql.ErrNoRows
+	}
+	if err == nil {
+		category, err = s.repo.UpdateCategory(ctx.Context(), db.UpdateCategoryParams{
+			CategoryID: category.ID,
+			Name:       req.Name,
+		})
 	}
-	category, err := s.repo.UpdateCategory(ctx.Context(), args)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			s.logs.WithError(err).Warn()
 			status = http.StatusNotFound
 			return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
 		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, categoryExists))
+		}
 		s.logs.WithError(err).Warn()
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
@@ -132,8 +157,9 @@ func (s *Server) updateCategory(ctx *fiber.Ctx) error {
 }
 
 type listCategoryRequest struct {
-	PageID   int32 `query:"page_id" validate:"required,min=1"`
-	PageSize int32 `query:"page_size" validate:"required,min=5,max=10"`
+	// Cursor is the next_cursor of the previous page, the first page is returned without it
+	Cursor   string `query:"cursor"`
+	PageSize int32  `query:"page_size" validate:"omitempty,min=1,max=100"`
 }
 
 func (s *Server) listCategories(ctx *fiber.Ctx) error {
@@ -151,11 +177,16 @@ func (s *Server) listCategories(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
 	args := db.ListCategoryParams{
 		UserID: userID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  size + 1,
+		Cursor: cursor,
 	}
 	categories, err := s.repo.ListCategories(ctx.Context(), args)
 	if err != nil {
@@ -163,13 +194,31 @@ func (s *Server) listCategories(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	if len(categories) == 0 {
-		status = http.StatusNotFound
-		return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
+	total, err := s.repo.CountCategories(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := page{Total: total}
+	if len(categories) > int(size) {
+		categories = categories[:size]
+		last := categories[size-1]
+		response.NextCursor = model.Cursor{Date: last.CreatedAt, ID: string(last.ID)}.Encode()
 	}
+	if categories == nil {
+		categories = []model.Category{}
+	}
+	response.Items = categories
 	s.logs.Info("categories returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
 
-	return ctx.Status(http.StatusOK).JSON(categories)
+type deleteCategoryRequest struct {
+	// ReassignTo is the category the transactions of the deleted category are moved to
+	ReassignTo model.CategoryID `query:"reassign_to" validate:"omitempty,uuid"`
+	// Unlock lets reconciled transactions be moved to ReassignTo
+	Unlock bool `query:"unlock"`
 }
 
 func (s *Server) deleteCategory(ctx *fiber.Ctx) error {
@@ -181,18 +230,172 @@ func (s *Server) deleteCategory(ctx *fiber.Ctx) error {
 		return ctx.Status(status).JSON(errorResponse(status, errors.New("categoryID not provided")))
 	}
 
-	deletedAt, err := s.repo.DeleteCategory(ctx.Context(), model.CategoryID(categoryID))
+	var req deleteCategoryRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	// transactions of the category are moved to reassign_to and its subcategories up to its parent before it is
+	// deleted
+	args := db.DeleteCategoryParams{
+		CategoryID: model.CategoryID(categoryID),
+		UserID:     ctx.Locals("userID").(model.UserID),
+		ReassignTo: req.ReassignTo,
+		Unlock:     req.Unlock,
+	}
+	deletedAt, err := s.repo.DeleteCategoryTx(ctx.Context(), args)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if code, ok := categoryTreeStatus(err); ok {
 			s.logs.WithError(err).Warn()
-			status = http.StatusNotFound
-			return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
+			status = code
+			return ctx.Status(status).JSON(errorResponse(status, categoryTreeError(err)))
 		}
 		s.logs.WithError(err).Warn()
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
+	// the classifier of the user would keep suggesting the deleted category until it expires
+	s.suggestions.Forget(string(args.UserID))
 	s.logs.Info("category deleted successfully")
 
 	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(categoryDeletedMSG, deletedAt.Format(time.ANSIC))})
 }
+
+// categoryTree returns every category of the user nested under its parent
+func (s *Server) categoryTree(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "category_api.go -> categoryTree()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+
+	categories, err := s.repo.ListAllCategories(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("category tree returned successfully")
+	return ctx.Status(http.StatusOK).JSON(model.CategoryTree(categories))
+}
+
+type moveCategoryRequest struct {
+	// ParentID is the category to move under, empty moves the category to the top level
+	ParentID model.CategoryID `json:"parent_id" validate:"omitempty,uuid"`
+}
+
+// moveCategory moves a category with its subcategories under another parent
+func (s *Server) moveCategory(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "category_api.go -> moveCategory()").Debug()
+	var req moveCategoryRequest
+	categoryID := ctx.Params("categoryID")
+	if categoryID == "" {
+		s.logs.WithField("categoryID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("categoryID not provided")))
+	}
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	category, err := s.repo.MoveCategoryTx(ctx.Context(), db.MoveCategoryParams{
+		CategoryID: model.CategoryID(categoryID),
+		UserID:     ctx.Locals("userID").(model.UserID),
+		ParentID:   req.ParentID,
+	})
+	if err != nil {
+		if code, ok := categoryTreeStatus(err); ok {
+			s.logs.WithError(err).Warn()
+			status = code
+			return ctx.Status(status).JSON(errorResponse(status, categoryTreeError(err)))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("category moved successfully")
+	return ctx.Status(http.StatusOK).JSON(category)
+}
+
+type mergeCategoryRequest struct {
+	TargetID model.CategoryID `json:"target_id" validate:"required,uuid"`
+}
+
+// mergeCategory moves everything filed under a category to the target category and deletes it
+func (s *Server) mergeCategory(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "category_api.go -> mergeCategory()").Debug()
+	var req mergeCategoryRequest
+	categoryID := ctx.Params("categoryID")
+	if categoryID == "" {
+		s.logs.WithField("categoryID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("categoryID not provided")))
+	}
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	merge, err := s.repo.MergeCategoriesTx(ctx.Context(), db.MergeCategoriesParams{
+		CategoryID: model.CategoryID(categoryID),
+		UserID:     ctx.Locals("userID").(model.UserID),
+		TargetID:   req.TargetID,
+		Unlock:     ctx.Query("unlock") == "true",
+	})
+	if err != nil {
+		if code, ok := categoryTreeStatus(err); ok {
+			s.logs.WithError(err).Warn()
+			status = code
+			return ctx.Status(status).JSON(errorResponse(status, categoryTreeError(err)))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.suggestions.Forget(string(ctx.Locals("userID").(model.UserID)))
+	s.logs.Info("category merged successfully")
+	return ctx.Status(http.StatusOK).JSON(merge)
+}
+
+// categoryTreeStatus returns the status of the errors a change to the category tree fails with because of the
+// request, ok is false for any other error
+func categoryTreeStatus(err error) (code int, ok bool) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, db.ErrCategoryNotFound):
+		return http.StatusNotFound, true
+	case errors.Is(err, db.ErrCategoryCycle):
+		return http.StatusBadRequest, true
+	case errors.Is(err, db.ErrCategoryInUse), errors.Is(err, db.ErrTransactionReconciled):
+		return http.StatusConflict, true
+	}
+	if pqErr, isPQ := err.(*pq.Error); isPQ && pqErr.Code.Name() == "unique_violation" {
+		return http.StatusConflict, true
+	}
+	return 0, false
+}
+
+// categoryTreeError returns the error a change to the category tree is reported to the client with
+func categoryTreeError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return categoryNotFound
+	case errors.Is(err, db.ErrCategoryNotFound), errors.Is(err, db.ErrCategoryCycle), errors.Is(err, db.ErrCategoryInUse),
+		errors.Is(err, db.ErrTransactionReconciled):
+		return err
+	}
+	return categoryExists
+}
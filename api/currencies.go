@@ -0,0 +1,13 @@
+package api
+
+import (
+	"FiberFinanceAPI/utils"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+// listCurrencies returns the ISO 4217 currencies accounts can be opened in with their minor units
+func (s *Server) listCurrencies(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "currencies.go -> listCurrencies()").Debug()
+	return ctx.Status(http.StatusOK).JSON(utils.Currencies())
+}
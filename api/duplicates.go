@@ -0,0 +1,188 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"time"
+)
+
+const duplicateDismissedMSG = "duplicate successfully dismissed at %s"
+
+// possibleDuplicates pairs new transactions of an account with the transactions of the account they are likely
+// duplicates of, the new transactions are not paired with one another
+func (s *Server) possibleDuplicates(ctx *fiber.Ctx, accountID model.AccountID, transactions []model.Transaction) ([]model.DuplicatePair, error) {
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+	from, to := transactions[0].Date, transactions[0].Date
+	created := make(map[model.TransactionID]bool, len(transactions))
+	for _, transaction := range transactions {
+		if transaction.Date.Before(from) {
+			from = transaction.Date
+		}
+		if transaction.Date.After(to) {
+			to = transaction.Date
+		}
+		created[transaction.ID] = transaction.ID != ""
+	}
+	candidates, err := s.repo.ListDuplicateCandidates(ctx.Context(), db.ListDuplicateCandidatesParams{
+		AccountID: accountID,
+		From:      from.Add(-model.DuplicateWindow),
+		To:        to.Add(model.DuplicateWindow),
+	})
+	if err != nil {
+		return nil, err
+	}
+	existing := candidates[:0]
+	for _, candidate := range candidates {
+		if !created[candidate.ID] {
+			existing = append(existing, candidate)
+		}
+	}
+	var pairs []model.DuplicatePair
+	for _, transaction := range transactions {
+		pairs = append(pairs, model.FindDuplicates(transaction, existing)...)
+	}
+	return pairs, nil
+}
+
+type listDuplicatesRequest struct {
+	// From and To bound the dates of the duplicates, every duplicate is listed without them
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
+
+// listDuplicates returns the pairs of transactions of the user that are likely the same transaction entered twice
+// and were not dismissed, latest first
+func (s *Server) listDuplicates(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "duplicates.go -> listDuplicates()").Debug()
+	var req listDuplicatesRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	candidates, err := s.repo.ListDuplicatePairs(ctx.Context(), db.ListDuplicatePairsParams{
+		UserID: userID,
+		From:   req.From,
+		To:     req.To,
+		Window: model.DuplicateWindow,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	pairs := []model.DuplicatePair{}
+	for _, pair := range candidates {
+		similarity, ok := model.LikelyDuplicate(pair.Duplicate, pair.Transaction)
+		if !ok {
+			continue
+		}
+		pair.Similarity = similarity
+		pairs = append(pairs, pair)
+	}
+	s.logs.Info("duplicates returned successfully")
+	return ctx.Status(http.StatusOK).JSON(pairs)
+}
+
+// duplicateRequest names a pair of transactions, TransactionID is the one kept when they are merged
+type duplicateRequest struct {
+	TransactionID model.TransactionID `json:"transaction_id" validate:"required,uuid"`
+	DuplicateID   model.TransactionID `json:"duplicate_id" validate:"required,uuid,nefield=TransactionID"`
+}
+
+func (s *Server) parseDuplicateRequest(ctx *fiber.Ctx, req *duplicateRequest) (bool, error) {
+	if err := ctx.BodyParser(req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return false, ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return false, ctx.Status(status).JSON(errs)
+	}
+	return true, nil
+}
+
+// mergeDuplicate deletes the duplicate of a transaction, the transaction kept takes the notes and merchant of the
+// duplicate it does not have
+func (s *Server) mergeDuplicate(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "duplicates.go -> mergeDuplicate()").Debug()
+	var req duplicateRequest
+	if ok, err := s.parseDuplicateRequest(ctx, &req); !ok {
+		return err
+	}
+	transaction, err := s.repo.MergeDuplicateTx(ctx.Context(), db.MergeDuplicateParams{
+		UserID:        ctx.Locals("userID").(model.UserID),
+		TransactionID: req.TransactionID,
+		DuplicateID:   req.DuplicateID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+		case errors.Is(err, db.ErrTransferLeg), errors.Is(err, db.ErrTransactionReconciled):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrNotDuplicate):
+			s.logs.WithError(err).Warn()
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("duplicate merged successfully")
+	return ctx.Status(http.StatusOK).JSON(transaction)
+}
+
+// dismissDuplicate keeps both transactions of a pair, the pair is not listed as duplicates again
+func (s *Server) dismissDuplicate(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "duplicates.go -> dismissDuplicate()").Debug()
+	var req duplicateRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if ok, err := s.parseDuplicateRequest(ctx, &req); !ok {
+		return err
+	}
+	for _, id := range []model.TransactionID{req.TransactionID, req.DuplicateID} {
+		transaction, err := s.repo.GetTransactionByID(ctx.Context(), id)
+		if err == nil && transaction.UserID != userID {
+			err = sql.ErrNoRows
+		}
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				s.logs.WithError(err).Warn()
+				status = http.StatusNotFound
+				return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+			}
+			s.logs.WithError(err).Warn()
+			status = http.StatusInternalServerError
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+	}
+	err := s.repo.DismissDuplicate(ctx.Context(), db.DismissDuplicateParams{
+		UserID:        userID,
+		TransactionID: req.TransactionID,
+		DuplicateID:   req.DuplicateID,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("duplicate dismissed successfully")
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(duplicateDismissedMSG, time.Now().Format(time.ANSIC))})
+}
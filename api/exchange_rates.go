@@ -0,0 +1,201 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/rates"
+	"FiberFinanceAPI/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"time"
+)
+
+var noExchangeRates = errors.New("rates file holds no exchange rates")
+
+type loadExchangeRatesRequest struct {
+	// Format is xml or csv, xml when it is not given
+	Format rates.Format `form:"format" validate:"omitempty,oneof=xml csv"`
+	// Base is the currency the rates of the file are quoted against, the ECB quotes against EUR
+	Base utils.CurrencyCode `form:"base" validate:"omitempty,currency"`
+}
+
+// loadExchangeRates stores the daily rates of an uploaded ECB-style rates file, replacing rates already stored
+// for the same day
+func (s *Server) loadExchangeRates(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "exchange_rates.go -> loadExchangeRates()").Debug()
+	var req loadExchangeRatesRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if req.Format == "" {
+		req.Format = rates.XML
+	}
+	if req.Base == "" {
+		req.Base = rates.ECBBase
+	}
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		s.logs.WithError(err).Warn("rates file not provided")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	file, err := header.Open()
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			s.logs.WithError(closeErr).Warn("could not close the uploaded file")
+		}
+	}()
+	exchangeRates, err := rates.Parse(req.Format, file, req.Base)
+	if err != nil {
+		s.logs.WithError(err).Warn("cannot parse rates")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(exchangeRates) == 0 {
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, noExchangeRates))
+	}
+	stored, err := s.repo.ImportExchangeRatesTx(ctx.Context(), exchangeRates)
+	if err != nil {
+		s.logs.WithError(err).Warn("could not store exchange rates")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("exchange rates loaded successfully")
+	return ctx.Status(http.StatusCreated).JSON(fiber.Map{"stored": stored})
+}
+
+type updateBaseCurrencyRequest struct {
+	BaseCurrency utils.CurrencyCode `json:"base_currency" validate:"required,currency"`
+}
+
+func (s *Server) updateBaseCurrency(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "exchange_rates.go -> updateBaseCurrency()").Debug()
+	var req updateBaseCurrencyRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	user, err := s.repo.UpdateBaseCurrency(ctx.Context(), db.UpdateBaseCurrencyParams{
+		UserID:       userID,
+		BaseCurrency: req.BaseCurrency,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, userNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("base currency updated successfully")
+	return ctx.Status(http.StatusOK).JSON(user)
+}
+
+// netWorthResponse is the balance of every account of a user and their total in the base currency, accounts
+// without a rate to the base currency are left out of the total and listed in MissingRates
+type netWorthResponse struct {
+	BaseCurrency utils.CurrencyCode       `json:"base_currency"`
+	Date         time.Time                `json:"date"`
+	Accounts     []model.ConvertedBalance `json:"accounts"`
+	Total        int64                    `json:"total"`
+	MissingRates []model.AccountID        `json:"missing_rates"`
+}
+
+type netWorthRequest struct {
+	// Date is the day whose rates are used, today when it is not given
+	Date time.Time `query:"date"`
+}
+
+// netWorth totals the balances of all the accounts of the user in their base currency
+func (s *Server) netWorth(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "exchange_rates.go -> netWorth()").Debug()
+	var req netWorthRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if req.Date.IsZero() {
+		req.Date = time.Now()
+	}
+	base, err := s.baseCurrency(ctx.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, userNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	balances, err := s.repo.ListConvertedBalances(ctx.Context(), db.ListConvertedBalancesParams{
+		UserID:       userID,
+		BaseCurrency: base,
+		Date:         req.Date,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(balances) == 0 {
+		s.logs.WithError(accountNotFound).Warn()
+		status = http.StatusNotFound
+		return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+	}
+	response := netWorthResponse{
+		BaseCurrency: base,
+		Date:         req.Date,
+		Accounts:     balances,
+		MissingRates: []model.AccountID{},
+	}
+	for _, balance := range balances {
+		if balance.ConvertedBalance == nil {
+			response.MissingRates = append(response.MissingRates, balance.AccountID)
+			continue
+		}
+		response.Total += *balance.ConvertedBalance
+	}
+	s.logs.Info("net worth returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+// baseCurrency returns the currency totals of the user are converted to, USD until a user who signed up without
+// one opens their first account
+func (s *Server) baseCurrency(ctx context.Context, userID model.UserID) (utils.CurrencyCode, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.BaseCurrency == "" {
+		return utils.USD, nil
+	}
+	return user.BaseCurrency, nil
+}
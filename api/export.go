@@ -0,0 +1,132 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/exporter"
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"time"
+)
+
+// exportPageSize is the number of transactions read from the database per page of an export
+const exportPageSize = 500
+
+type exportRequest struct {
+	// Format defaults to json
+	Format exporter.Format `query:"format" validate:"omitempty,oneof=csv json beancount"`
+}
+
+// exportUserData streams all the accounts, categories, merchants and transactions of the user as a download.
+// The reference data and the first page of transactions are read before the response starts, once it is
+// streaming errors can only be logged and cut the download short
+func (s *Server) exportUserData(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "export.go -> exportUserData()").Debug()
+	var req exportRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if req.Format == "" {
+		req.Format = exporter.JSON
+	}
+
+	data := exporter.Data{ExportedAt: time.Now().UTC()}
+	var err error
+	if data.Accounts, err = s.repo.ExportAccounts(ctx.Context(), userID); err == nil {
+		if data.Categories, err = s.repo.ExportCategories(ctx.Context(), userID); err == nil {
+			data.Merchants, err = s.repo.ExportMerchants(ctx.Context(), userID)
+		}
+	}
+	var page []model.Transaction
+	if err == nil {
+		page, err = s.exportPage(ctx.Context(), db.ExportTransactionsParams{UserID: userID, Limit: exportPageSize})
+	}
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(page) > 0 {
+		data.Since = page[0].Date
+	}
+
+	ctx.Set(fiber.HeaderContentType, exporter.ContentType(req.Format))
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="export-%s.%s"`,
+		data.ExportedAt.Format("20060102"), exporter.Extension(req.Format)))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// the request context is recycled once the handler returns, the stream runs after that
+		if err := s.writeExport(context.Background(), w, req.Format, data, page); err != nil {
+			s.logs.WithError(err).Error("export cut short")
+		}
+		if err := w.Flush(); err != nil {
+			s.logs.WithError(err).Warn()
+		}
+	})
+	return nil
+}
+
+// writeExport writes the export, page is the first page of transactions and the rest is read as it goes
+func (s *Server) writeExport(ctx context.Context, w *bufio.Writer, format exporter.Format, data exporter.Data, page []model.Transaction) error {
+	writer, err := exporter.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+	if err = writer.Begin(data); err != nil {
+		return err
+	}
+	for len(page) > 0 {
+		if err = writer.Transactions(page); err != nil {
+			return err
+		}
+		if len(page) < exportPageSize {
+			break
+		}
+		last := page[len(page)-1]
+		page, err = s.exportPage(ctx, db.ExportTransactionsParams{
+			UserID:    last.UserID,
+			AfterDate: last.Date,
+			AfterID:   last.ID,
+			Limit:     exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// exportPage reads a page of transactions with their splits
+func (s *Server) exportPage(ctx context.Context, args db.ExportTransactionsParams) ([]model.Transaction, error) {
+	transactions, err := s.repo.ExportTransactions(ctx, args)
+	if err != nil || len(transactions) == 0 {
+		return nil, err
+	}
+	ids := make([]model.TransactionID, 0, len(transactions))
+	for _, transaction := range transactions {
+		ids = append(ids, transaction.ID)
+	}
+	splits, err := s.repo.ListSplitsByTransactionIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[model.TransactionID]int, len(transactions))
+	for i, transaction := range transactions {
+		index[transaction.ID] = i
+	}
+	for _, split := range splits {
+		i := index[split.TransactionID]
+		transactions[i].Splits = append(transactions[i].Splits, split)
+	}
+	return transactions, nil
+}
@@ -0,0 +1,478 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/importer"
+	"FiberFinanceAPI/suggest"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"net/http"
+	"time"
+)
+
+var (
+	importMappingNotFound   = errors.New("import mapping(s) not found or deleted")
+	importMappingDeletedMSG = "import mapping successfully deleted at %s"
+	emptyStatement          = errors.New("statement has no transactions")
+	mappingRequired         = errors.New("csv statements need a mapping_id or a mapping")
+)
+
+type importMappingRequest struct {
+	Name    string              `json:"name" validate:"required"`
+	Mapping importer.CSVMapping `json:"mapping"`
+}
+
+func (s *Server) createImportMapping(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "imports.go -> createImportMapping()").Debug()
+	var req importMappingRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if err := req.Mapping.Validate(); err != nil {
+		s.logs.WithError(err).Warn("mapping is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	args := db.CreateImportMappingParams{
+		UserID:  userID,
+		Name:    req.Name,
+		Mapping: req.Mapping,
+	}
+	mapping, err := s.repo.CreateImportMapping(ctx.Context(), args)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			s.logs.WithField(string(pqErr.Code), pqErr.Code.Name()).Debug("postgres error codes")
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				status = http.StatusForbidden
+				return ctx.Status(status).JSON(errorResponse(status, err))
+			}
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Import mapping created successfully")
+	return ctx.Status(http.StatusCreated).JSON(mapping)
+}
+
+func (s *Server) listImportMappings(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "imports.go -> listImportMappings()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	mappings, err := s.repo.ListImportMappings(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(mappings) == 0 {
+		status = http.StatusNotFound
+		return ctx.Status(status).JSON(errorResponse(status, importMappingNotFound))
+	}
+	s.logs.Info("import mappings returned successfully")
+	return ctx.Status(http.StatusOK).JSON(mappings)
+}
+
+func (s *Server) deleteImportMapping(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "imports.go -> deleteImportMapping()").Debug()
+	mappingID := model.ImportMappingID(ctx.Params("mappingID"))
+	if mappingID == "" {
+		s.logs.WithField("mappingID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("mappingID not provided")))
+	}
+
+	userID := ctx.Locals("userID").(model.UserID)
+	args := db.DeleteImportMappingParams{
+		MappingID: mappingID,
+		UserID:    userID,
+	}
+	deletedAt, err := s.repo.DeleteImportMapping(ctx.Context(), args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, importMappingNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("import mapping deleted successfully")
+
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(importMappingDeletedMSG, deletedAt.Format(time.ANSIC))})
+}
+
+// importRequest is sent as a multipart form along with the statement in its file field
+type importRequest struct {
+	// Format is csv, ofx, qfx, qif, mpesa or mpesa_text, csv when it is not given
+	Format importer.Format `form:"format" validate:"omitempty,oneof=csv ofx qfx qif mpesa mpesa_text"`
+	// MappingID is a saved mapping, Mapping a JSON mapping sent with the statement, CSV statements need one
+	MappingID model.ImportMappingID `form:"mapping_id"`
+	Mapping   string                `form:"mapping"`
+	// CategoryID is given to the imported transactions no rule gives a category and no category is suggested for
+	CategoryID model.CategoryID `form:"category_id" validate:"required"`
+	// FeeCategoryID is the category of the charges a statement lists on their own, CategoryID when not given
+	FeeCategoryID model.CategoryID `form:"fee_category_id"`
+	// CreateMerchants links the transactions to a merchant named after their payee, creating the merchants
+	// the user does not have yet. M-Pesa statements always do
+	CreateMerchants bool `form:"create_merchants"`
+	// DayFirst reads QIF dates as day/month/year
+	DayFirst bool `form:"day_first"`
+	// AdjustBalance adds a transaction making up any difference between the account balance after the import
+	// and the ledger balance of an OFX statement
+	AdjustBalance bool `form:"adjust_balance"`
+	// NoSuggestions leaves the categories learnt from the earlier transactions of the user out of the import
+	NoSuggestions bool `form:"no_suggestions"`
+	// Commit creates the transactions, without it the transactions that would be created are returned
+	Commit bool `form:"commit"`
+}
+
+// importStatement parses a statement uploaded for an account and either previews or creates its transactions
+func (s *Server) importStatement(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "imports.go -> importStatement()").Debug()
+	var req importRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	accountID := model.AccountID(ctx.Params("accountID"))
+	if accountID == "" {
+		s.logs.WithField("accountID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("accountID not provided")))
+	}
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if req.Format == "" {
+		req.Format = importer.CSV
+	}
+	if req.FeeCategoryID == "" {
+		req.FeeCategoryID = req.CategoryID
+	}
+	if req.Format == importer.MPesa || req.Format == importer.MPesaText {
+		req.CreateMerchants = true
+	}
+
+	account, err := s.repo.GetAccountByID(ctx.Context(), accountID)
+	if err == nil && account.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+
+	opts := importer.Options{Currency: account.Currency, DayFirst: req.DayFirst}
+	if req.Format == importer.CSV {
+		switch {
+		case req.MappingID != "":
+			mapping, err := s.repo.GetImportMappingByID(ctx.Context(), req.MappingID)
+			if err == nil && mapping.UserID != userID {
+				err = sql.ErrNoRows
+			}
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					s.logs.WithError(err).Warn()
+					status = http.StatusNotFound
+					return ctx.Status(status).JSON(errorResponse(status, importMappingNotFound))
+				}
+				s.logs.WithError(err).Warn()
+				status = http.StatusInternalServerError
+				return ctx.Status(status).JSON(errorResponse(status, err))
+			}
+			opts.Mapping = mapping.Mapping
+		case req.Mapping != "":
+			if err = json.Unmarshal([]byte(req.Mapping), &opts.Mapping); err != nil {
+				s.logs.WithError(err).Warn("cannot decode mapping")
+				status = http.StatusUnprocessableEntity
+				return ctx.Status(status).JSON(errorResponse(status, err))
+			}
+		default:
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, mappingRequired))
+		}
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		s.logs.WithError(err).Warn("statement file not provided")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	file, err := header.Open()
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			s.logs.WithError(closeErr).Warn("could not close the uploaded file")
+		}
+	}()
+	statement, err := importer.Parse(req.Format, file, opts)
+	if err != nil {
+		s.logs.WithError(err).Warn("cannot parse statement")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(statement.Lines) == 0 {
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, emptyStatement))
+	}
+
+	rules, err := s.repo.ListRules(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	var classifier *suggest.Classifier
+	if !req.NoSuggestions {
+		classifier, err = s.categoryClassifier(ctx, userID)
+		if err != nil {
+			s.logs.WithError(err).Warn()
+			status = http.StatusInternalServerError
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+	}
+	args := db.ImportTransactionsParams{
+		UserID:               userID,
+		AccountID:            accountID,
+		Transactions:         statementTransactions(statement.Lines, req, accountID, rules, classifier),
+		AdjustmentCategoryID: req.CategoryID,
+	}
+	if req.AdjustBalance && statement.LedgerBalance != nil {
+		args.Balance = &statement.LedgerBalance.Amount
+		args.BalanceDate = statement.LedgerBalance.Date
+	}
+	if !req.Commit {
+		preview, err := s.previewImport(ctx, account, args)
+		if err != nil {
+			s.logs.WithError(err).Warn("could not preview statement")
+			status = http.StatusInternalServerError
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		preview.LedgerBalance = statement.LedgerBalance
+		preview.Merchants = importMerchants(args)
+		if preview.PossibleDuplicates, err = s.possibleDuplicates(ctx, accountID, preview.Transactions); err != nil {
+			s.logs.WithError(err).Warn("could not look for duplicates")
+		}
+		s.logs.Info("statement previewed successfully")
+		return ctx.Status(http.StatusOK).JSON(preview)
+	}
+	result, err := s.repo.ImportTransactionsTx(ctx.Context(), args)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrAccountNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		case errors.Is(err, db.ErrAccountNotOwned):
+			s.logs.WithError(err).Warn()
+			status = http.StatusForbidden
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn("could not import statement")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if result.PossibleDuplicates, err = s.possibleDuplicates(ctx, accountID, result.Transactions); err != nil {
+		// the statement is imported either way, it is only returned without its possible duplicates
+		s.logs.WithError(err).Warn("could not look for duplicates")
+	}
+	s.logs.Info("statement imported successfully")
+	return ctx.Status(http.StatusCreated).JSON(importResponse{
+		Committed:     true,
+		ImportResult:  result,
+		LedgerBalance: statement.LedgerBalance,
+		Merchants:     importMerchants(args),
+	})
+}
+
+// importResponse is returned for both previews and imports, a preview shows what an import would do
+type importResponse struct {
+	Committed bool `json:"committed"`
+	model.ImportResult
+	// LedgerBalance is the balance the statement gives the account, when the format carries one
+	LedgerBalance *importer.Balance `json:"ledger_balance,omitempty"`
+	// Merchants are the names of the merchants the transactions are linked to, created if the user had none
+	Merchants []string `json:"merchants,omitempty"`
+}
+
+// statementTransactions turns the lines of a statement into transactions, money out of the account is an expense
+// and money in an income. The rules of the user give them a category, merchant and notes, the lines no rule
+// gives a category go in the category the classifier is sure of and otherwise in the categories of the request.
+// Charges are always filed under the fee category
+func statementTransactions(lines []importer.Line, req importRequest, accountID model.AccountID, rules []model.Rule, classifier *suggest.Classifier) []db.ImportTransactionParams {
+	var transactions []db.ImportTransactionParams
+	for _, line := range lines {
+		transactionType, amount := model.Income, line.Amount
+		if amount < 0 {
+			transactionType, amount = model.Expense, -amount
+		}
+		name := line.Description
+		if name == "" {
+			name = line.Payee
+		}
+		transaction := db.ImportTransactionParams{
+			Transaction: db.CreateTransactionParams{
+				AccountID:       accountID,
+				Name:            name,
+				TransactionType: transactionType,
+				Amount:          amount,
+				Date:            line.Date,
+				// the lines of a statement are on the account statement already
+				Status: model.Cleared,
+			},
+			ExternalID: line.ExternalID,
+		}
+		db.ApplyTransactionRules(rules, &transaction.Transaction)
+		if transaction.Transaction.CategoryID == "" && !line.Fee {
+			transaction.Transaction.CategoryID = suggestedCategory(classifier, name, line.Payee)
+		}
+		if transaction.Transaction.CategoryID == "" {
+			transaction.Transaction.CategoryID = req.CategoryID
+			if line.Fee {
+				transaction.Transaction.CategoryID = req.FeeCategoryID
+			}
+		}
+		if req.CreateMerchants && !line.Fee && transaction.Transaction.MerchantID == "" {
+			transaction.MerchantName = line.Payee
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions
+}
+
+// previewImport works out what importing the statement would do to the account without writing anything,
+// the transactions it would create have no ID
+func (s *Server) previewImport(ctx *fiber.Ctx, account model.Account, args db.ImportTransactionsParams) (importResponse, error) {
+	var ids []string
+	for _, arg := range args.Transactions {
+		if arg.ExternalID != "" {
+			ids = append(ids, arg.ExternalID)
+		}
+	}
+	imported := map[string]bool{}
+	if len(ids) > 0 {
+		existing, err := s.repo.ListImportedExternalIDs(ctx.Context(), db.ListImportedExternalIDsParams{
+			AccountID:   account.AccountID,
+			ExternalIDs: ids,
+		})
+		if err != nil {
+			return importResponse{}, err
+		}
+		for _, id := range existing {
+			imported[id] = true
+		}
+	}
+	preview := importResponse{
+		ImportResult: model.ImportResult{
+			Transactions: []model.Transaction{},
+			Duplicates:   []string{},
+			Balance:      account.Balance,
+		},
+	}
+	for _, arg := range args.Transactions {
+		if arg.ExternalID != "" && imported[arg.ExternalID] {
+			preview.Duplicates = append(preview.Duplicates, arg.ExternalID)
+			continue
+		}
+		imported[arg.ExternalID] = arg.ExternalID != ""
+		preview.Transactions = append(preview.Transactions, model.Transaction{
+			UserID:          args.UserID,
+			AccountID:       args.AccountID,
+			CategoryID:      arg.Transaction.CategoryID,
+			Name:            arg.Transaction.Name,
+			TransactionType: arg.Transaction.TransactionType,
+			Amount:          arg.Transaction.Amount,
+			Notes:           arg.Transaction.Notes,
+			Date:            arg.Transaction.Date,
+			MerchantID:      arg.Transaction.MerchantID,
+			Currency:        account.Currency,
+			Status:          model.Cleared,
+		})
+		preview.Balance += arg.Transaction.TransactionType.SignedAmount(arg.Transaction.Amount)
+	}
+	if args.Balance == nil {
+		return preview, nil
+	}
+	// the statement balance is compared with the balance of the account on the date it is given for
+	date, balance := args.BalanceDate, preview.Balance
+	if date.IsZero() {
+		date = time.Now()
+	} else {
+		after, err := s.repo.GetAccountAmountAfter(ctx.Context(), db.GetAccountAmountAfterParams{
+			AccountID: account.AccountID,
+			Date:      date,
+		})
+		if err != nil {
+			return importResponse{}, err
+		}
+		balance -= after
+		for _, transaction := range preview.Transactions {
+			if transaction.Date.After(date) {
+				balance -= transaction.TransactionType.SignedAmount(transaction.Amount)
+			}
+		}
+	}
+	if *args.Balance != balance {
+		adjustment := model.Transaction{
+			UserID:          args.UserID,
+			AccountID:       args.AccountID,
+			CategoryID:      args.AdjustmentCategoryID,
+			Name:            db.AdjustmentName,
+			TransactionType: model.Income,
+			Amount:          *args.Balance - balance,
+			Date:            date,
+			Currency:        account.Currency,
+			Status:          model.Cleared,
+		}
+		if adjustment.Amount < 0 {
+			adjustment.TransactionType, adjustment.Amount = model.Expense, -adjustment.Amount
+		}
+		preview.Adjustment = &adjustment
+		preview.Balance += *args.Balance - balance
+	}
+	return preview, nil
+}
+
+// importMerchants returns the names of the merchants an import links transactions to
+func importMerchants(args db.ImportTransactionsParams) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, arg := range args.Transactions {
+		if arg.MerchantName != "" && !seen[arg.MerchantName] {
+			seen[arg.MerchantName] = true
+			names = append(names, arg.MerchantName)
+		}
+	}
+	return names
+}
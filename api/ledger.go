@@ -0,0 +1,131 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
+	"database/sql"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"sort"
+)
+
+// trialBalanceResponse lists what has been posted to every ledger account of a user, the books balance when the
+// debits equal the credits in every currency and every account balance matches its postings. The converted totals
+// are in the base currency of the user at the rates of the days the postings were made, postings without a rate
+// are left out
+type trialBalanceResponse struct {
+	Lines                []model.TrialBalanceLine `json:"lines"`
+	Totals               []trialBalanceTotal      `json:"totals"`
+	BaseCurrency         utils.CurrencyCode       `json:"base_currency"`
+	ConvertedTotalDebit  int64                    `json:"converted_total_debit"`
+	ConvertedTotalCredit int64                    `json:"converted_total_credit"`
+	MissingRates         int64                    `json:"missing_rates"`
+	Balanced             bool                     `json:"balanced"`
+	Imbalances           []model.AccountImbalance `json:"account_imbalances"`
+}
+
+// trialBalanceTotal is what was debited and credited in one currency
+type trialBalanceTotal struct {
+	Currency utils.CurrencyCode `json:"currency"`
+	Debit    int64              `json:"debit"`
+	Credit   int64              `json:"credit"`
+}
+
+func newTrialBalanceResponse(base utils.CurrencyCode, lines []model.TrialBalanceLine, imbalances []model.AccountImbalance) trialBalanceResponse {
+	response := trialBalanceResponse{
+		Lines:        lines,
+		Totals:       []trialBalanceTotal{},
+		BaseCurrency: base,
+		Imbalances:   imbalances,
+	}
+	totals := map[utils.CurrencyCode]int{}
+	for _, line := range lines {
+		i, ok := totals[line.Currency]
+		if !ok {
+			i = len(response.Totals)
+			totals[line.Currency] = i
+			response.Totals = append(response.Totals, trialBalanceTotal{Currency: line.Currency})
+		}
+		response.Totals[i].Debit += line.Debit
+		response.Totals[i].Credit += line.Credit
+		response.ConvertedTotalDebit += line.ConvertedDebit
+		response.ConvertedTotalCredit += line.ConvertedCredit
+		response.MissingRates += line.MissingRates
+	}
+	sort.Slice(response.Totals, func(i, j int) bool { return response.Totals[i].Currency < response.Totals[j].Currency })
+	response.Balanced = len(imbalances) == 0
+	for _, total := range response.Totals {
+		if total.Debit != total.Credit {
+			response.Balanced = false
+		}
+	}
+	return response
+}
+
+func (s *Server) trialBalance(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "ledger.go -> trialBalance()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+
+	base, err := s.baseCurrency(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	lines, err := s.repo.TrialBalance(ctx.Context(), db.TrialBalanceParams{
+		UserID:       userID,
+		BaseCurrency: base,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	imbalances, err := s.repo.ListAccountImbalances(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := newTrialBalanceResponse(base, lines, imbalances)
+	if !response.Balanced {
+		s.logs.WithField("userID", userID).Warn("books do not balance")
+	}
+	s.logs.Info("trial balance returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+func (s *Server) listTransactionPostings(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "ledger.go -> listTransactionPostings()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	transactionID := model.TransactionID(ctx.Params("transactionID"))
+	if transactionID == "" {
+		s.logs.WithField("transactionID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("transactionID not provided")))
+	}
+	transaction, err := s.repo.GetTransactionByID(ctx.Context(), transactionID)
+	if err == nil && transaction.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	postings, err := s.repo.ListPostingsByTransactionID(ctx.Context(), transactionID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("postings returned successfully")
+	return ctx.Status(http.StatusOK).JSON(postings)
+}
@@ -125,8 +125,9 @@ func (s *Server) updateMerchant(ctx *fiber.Ctx) error {
 }
 
 type listMerchantRequest struct {
-	PageID   int32 `query:"page_id" validate:"required,min=1"`
-	PageSize int32 `query:"page_size" validate:"required,min=5,max=10"`
+	// Cursor is the next_cursor of the previous page, the first page is returned without it
+	Cursor   string `query:"cursor"`
+	PageSize int32  `query:"page_size" validate:"omitempty,min=1,max=100"`
 }
 
 func (s *Server) listMerchants(ctx *fiber.Ctx) error {
@@ -144,11 +145,16 @@ func (s *Server) listMerchants(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
 	args := db.ListMerchantParams{
 		UserID: userID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  size + 1,
+		Cursor: cursor,
 	}
 	merchants, err := s.repo.ListMerchants(ctx.Context(), args)
 	if err != nil {
@@ -156,13 +162,24 @@ func (s *Server) listMerchants(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	if len(merchants) == 0 {
-		status = http.StatusNotFound
-		return ctx.Status(status).JSON(errorResponse(status, merchantNotFound))
+	total, err := s.repo.CountMerchants(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := page{Total: total}
+	if len(merchants) > int(size) {
+		merchants = merchants[:size]
+		last := merchants[size-1]
+		response.NextCursor = model.Cursor{Date: last.CreatedAt, ID: string(last.ID)}.Encode()
 	}
+	if merchants == nil {
+		merchants = []model.Merchant{}
+	}
+	response.Items = merchants
 	s.logs.Info("merchants returned successfully")
-
-	return ctx.Status(http.StatusOK).JSON(merchants)
+	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 func (s *Server) deleteMerchant(ctx *fiber.Ctx) error {
@@ -174,12 +191,23 @@ func (s *Server) deleteMerchant(ctx *fiber.Ctx) error {
 		return ctx.Status(status).JSON(errorResponse(status, errors.New("merchantID not provided")))
 	}
 
-	deletedAt, err := s.repo.DeleteMerchant(ctx.Context(), model.MerchantID(merchantID))
+	// transactions of the merchant are moved to reassign_to before it is deleted
+	args := db.DeleteMerchantParams{
+		MerchantID: model.MerchantID(merchantID),
+		UserID:     ctx.Locals("userID").(model.UserID),
+		ReassignTo: model.MerchantID(ctx.Query("reassign_to")),
+	}
+	deletedAt, err := s.repo.DeleteMerchantTx(ctx.Context(), args)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		switch {
+		case errors.Is(err, sql.ErrNoRows), errors.Is(err, db.ErrMerchantNotFound):
 			s.logs.WithError(err).Warn()
 			status = http.StatusNotFound
 			return ctx.Status(status).JSON(errorResponse(status, merchantNotFound))
+		case errors.Is(err, db.ErrMerchantInUse):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
 		}
 		s.logs.WithError(err).Warn()
 		status = http.StatusInternalServerError
@@ -189,3 +217,118 @@ func (s *Server) deleteMerchant(ctx *fiber.Ctx) error {
 
 	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(merchantDeletedMSG, deletedAt.Format(time.ANSIC))})
 }
+
+func (s *Server) listMerchantTransactions(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "merchant_api.go -> listMerchantTransactions()").Debug()
+	var req listTransactionsRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	merchantID := model.MerchantID(ctx.Params("merchantID"))
+	if merchantID == "" {
+		s.logs.WithField("merchantID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("merchantID not provided")))
+	}
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	merchant, err := s.repo.GetMerchantByID(ctx.Context(), merchantID)
+	if err == nil && merchant.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, merchantNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
+	args := db.ListTxByMerchantIDParams{
+		MerchantID: merchantID,
+		Limit:      size + 1,
+		From:       req.From,
+		To:         req.To,
+		Cursor:     cursor,
+	}
+	transactions, err := s.repo.ListTransactionsByMerchantID(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	total, err := s.repo.CountMerchantTransactions(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := page{Total: total}
+	if len(transactions) > int(size) {
+		transactions = transactions[:size]
+		last := transactions[size-1]
+		response.NextCursor = model.Cursor{Date: last.Date, ID: string(last.ID)}.Encode()
+	}
+	if transactions == nil {
+		transactions = []model.Transaction{}
+	}
+	response.Items = transactions
+	s.logs.Info("merchant transactions returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+type merchantSpendRequest struct {
+	From time.Time `query:"from" validate:"required"`
+	To   time.Time `query:"to" validate:"required,gtfield=From"`
+}
+
+func (s *Server) listMerchantSpend(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "merchant_api.go -> listMerchantSpend()").Debug()
+	var req merchantSpendRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	base, err := s.baseCurrency(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	args := db.ListMerchantSpendParams{
+		UserID:       userID,
+		From:         req.From,
+		To:           req.To,
+		BaseCurrency: base,
+	}
+	spends, err := s.repo.ListMerchantSpend(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("merchant spend returned successfully")
+	return ctx.Status(http.StatusOK).JSON(spends)
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+// defaultPageSize is the size of a page when the request does not give one, requests ask for at most 100 rows
+const defaultPageSize = 20
+
+// page is the envelope lists are returned in. NextCursor fetches the page that follows and is left out of the
+// last one, Total counts the rows of every page
+type page struct {
+	Items      interface{} `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	Total      int64       `json:"total"`
+}
+
+// parseCursor reads the cursor of a list request, it writes the error response itself and reports whether the
+// request can go on
+func (s *Server) parseCursor(ctx *fiber.Ctx, raw string) (model.Cursor, bool, error) {
+	cursor, err := model.ParseCursor(raw)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusBadRequest
+		return cursor, false, ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	return cursor, true, nil
+}
+
+// pageSize returns the size of the page a request asks for, list queries fetch one row more to tell whether
+// another page follows
+func pageSize(size int32) int32 {
+	if size == 0 {
+		return defaultPageSize
+	}
+	return size
+}
@@ -0,0 +1,355 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+var (
+	reconciliationNotFound   = errors.New("reconciliation not found or cancelled")
+	reconciliationOpen       = errors.New("account already has an open reconciliation, complete or cancel it first")
+	reconciliationDeletedMSG = "reconciliation successfully cancelled at %s"
+)
+
+// reconciliationResponse is a reconciliation with the transactions it is worked out from. Those of an open
+// reconciliation are the unreconciled transactions on its statement and those of a completed one the transactions
+// it reconciled. ReconciledBalance is the balance of the transactions reconciled before, ClearedBalance adds the
+// cleared transactions to it and Difference is what the statement balance is away from ClearedBalance
+type reconciliationResponse struct {
+	Reconciliation    model.Reconciliation `json:"reconciliation"`
+	ReconciledBalance utils.Money          `json:"reconciled_balance"`
+	ClearedBalance    utils.Money          `json:"cleared_balance"`
+	Difference        utils.Money          `json:"difference"`
+	Transactions      []model.Transaction  `json:"transactions"`
+}
+
+// reconciliationSession works out where a reconciliation stands, the cleared transactions of an open one are taken
+// as the ones on the statement
+func (s *Server) reconciliationSession(ctx *fiber.Ctx, reconciliation model.Reconciliation) (reconciliationResponse, error) {
+	currency := reconciliation.Currency
+	response := reconciliationResponse{Reconciliation: reconciliation}
+	if reconciliation.Status == model.ReconciliationCompleted {
+		transactions, err := s.repo.ListReconciliationTransactions(ctx.Context(), reconciliation.ID)
+		if err != nil {
+			return response, err
+		}
+		response.ReconciledBalance = utils.NewMoney(reconciliation.OpeningBalance, currency)
+		response.ClearedBalance = utils.NewMoney(reconciliation.StatementBalance, currency)
+		response.Difference = utils.NewMoney(0, currency)
+		response.Transactions = transactions
+		if response.Transactions == nil {
+			response.Transactions = []model.Transaction{}
+		}
+		return response, nil
+	}
+
+	account, err := s.repo.GetAccountByID(ctx.Context(), reconciliation.AccountID)
+	if err != nil {
+		return response, err
+	}
+	unreconciled, err := s.repo.GetUnreconciledAmount(ctx.Context(), reconciliation.AccountID)
+	if err != nil {
+		return response, err
+	}
+	transactions, err := s.repo.ListUnreconciledTransactions(ctx.Context(), db.ListUnreconciledTransactionsParams{
+		AccountID:     reconciliation.AccountID,
+		StatementDate: reconciliation.StatementDate,
+	})
+	if err != nil {
+		return response, err
+	}
+	reconciled := account.Balance - unreconciled
+	cleared := reconciled
+	for _, transaction := range transactions {
+		if transaction.Status == model.Cleared {
+			cleared += transaction.TransactionType.SignedAmount(transaction.Amount)
+		}
+	}
+	response.ReconciledBalance = utils.NewMoney(reconciled, currency)
+	response.ClearedBalance = utils.NewMoney(cleared, currency)
+	response.Difference = utils.NewMoney(reconciliation.StatementBalance-cleared, currency)
+	response.Transactions = transactions
+	if response.Transactions == nil {
+		response.Transactions = []model.Transaction{}
+	}
+	return response, nil
+}
+
+// ownedReconciliation reads the reconciliation of the request, a reconciliation of another user or account is
+// reported as not found. It writes the error response itself and reports whether the request can go on
+func (s *Server) ownedReconciliation(ctx *fiber.Ctx) (model.Reconciliation, bool, error) {
+	userID := ctx.Locals("userID").(model.UserID)
+	reconciliationID := model.ReconciliationID(ctx.Params("reconciliationID"))
+	if reconciliationID == "" {
+		s.logs.WithField("reconciliationID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return model.Reconciliation{}, false, ctx.Status(status).JSON(errorResponse(status, errors.New("reconciliationID not provided")))
+	}
+	reconciliation, err := s.repo.GetReconciliationByID(ctx.Context(), reconciliationID)
+	if err == nil && (reconciliation.UserID != userID || reconciliation.AccountID != model.AccountID(ctx.Params("accountID"))) {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return reconciliation, false, ctx.Status(status).JSON(errorResponse(status, reconciliationNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return reconciliation, false, ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	return reconciliation, true, nil
+}
+
+type createReconciliationRequest struct {
+	// StatementDate is the last day of the statement, given as YYYY-MM-DD
+	StatementDate    string `json:"statement_date" validate:"required,datetime=2006-01-02"`
+	StatementBalance int64  `json:"statement_balance"`
+}
+
+// createReconciliation opens a reconciliation of an account against the ending balance of a statement and returns
+// the unreconciled transactions on the statement with how far their balance is from it
+func (s *Server) createReconciliation(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "reconciliations.go -> createReconciliation()").Debug()
+	var req createReconciliationRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	accountID := model.AccountID(ctx.Params("accountID"))
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	statementDate, _ := time.Parse(model.ReconciliationDateLayout, req.StatementDate)
+
+	account, err := s.repo.GetAccountByID(ctx.Context(), accountID)
+	if err == nil && account.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	reconciliation, err := s.repo.CreateReconciliation(ctx.Context(), db.CreateReconciliationParams{
+		UserID:           userID,
+		AccountID:        accountID,
+		StatementDate:    statementDate,
+		StatementBalance: req.StatementBalance,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			s.logs.WithField(string(pqErr.Code), pqErr.Code.Name()).Debug("postgres error codes")
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				status = http.StatusForbidden
+				return ctx.Status(status).JSON(errorResponse(status, reconciliationOpen))
+			}
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response, err := s.reconciliationSession(ctx, reconciliation)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("reconciliation created successfully")
+	return ctx.Status(http.StatusCreated).JSON(response)
+}
+
+// getReconciliation returns where a reconciliation stands, worked out again from the transactions of its account
+func (s *Server) getReconciliation(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "reconciliations.go -> getReconciliation()").Debug()
+	reconciliation, ok, err := s.ownedReconciliation(ctx)
+	if !ok {
+		return err
+	}
+	response, err := s.reconciliationSession(ctx, reconciliation)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("reconciliation returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+type listReconciliationsRequest struct {
+	// Cursor is the next_cursor of the previous page, the first page is returned without it
+	Cursor   string `query:"cursor"`
+	PageSize int32  `query:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// listReconciliations returns the reconciliation history of an account, latest statement first
+func (s *Server) listReconciliations(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "reconciliations.go -> listReconciliations()").Debug()
+	var req listReconciliationsRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	accountID := model.AccountID(ctx.Params("accountID"))
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+
+	account, err := s.repo.GetAccountByID(ctx.Context(), accountID)
+	if err == nil && account.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
+	reconciliations, err := s.repo.ListReconciliations(ctx.Context(), db.ListReconciliationsParams{
+		AccountID: accountID,
+		Limit:     size + 1,
+		Cursor:    cursor,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	total, err := s.repo.CountReconciliations(ctx.Context(), accountID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := page{Total: total}
+	if len(reconciliations) > int(size) {
+		reconciliations = reconciliations[:size]
+		last := reconciliations[size-1]
+		response.NextCursor = model.Cursor{Date: last.StatementDate, ID: string(last.ID)}.Encode()
+	}
+	if reconciliations == nil {
+		reconciliations = []model.Reconciliation{}
+	}
+	response.Items = reconciliations
+	s.logs.Info("reconciliations returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+// completeReconciliationRequest names the transactions on the statement, an empty list completes a statement with
+// nothing new on it
+type completeReconciliationRequest struct {
+	TransactionIDs []model.TransactionID `json:"transaction_ids" validate:"max=5000,dive,uuid"`
+}
+
+// completeReconciliation reconciles the transactions on the statement and records the reconciliation in the
+// history of the account, their balance has to be the statement balance
+func (s *Server) completeReconciliation(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "reconciliations.go -> completeReconciliation()").Debug()
+	var req completeReconciliationRequest
+	reconciliation, ok, err := s.ownedReconciliation(ctx)
+	if !ok {
+		return err
+	}
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	reconciliation, err = s.repo.CompleteReconciliationTx(ctx.Context(), db.CompleteReconciliationTxParams{
+		UserID:           reconciliation.UserID,
+		ReconciliationID: reconciliation.ID,
+		TransactionIDs:   req.TransactionIDs,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, reconciliationNotFound))
+		case errors.Is(err, db.ErrNotReconcilable):
+			s.logs.WithError(err).Warn()
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrReconciliationClosed), errors.Is(err, db.ErrReconciliationUnbalanced):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn("could not complete reconciliation")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response, err := s.reconciliationSession(ctx, reconciliation)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("reconciliation completed successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+// deleteReconciliation cancels an open reconciliation, nothing was reconciled by it
+func (s *Server) deleteReconciliation(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "reconciliations.go -> deleteReconciliation()").Debug()
+	reconciliation, ok, err := s.ownedReconciliation(ctx)
+	if !ok {
+		return err
+	}
+	if reconciliation.Status != model.ReconciliationOpen {
+		status = http.StatusConflict
+		return ctx.Status(status).JSON(errorResponse(status, db.ErrReconciliationClosed))
+	}
+	deletedAt, err := s.repo.DeleteReconciliation(ctx.Context(), reconciliation.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, reconciliationNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("reconciliation cancelled successfully")
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(reconciliationDeletedMSG, deletedAt.Format(time.ANSIC))})
+}
@@ -0,0 +1,309 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+var (
+	recurringNotFound   = errors.New("recurring transaction(s) not found or deleted")
+	recurringDeletedMSG = "recurring transaction successfully deleted at %s"
+)
+
+type recurringRequest struct {
+	AccountID       model.AccountID       `json:"account_id" validate:"required"`
+	CategoryID      model.CategoryID      `json:"category_id" validate:"required,uuid"`
+	MerchantID      model.MerchantID      `json:"merchant_id"`
+	Name            string                `json:"name" validate:"required"`
+	TransactionType model.TransactionType `json:"transaction_type" validate:"required,oneof=income expense"`
+	Amount          int64                 `json:"amount" validate:"required,gt=0"`
+	Notes           string                `json:"notes"`
+	// Rule is an RRULE such as FREQ=MONTHLY;BYMONTHDAY=1, see utils.Rule for the parts supported
+	Rule      string    `json:"rule" validate:"required,rrule"`
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date" validate:"omitempty,gtfield=StartDate"`
+	SkipDates []string  `json:"skip_dates" validate:"omitempty,dive,datetime=2006-01-02"`
+}
+
+func (s *Server) createRecurring(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "recurring.go -> createRecurring()").Debug()
+	var req recurringRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	args := db.CreateRecurringParams{
+		UserID:          userID,
+		AccountID:       req.AccountID,
+		CategoryID:      req.CategoryID,
+		MerchantID:      req.MerchantID,
+		Name:            req.Name,
+		TransactionType: req.TransactionType,
+		Amount:          req.Amount,
+		Notes:           req.Notes,
+		Rule:            req.Rule,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		SkipDates:       req.SkipDates,
+	}
+	recurring, err := s.repo.CreateRecurringTx(ctx.Context(), args)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrAccountNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		case errors.Is(err, db.ErrAccountNotOwned):
+			s.logs.WithError(err).Warn()
+			status = http.StatusForbidden
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrMerchantNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, merchantNotFound))
+		case errors.Is(err, db.ErrCategoryNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Recurring transaction created successfully")
+	return ctx.Status(http.StatusCreated).JSON(recurring)
+}
+
+func (s *Server) updateRecurring(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "recurring.go -> updateRecurring()").Debug()
+	var req recurringRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	recurringID := model.RecurringID(ctx.Params("recurringID"))
+	if recurringID == "" {
+		s.logs.WithField("recurringID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("recurringID not provided")))
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	args := db.UpdateRecurringParams{
+		RecurringID:     recurringID,
+		UserID:          userID,
+		AccountID:       req.AccountID,
+		CategoryID:      req.CategoryID,
+		MerchantID:      req.MerchantID,
+		Name:            req.Name,
+		TransactionType: req.TransactionType,
+		Amount:          req.Amount,
+		Notes:           req.Notes,
+		Rule:            req.Rule,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		SkipDates:       req.SkipDates,
+	}
+	recurring, err := s.repo.UpdateRecurringTx(ctx.Context(), args)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, recurringNotFound))
+		case errors.Is(err, db.ErrAccountNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		case errors.Is(err, db.ErrAccountNotOwned):
+			s.logs.WithError(err).Warn()
+			status = http.StatusForbidden
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrMerchantNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, merchantNotFound))
+		case errors.Is(err, db.ErrCategoryNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
+		}
+		s.logs.WithError(err).Warn("could not update recurring transaction")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Recurring transaction updated successfully")
+	return ctx.Status(http.StatusOK).JSON(recurring)
+}
+
+func (s *Server) getRecurring(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "recurring.go -> getRecurring()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	recurringID := model.RecurringID(ctx.Params("recurringID"))
+	if recurringID == "" {
+		s.logs.WithField("recurringID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("recurringID not provided")))
+	}
+	recurring, err := s.repo.GetRecurringByID(ctx.Context(), recurringID)
+	if err == nil && recurring.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, recurringNotFound))
+		}
+		s.logs.WithError(err).Warn("could not get recurring transaction")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Recurring transaction returned successfully")
+	return ctx.Status(http.StatusOK).JSON(recurring)
+}
+
+type previewRecurringRequest struct {
+	Count int `query:"count" validate:"omitempty,min=1,max=50"`
+}
+
+// previewRecurring returns the next count dates a recurring transaction will be posted on
+func (s *Server) previewRecurring(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "recurring.go -> previewRecurring()").Debug()
+	var req previewRecurringRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	recurringID := model.RecurringID(ctx.Params("recurringID"))
+	if recurringID == "" {
+		s.logs.WithField("recurringID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("recurringID not provided")))
+	}
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if req.Count == 0 {
+		req.Count = 10
+	}
+	recurring, err := s.repo.GetRecurringByID(ctx.Context(), recurringID)
+	if err == nil && recurring.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	occurrences := []time.Time{}
+	if err == nil && !recurring.NextRun.IsZero() {
+		occurrences, err = recurring.Occurrences(recurring.NextRun, req.Count)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, recurringNotFound))
+		}
+		s.logs.WithError(err).Warn("could not preview recurring transaction")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Recurring transaction occurrences returned successfully")
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{
+		"recurring_id": recurring.ID,
+		"occurrences":  occurrences,
+	})
+}
+
+type listRecurringRequest struct {
+	PageID   int32 `query:"page_id" validate:"required,min=1"`
+	PageSize int32 `query:"page_size" validate:"required,min=5,max=10"`
+}
+
+func (s *Server) listRecurring(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "recurring.go -> listRecurring()").Debug()
+	var req listRecurringRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	args := db.ListRecurringParams{
+		UserID: userID,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	recurrings, err := s.repo.ListRecurring(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(recurrings) == 0 {
+		status = http.StatusNotFound
+		return ctx.Status(status).JSON(errorResponse(status, recurringNotFound))
+	}
+	s.logs.Info("recurring transactions returned successfully")
+
+	return ctx.Status(http.StatusOK).JSON(recurrings)
+}
+
+func (s *Server) deleteRecurring(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "recurring.go -> deleteRecurring()").Debug()
+	recurringID := model.RecurringID(ctx.Params("recurringID"))
+	if recurringID == "" {
+		s.logs.WithField("recurringID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("recurringID not provided")))
+	}
+
+	userID := ctx.Locals("userID").(model.UserID)
+	args := db.DeleteRecurringParams{
+		RecurringID: recurringID,
+		UserID:      userID,
+	}
+	deletedAt, err := s.repo.DeleteRecurring(ctx.Context(), args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, recurringNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("recurring transaction deleted successfully")
+
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(recurringDeletedMSG, deletedAt.Format(time.ANSIC))})
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"time"
+)
+
+type summaryReportRequest struct {
+	From time.Time `query:"from" validate:"required"`
+	To   time.Time `query:"to" validate:"required,gtfield=From"`
+	// Interval is the length of the periods, a month when it is not given
+	Interval model.ReportInterval `query:"interval" validate:"omitempty,oneof=day week month quarter year"`
+}
+
+// summaryReportResponse is the income and expense of every period of the report and of the whole report
+type summaryReportResponse struct {
+	BaseCurrency utils.CurrencyCode    `json:"base_currency"`
+	Interval     model.ReportInterval  `json:"interval"`
+	From         time.Time             `json:"from"`
+	To           time.Time             `json:"to"`
+	Periods      []model.PeriodSummary `json:"periods"`
+	Total        model.PeriodSummary   `json:"total"`
+}
+
+func newSummaryReportResponse(base utils.CurrencyCode, req summaryReportRequest, periods []model.PeriodSummary) summaryReportResponse {
+	response := summaryReportResponse{
+		BaseCurrency: base,
+		Interval:     req.Interval,
+		From:         req.From,
+		To:           req.To,
+		Periods:      periods,
+		Total:        model.PeriodSummary{PeriodStart: req.From},
+	}
+	if response.Periods == nil {
+		response.Periods = []model.PeriodSummary{}
+	}
+	for _, period := range periods {
+		response.Total.TransactionCount += period.TransactionCount
+		response.Total.Income += period.Income
+		response.Total.Expense += period.Expense
+		response.Total.MissingRates += period.MissingRates
+	}
+	response.Total.SetNet()
+	return response
+}
+
+// summaryReport returns the income, expense, net and savings rate of a user per period between two dates
+func (s *Server) summaryReport(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "reports.go -> summaryReport()").Debug()
+	var req summaryReportRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if req.Interval == "" {
+		req.Interval = model.Month
+	}
+	base, err := s.baseCurrency(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	periods, err := s.repo.SummaryReport(ctx.Context(), db.SummaryReportParams{
+		UserID:       userID,
+		From:         req.From,
+		To:           req.To,
+		Interval:     req.Interval,
+		BaseCurrency: base,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("summary report returned successfully")
+	return ctx.Status(http.StatusOK).JSON(newSummaryReportResponse(base, req, periods))
+}
+
+type categoryReportRequest struct {
+	From time.Time `query:"from" validate:"required"`
+	To   time.Time `query:"to" validate:"required,gtfield=From"`
+	// Type is expense or income, expense when it is not given
+	Type model.TransactionType `query:"type" validate:"omitempty,oneof=expense income"`
+}
+
+// categoryReportResponse is the spend per category between two dates and in the period of the same length before,
+// the totals are those of the top level categories and the transactions without one
+type categoryReportResponse struct {
+	BaseCurrency  utils.CurrencyCode    `json:"base_currency"`
+	Type          model.TransactionType `json:"type"`
+	From          time.Time             `json:"from"`
+	To            time.Time             `json:"to"`
+	PreviousFrom  time.Time             `json:"previous_from"`
+	Total         int64                 `json:"total"`
+	PreviousTotal int64                 `json:"previous_total"`
+	MissingRates  int64                 `json:"missing_rates"`
+	Categories    []model.CategorySpend `json:"categories"`
+}
+
+func newCategoryReportResponse(base utils.CurrencyCode, args db.CategorySpendReportParams, spends []model.CategorySpend) categoryReportResponse {
+	response := categoryReportResponse{
+		BaseCurrency: base,
+		Type:         args.TransactionType,
+		From:         args.From,
+		To:           args.To,
+		PreviousFrom: args.PreviousFrom,
+		Categories:   spends,
+	}
+	if response.Categories == nil {
+		response.Categories = []model.CategorySpend{}
+	}
+	// every parent of a category with spend has spend too, so a category whose parent is not listed is at the top
+	listed := make(map[model.CategoryID]bool, len(spends))
+	for _, spend := range spends {
+		listed[spend.CategoryID] = true
+	}
+	for _, spend := range spends {
+		if spend.CategoryID == "" || !listed[spend.ParentID] {
+			response.Total += spend.Amount
+			response.PreviousTotal += spend.PreviousAmount
+			response.MissingRates += spend.MissingRates
+		}
+	}
+	for i := range response.Categories {
+		spend := &response.Categories[i]
+		if response.Total != 0 {
+			spend.Percentage = float64(spend.Amount) * 100 / float64(response.Total)
+		}
+		spend.Change = spend.Amount - spend.PreviousAmount
+		if spend.PreviousAmount != 0 {
+			rate := float64(spend.Change) / float64(spend.PreviousAmount)
+			spend.ChangeRate = &rate
+		}
+	}
+	return response
+}
+
+// categoryReport returns what a user spent per category between two dates with subcategories rolled up into
+// their parents, compared with the period of the same length before
+func (s *Server) categoryReport(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "reports.go -> categoryReport()").Debug()
+	var req categoryReportRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if req.Type == "" {
+		req.Type = model.Expense
+	}
+	base, err := s.baseCurrency(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	args := db.CategorySpendReportParams{
+		UserID:          userID,
+		From:            req.From,
+		To:              req.To,
+		PreviousFrom:    req.From.Add(-req.To.Sub(req.From)),
+		TransactionType: req.Type,
+		BaseCurrency:    base,
+	}
+	spends, err := s.repo.CategorySpendReport(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("category report returned successfully")
+	return ctx.Status(http.StatusOK).JSON(newCategoryReportResponse(base, args, spends))
+}
@@ -7,6 +7,7 @@ func (s *Server) registerRoutes() {
 	s.logs.WithField("func", "router.go -> registerRoutes()").Debug()
 	s.routes = fiber.New()
 	s.routes.Get("/version", s.version)
+	s.routes.Get("/currencies", s.listCurrencies)
 	permissions := newPermissions(s.repo, s.logs)
 
 	v1 := s.routes.Group("/api/v1")
@@ -33,28 +34,100 @@ func (s *Server) registerRoutes() {
 	v1auth.Get("/users/:userID/accounts", permissions.wrap(memberIsTarget), s.listAccounts)
 	v1auth.Delete("/users/:userID/accounts/:accountID", permissions.wrap(memberIsTarget), s.deleteAccount)
 
+	// -----RECONCILIATIONS-----
+	v1auth.Post("/users/:userID/accounts/:accountID/reconciliations", permissions.wrap(memberIsTarget), s.createReconciliation)
+	v1auth.Get("/users/:userID/accounts/:accountID/reconciliations", permissions.wrap(memberIsTarget), s.listReconciliations)
+	v1auth.Get("/users/:userID/accounts/:accountID/reconciliations/:reconciliationID", permissions.wrap(memberIsTarget), s.getReconciliation)
+	v1auth.Post("/users/:userID/accounts/:accountID/reconciliations/:reconciliationID/complete", permissions.wrap(memberIsTarget), s.completeReconciliation)
+	v1auth.Delete("/users/:userID/accounts/:accountID/reconciliations/:reconciliationID", permissions.wrap(memberIsTarget), s.deleteReconciliation)
+
+	// -----IMPORTS-----
+	v1auth.Post("/users/:userID/accounts/:accountID/imports", permissions.wrap(memberIsTarget), s.importStatement)
+	v1auth.Post("/users/:userID/import-mappings", permissions.wrap(memberIsTarget), s.createImportMapping)
+	v1auth.Get("/users/:userID/import-mappings", permissions.wrap(memberIsTarget), s.listImportMappings)
+	v1auth.Delete("/users/:userID/import-mappings/:mappingID", permissions.wrap(memberIsTarget), s.deleteImportMapping)
+
 	// -----CATEGORY-----
 	v1auth.Post("/users/:userID/categories", permissions.wrap(memberIsTarget), s.createCategory)
+	v1auth.Get("/users/:userID/categories/tree", permissions.wrap(memberIsTarget), s.categoryTree)
+	v1auth.Get("/users/:userID/categories/suggest", permissions.wrap(memberIsTarget), s.suggestCategories)
 	v1auth.Get("/users/:userID/categories/:categoryID", permissions.wrap(memberIsTarget), s.getCategory)
 	v1auth.Get("/users/:userID/categories", permissions.wrap(memberIsTarget), s.listCategories)
 	v1auth.Put("/users/:userID/categories/:categoryID", permissions.wrap(memberIsTarget), s.updateCategory)
+	v1auth.Put("/users/:userID/categories/:categoryID/parent", permissions.wrap(memberIsTarget), s.moveCategory)
+	v1auth.Post("/users/:userID/categories/:categoryID/merge", permissions.wrap(memberIsTarget), s.mergeCategory)
 	v1auth.Delete("/users/:userID/categories/:categoryID", permissions.wrap(memberIsTarget), s.deleteCategory)
 
 	// -----MERCHANTS-----
 	v1auth.Post("/users/:userID/merchants", permissions.wrap(memberIsTarget), s.createMerchant)
+	v1auth.Get("/users/:userID/merchants/spend", permissions.wrap(memberIsTarget), s.listMerchantSpend)
 	v1auth.Get("/users/:userID/merchants/:merchantID", permissions.wrap(memberIsTarget), s.getMerchant)
+	v1auth.Get("/users/:userID/merchants/:merchantID/transactions", permissions.wrap(memberIsTarget), s.listMerchantTransactions)
 	v1auth.Get("/users/:userID/merchants", permissions.wrap(memberIsTarget), s.listMerchants)
 	v1auth.Put("/users/:userID/merchants/:merchantID", permissions.wrap(memberIsTarget), s.updateMerchant)
 	v1auth.Delete("/users/:userID/merchants/:merchantID", permissions.wrap(memberIsTarget), s.deleteMerchant)
 
+	// -----RULES-----
+	v1auth.Post("/users/:userID/rules", permissions.wrap(memberIsTarget), s.createRule)
+	v1auth.Post("/users/:userID/rules/test", permissions.wrap(memberIsTarget), s.testRule)
+	v1auth.Post("/users/:userID/rules/apply", permissions.wrap(memberIsTarget), s.applyRules)
+	v1auth.Put("/users/:userID/rules/order", permissions.wrap(memberIsTarget), s.reorderRules)
+	v1auth.Get("/users/:userID/rules/:ruleID", permissions.wrap(memberIsTarget), s.getRule)
+	v1auth.Get("/users/:userID/rules", permissions.wrap(memberIsTarget), s.listRules)
+	v1auth.Put("/users/:userID/rules/:ruleID", permissions.wrap(memberIsTarget), s.updateRule)
+	v1auth.Delete("/users/:userID/rules/:ruleID", permissions.wrap(memberIsTarget), s.deleteRule)
+
 	// -----TRANSACTIONS-----
 	v1auth.Post("/users/:userID/transactions", permissions.wrap(memberIsTarget), s.createTransaction)
+	v1auth.Get("/users/:userID/transactions/search", permissions.wrap(memberIsTarget), s.searchTransactions)
+	v1auth.Get("/users/:userID/transactions/duplicates", permissions.wrap(memberIsTarget), s.listDuplicates)
+	v1auth.Post("/users/:userID/transactions/duplicates/merge", permissions.wrap(memberIsTarget), s.mergeDuplicate)
+	v1auth.Post("/users/:userID/transactions/duplicates/dismiss", permissions.wrap(memberIsTarget), s.dismissDuplicate)
+	v1auth.Put("/users/:userID/transactions/status", permissions.wrap(memberIsTarget), s.setTransactionStatus)
 	v1auth.Get("/users/:userID/transactions/:transactionID", permissions.wrap(memberIsTarget), s.getTransaction)
 	v1auth.Get("/users/:userID/transactions", permissions.wrap(memberIsTarget), s.listTransactionsByUserID)
 	v1auth.Get("/accounts/:accountID/transactions", permissions.wrap(memberIsTarget), s.listTransactionsByAccountID)
 	v1auth.Get("/categories/:categoryID/transactions", permissions.wrap(memberIsTarget), s.listTransactionsByCategoryID)
 	v1auth.Put("/users/:userID/transactions/:transactionID", permissions.wrap(memberIsTarget), s.updateTransaction)
 	v1auth.Delete("/users/:userID/transactions/:transactionID", permissions.wrap(memberIsTarget), s.deleteTransaction)
+	v1auth.Get("/users/:userID/transactions/:transactionID/postings", permissions.wrap(memberIsTarget), s.listTransactionPostings)
+
+	// -----TRANSFERS-----
+	v1auth.Post("/users/:userID/transfers", permissions.wrap(memberIsTarget), s.createTransfer)
+	v1auth.Get("/users/:userID/transfers/:transferID", permissions.wrap(memberIsTarget), s.getTransfer)
+	v1auth.Get("/users/:userID/transfers", permissions.wrap(memberIsTarget), s.listTransfers)
+	v1auth.Put("/users/:userID/transfers/:transferID", permissions.wrap(memberIsTarget), s.updateTransfer)
+	v1auth.Delete("/users/:userID/transfers/:transferID", permissions.wrap(memberIsTarget), s.deleteTransfer)
+
+	// -----RECURRING-----
+	v1auth.Post("/users/:userID/recurring", permissions.wrap(memberIsTarget), s.createRecurring)
+	v1auth.Get("/users/:userID/recurring/:recurringID", permissions.wrap(memberIsTarget), s.getRecurring)
+	v1auth.Get("/users/:userID/recurring/:recurringID/preview", permissions.wrap(memberIsTarget), s.previewRecurring)
+	v1auth.Get("/users/:userID/recurring", permissions.wrap(memberIsTarget), s.listRecurring)
+	v1auth.Put("/users/:userID/recurring/:recurringID", permissions.wrap(memberIsTarget), s.updateRecurring)
+	v1auth.Delete("/users/:userID/recurring/:recurringID", permissions.wrap(memberIsTarget), s.deleteRecurring)
+
+	// -----BUDGETS-----
+	v1auth.Post("/users/:userID/budgets", permissions.wrap(memberIsTarget), s.createBudget)
+	v1auth.Get("/users/:userID/budgets/:month", permissions.wrap(memberIsTarget), s.budgetMonth)
+	v1auth.Put("/users/:userID/budgets/:budgetID", permissions.wrap(memberIsTarget), s.updateBudget)
+	v1auth.Delete("/users/:userID/budgets/:budgetID", permissions.wrap(memberIsTarget), s.deleteBudget)
+
+	// -----EXPORT & BACKUP-----
+	v1auth.Get("/users/:userID/export", permissions.wrap(memberIsTarget), s.exportUserData)
+	v1auth.Get("/users/:userID/backup", permissions.wrap(memberIsTarget), s.backupUserData)
+	v1auth.Post("/users/:userID/restore", permissions.wrap(memberIsTarget), s.restoreBackup)
+
+	// -----LEDGER-----
+	v1auth.Get("/users/:userID/ledger", permissions.wrap(memberIsTarget), s.trialBalance)
+
+	// -----EXCHANGE RATES-----
+	v1auth.Put("/users/:userID/base-currency", permissions.wrap(memberIsTarget), s.updateBaseCurrency)
+	v1auth.Get("/users/:userID/balance", permissions.wrap(memberIsTarget), s.netWorth)
+
+	// -----REPORTS-----
+	v1auth.Get("/users/:userID/reports/summary", permissions.wrap(memberIsTarget), s.summaryReport)
+	v1auth.Get("/users/:userID/reports/categories", permissions.wrap(memberIsTarget), s.categoryReport)
 
 	//  ----ADMIN ROLES----
 	v1Admin := v1auth.Use(permissions.wrap(admin))
@@ -62,4 +135,10 @@ func (s *Server) registerRoutes() {
 	v1Admin.Delete("/users/:userID/role", s.revokeRole)
 	v1Admin.Get("/users/:userID/role", s.getUserRole)
 	v1Admin.Get("/users/:userID/roles", s.listRoles)
+	v1Admin.Post("/exchange-rates", s.loadExchangeRates)
+	v1Admin.Post("/signup-templates", s.createSignupTemplate)
+	v1Admin.Get("/signup-templates/:templateID", s.getSignupTemplate)
+	v1Admin.Get("/signup-templates", s.listSignupTemplates)
+	v1Admin.Put("/signup-templates/:templateID", s.updateSignupTemplate)
+	v1Admin.Delete("/signup-templates/:templateID", s.deleteSignupTemplate)
 }
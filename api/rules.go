@@ -0,0 +1,428 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"time"
+)
+
+var (
+	ruleNotFound       = errors.New("rule(s) not found or deleted")
+	ruleActionRequired = errors.New("a rule needs a category_id, merchant_id or notes to assign")
+	ruleDeletedMSG     = "rule successfully deleted at %s"
+)
+
+type ruleRequest struct {
+	Name       string               `json:"name" validate:"required"`
+	Conditions model.RuleConditions `json:"conditions"`
+	CategoryID model.CategoryID     `json:"category_id" validate:"omitempty,uuid"`
+	MerchantID model.MerchantID     `json:"merchant_id" validate:"omitempty,uuid"`
+	Notes      string               `json:"notes"`
+}
+
+func (req ruleRequest) actions() model.RuleActions {
+	return model.RuleActions{
+		CategoryID: req.CategoryID,
+		MerchantID: req.MerchantID,
+		Notes:      req.Notes,
+	}
+}
+
+// checkRule checks the conditions of the rule can be matched and that the categories, merchants and accounts it
+// refers to belong to the user, it writes the error response itself and reports whether the request can go on
+func (s *Server) checkRule(ctx *fiber.Ctx, userID model.UserID, req ruleRequest) (bool, error) {
+	if err := req.Conditions.Validate(); err != nil {
+		s.logs.WithError(err).Warn("rule conditions are invalid")
+		status = http.StatusBadRequest
+		return false, ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if req.actions().IsEmpty() {
+		status = http.StatusBadRequest
+		return false, ctx.Status(status).JSON(errorResponse(status, ruleActionRequired))
+	}
+	if err := s.checkRuleReferences(ctx.Context(), userID, req); err != nil {
+		s.logs.WithError(err).Warn()
+		switch {
+		case errors.Is(err, categoryNotFound), errors.Is(err, merchantNotFound), errors.Is(err, accountNotFound):
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+		return false, ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	return true, nil
+}
+
+// checkRuleReferences returns categoryNotFound, merchantNotFound or accountNotFound when the rule refers to one
+// the user does not have
+func (s *Server) checkRuleReferences(ctx context.Context, userID model.UserID, req ruleRequest) error {
+	merchants := []model.MerchantID{req.MerchantID}
+	var accounts []model.AccountID
+	for _, condition := range req.Conditions {
+		switch condition.Field {
+		case model.RuleMerchant:
+			merchants = append(merchants, model.MerchantID(condition.Value))
+		case model.RuleAccount:
+			accounts = append(accounts, model.AccountID(condition.Value))
+		}
+	}
+	if req.CategoryID != "" {
+		category, err := s.repo.GetCategoryByID(ctx, req.CategoryID)
+		if err == nil && category.UserID != userID {
+			err = sql.ErrNoRows
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return categoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range merchants {
+		if id == "" {
+			continue
+		}
+		merchant, err := s.repo.GetMerchantByID(ctx, id)
+		if err == nil && merchant.UserID != userID {
+			err = sql.ErrNoRows
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return merchantNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range accounts {
+		account, err := s.repo.GetAccountByID(ctx, id)
+		if err == nil && account.UserID != userID {
+			err = sql.ErrNoRows
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return accountNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createRule adds a rule that runs after the rules the user already has
+func (s *Server) createRule(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> createRule()").Debug()
+	var req ruleRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if ok, err := s.checkRule(ctx, userID, req); !ok {
+		return err
+	}
+	args := db.CreateRuleParams{
+		UserID:      userID,
+		Name:        req.Name,
+		Conditions:  req.Conditions,
+		RuleActions: req.actions(),
+	}
+	rule, err := s.repo.CreateRule(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Rule created successfully")
+	return ctx.Status(http.StatusCreated).JSON(rule)
+}
+
+func (s *Server) getRule(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> getRule()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	rule, err := s.repo.GetRuleByID(ctx.Context(), model.RuleID(ctx.Params("ruleID")))
+	if err == nil && rule.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, ruleNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("rule returned successfully")
+	return ctx.Status(http.StatusOK).JSON(rule)
+}
+
+// listRules returns the rules of the user in the order they run
+func (s *Server) listRules(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> listRules()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	rules, err := s.repo.ListRules(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(rules) == 0 {
+		status = http.StatusNotFound
+		return ctx.Status(status).JSON(errorResponse(status, ruleNotFound))
+	}
+	s.logs.Info("rules returned successfully")
+	return ctx.Status(http.StatusOK).JSON(rules)
+}
+
+func (s *Server) updateRule(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> updateRule()").Debug()
+	var req ruleRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	ruleID := model.RuleID(ctx.Params("ruleID"))
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	rule, err := s.repo.GetRuleByID(ctx.Context(), ruleID)
+	if err == nil && rule.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, ruleNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if ok, err := s.checkRule(ctx, userID, req); !ok {
+		return err
+	}
+	args := db.UpdateRuleParams{
+		RuleID:      ruleID,
+		Name:        req.Name,
+		Conditions:  req.Conditions,
+		RuleActions: req.actions(),
+	}
+	rule, err = s.repo.UpdateRule(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn("could not update rule")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Rule updated successfully")
+	return ctx.Status(http.StatusOK).JSON(rule)
+}
+
+type reorderRulesRequest struct {
+	// RuleIDs are the rules to run first, in order, the rules left out run after them
+	RuleIDs []model.RuleID `json:"rule_ids" validate:"required,min=1"`
+}
+
+// reorderRules changes the order the rules of the user run in
+func (s *Server) reorderRules(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> reorderRules()").Debug()
+	var req reorderRulesRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	rules, err := s.repo.ReorderRulesTx(ctx.Context(), db.ReorderRulesParams{UserID: userID, RuleIDs: req.RuleIDs})
+	if err != nil {
+		if errors.Is(err, db.ErrRuleNotFound) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, ruleNotFound))
+		}
+		s.logs.WithError(err).Warn("could not reorder rules")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("rules reordered successfully")
+	return ctx.Status(http.StatusOK).JSON(rules)
+}
+
+func (s *Server) deleteRule(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> deleteRule()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	args := db.DeleteRuleParams{
+		RuleID: model.RuleID(ctx.Params("ruleID")),
+		UserID: userID,
+	}
+	deletedAt, err := s.repo.DeleteRule(ctx.Context(), args)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, ruleNotFound))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("rule deleted successfully")
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(ruleDeletedMSG, deletedAt.Format(time.ANSIC))})
+}
+
+// applyRulesRequest picks the existing transactions rules are applied to
+type applyRulesRequest struct {
+	// From and To limit the transactions to a period, To is excluded, all transactions are used without them
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Overwrite replaces the category, merchant and notes transactions already have
+	Overwrite bool `json:"overwrite"`
+}
+
+type testRuleRequest struct {
+	Rule ruleRequest `json:"rule"`
+	applyRulesRequest
+}
+
+// testRule returns what an unsaved rule would change in the existing transactions of the user, nothing is written
+func (s *Server) testRule(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> testRule()").Debug()
+	var req testRuleRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if ok, err := s.checkRule(ctx, userID, req.Rule); !ok {
+		return err
+	}
+	rule := model.Rule{
+		UserID:      userID,
+		Name:        req.Rule.Name,
+		Conditions:  req.Rule.Conditions,
+		RuleActions: req.Rule.actions(),
+	}
+	changes, err := s.repo.ApplyRulesTx(ctx.Context(), db.ApplyRulesParams{
+		UserID:    userID,
+		Rules:     []model.Rule{rule},
+		From:      req.From,
+		To:        req.To,
+		Overwrite: req.Overwrite,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn("could not test rule")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	// the rule has no ID to list
+	for i := range changes {
+		changes[i].Rules = nil
+	}
+	s.logs.Info("rule tested successfully")
+	return ctx.Status(http.StatusOK).JSON(applyRulesResponse{Changes: changes})
+}
+
+type runRulesRequest struct {
+	// RuleIDs limits the rules that are applied, all the rules of the user are when it is empty
+	RuleIDs []model.RuleID `json:"rule_ids"`
+	applyRulesRequest
+	// Commit writes the changes, without it the changes that would be made are returned
+	Commit bool `json:"commit"`
+}
+
+type applyRulesResponse struct {
+	Committed bool               `json:"committed"`
+	Changes   []model.RuleChange `json:"changes"`
+}
+
+// applyRules runs the saved rules of the user over their existing transactions, in the order the rules run.
+// Reconciled transactions are locked and left as they are
+func (s *Server) applyRules(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "rules.go -> applyRules()").Debug()
+	var req runRulesRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	rules, err := s.repo.ListRules(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(req.RuleIDs) > 0 {
+		if rules, err = selectRules(rules, req.RuleIDs); err != nil {
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+	}
+	changes, err := s.repo.ApplyRulesTx(ctx.Context(), db.ApplyRulesParams{
+		UserID:    userID,
+		Rules:     rules,
+		From:      req.From,
+		To:        req.To,
+		Overwrite: req.Overwrite,
+		Commit:    req.Commit,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn("could not apply rules")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("rules applied successfully")
+	return ctx.Status(http.StatusOK).JSON(applyRulesResponse{Committed: req.Commit, Changes: changes})
+}
+
+// selectRules returns the rules with the given IDs, still in the order they run
+func selectRules(rules []model.Rule, ids []model.RuleID) ([]model.Rule, error) {
+	wanted := make(map[model.RuleID]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	var selected []model.Rule
+	for _, rule := range rules {
+		if wanted[rule.ID] {
+			selected = append(selected, rule)
+			delete(wanted, rule.ID)
+		}
+	}
+	if len(wanted) > 0 {
+		return nil, ruleNotFound
+	}
+	return selected, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// recurringBatch is how many due recurring transactions are picked up each round, the rest wait for the next
+const recurringBatch = 50
+
+// runRecurring posts the recurring transactions that have fallen due every interval until ctx is done
+func (s *Server) runRecurring(ctx context.Context, interval time.Duration) {
+	s.logs.WithField("func", "scheduler.go -> runRecurring()").Debug()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.postDueRecurring(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// postDueRecurring posts every occurrence due by now, catching up on the ones missed while the server was down
+func (s *Server) postDueRecurring(ctx context.Context, now time.Time) {
+	s.logs.WithField("func", "scheduler.go -> postDueRecurring()").Debug()
+	ids, err := s.repo.ListDueRecurring(ctx, db.ListDueRecurringParams{
+		Now:   now,
+		Limit: recurringBatch,
+	})
+	if err != nil {
+		s.logs.WithError(err).Warn("could not list due recurring transactions")
+		return
+	}
+	for _, id := range ids {
+		for {
+			transaction, err := s.repo.PostRecurringTx(ctx, db.PostRecurringParams{
+				RecurringID: id,
+				Now:         now,
+			})
+			if errors.Is(err, db.ErrRecurringNotDue) || errors.Is(err, sql.ErrNoRows) {
+				break
+			}
+			if err != nil {
+				s.logs.WithError(err).WithField("recurring_id", id).Warn("could not post recurring transaction")
+				if permanentRecurringError(err) {
+					s.disableRecurring(ctx, id)
+				}
+				break
+			}
+			s.logs.WithFields(logrus.Fields{"recurring_id": id, "transaction_id": transaction.ID}).Info("recurring transaction posted")
+		}
+	}
+}
+
+// disableRecurring stops a recurring transaction that can never be posted from being picked up again, so it does
+// not hold up the ones due after it. Updating it works out its next run again
+func (s *Server) disableRecurring(ctx context.Context, id model.RecurringID) {
+	err := s.repo.SetRecurringNextRun(ctx, db.SetRecurringNextRunParams{RecurringID: id})
+	if err != nil {
+		s.logs.WithError(err).WithField("recurring_id", id).Warn("could not disable recurring transaction")
+		return
+	}
+	s.logs.WithField("recurring_id", id).Warn("recurring transaction disabled")
+}
+
+// permanentRecurringError reports whether posting a recurring transaction failed in a way that will not change
+// by trying again, bad data rather than a database that could not be reached
+func permanentRecurringError(err error) bool {
+	switch {
+	case errors.Is(err, db.ErrAccountNotFound), errors.Is(err, db.ErrAccountNotOwned),
+		errors.Is(err, db.ErrMerchantNotFound), errors.Is(err, db.ErrCategoryNotFound),
+		errors.Is(err, db.ErrSplitsUnbalanced),
+		errors.Is(err, utils.ErrInvalidRule):
+		return true
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// data exceptions and integrity constraint violations
+		return pqErr.Code.Class() == "22" || pqErr.Code.Class() == "23"
+	}
+	return false
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"FiberFinanceAPI/auth"
 	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/suggest"
 	"FiberFinanceAPI/utils"
+	"context"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"time"
@@ -17,6 +19,8 @@ type Server struct {
 	token    auth.Maker
 	logs     *utils.StandardLogger
 	routes   *fiber.App
+	// suggestions keeps the category classifier of each user
+	suggestions *suggest.Cache
 }
 
 // NewServer creates a new Server instance
@@ -31,6 +35,8 @@ func NewServer(config utils.Config, logs *utils.StandardLogger, repo db.Repo) (S
 		repo:   repo,
 		logs:   logs,
 		token:  maker,
+		// trained classifiers are kept for a while so suggestions do not retrain on every request
+		suggestions: suggest.NewCache(suggestionTTL),
 	}
 	server.registerRoutes()
 	server.validate = newValidator(server.logs)
@@ -38,8 +44,13 @@ func NewServer(config utils.Config, logs *utils.StandardLogger, repo db.Repo) (S
 	return server, nil
 }
 
-// Run runs our Server instance
+// Run runs our Server instance, along with the scheduler posting recurring transactions when it is enabled
 func (s *Server) Run(address string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if s.config.RecurringInterval > 0 {
+		go s.runRecurring(ctx, s.config.RecurringInterval)
+	}
 	return s.routes.Listen(address)
 }
 
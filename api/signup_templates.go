@@ -0,0 +1,160 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	signupTemplateNotFound   = errors.New("signup template(s) not found or deleted")
+	signupTemplateExists     = errors.New("a signup template for the locale exists")
+	signupTemplateDeletedMSG = "signup template successfully deleted at %s"
+)
+
+type signupTemplateRequest struct {
+	Locale     string                   `json:"locale" validate:"required,bcp47_language_tag"`
+	Categories model.TemplateCategories `json:"categories"`
+	// AccountName is the account opened in the base currency of new users, none is opened when it is empty
+	AccountName string `json:"account_name" validate:"max=155"`
+	// AccountType is cash when it is not given
+	AccountType model.AccountType `json:"account_type" validate:"omitempty,oneof=cash credit"`
+	// Currency is the base currency of new users who do not give one
+	Currency utils.CurrencyCode `json:"currency" validate:"omitempty,currency"`
+}
+
+// parseSignupTemplateRequest decodes and validates a signup template, it writes the error response itself and
+// reports whether the request can go on
+func (s *Server) parseSignupTemplateRequest(ctx *fiber.Ctx, req *signupTemplateRequest) (bool, error) {
+	if err := ctx.BodyParser(req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return false, ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return false, ctx.Status(status).JSON(errs)
+	}
+	if err := req.Categories.Validate(); err != nil {
+		s.logs.WithError(err).Warn("template categories are invalid")
+		status = http.StatusBadRequest
+		return false, ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	// templates are looked up by hyphenated locales, en_KE is saved as en-KE
+	req.Locale = strings.ReplaceAll(req.Locale, "_", "-")
+	if req.AccountType == "" {
+		req.AccountType = model.Cash
+	}
+	return true, nil
+}
+
+// signupTemplateError maps a failed write of a signup template to its response
+func (s *Server) signupTemplateError(ctx *fiber.Ctx, err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		s.logs.WithField(string(pqErr.Code), pqErr.Code.Name()).Debug("postgres error codes")
+		if pqErr.Code.Name() == "unique_violation" {
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, signupTemplateExists))
+		}
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		s.logs.WithError(err).Warn()
+		status = http.StatusNotFound
+		return ctx.Status(status).JSON(errorResponse(status, signupTemplateNotFound))
+	}
+	s.logs.WithError(err).Warn()
+	status = http.StatusInternalServerError
+	return ctx.Status(status).JSON(errorResponse(status, err))
+}
+
+// createSignupTemplate adds the template users signing up with its locale start with
+func (s *Server) createSignupTemplate(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "signup_templates.go -> createSignupTemplate()").Debug()
+	var req signupTemplateRequest
+	if ok, err := s.parseSignupTemplateRequest(ctx, &req); !ok {
+		return err
+	}
+	args := db.CreateSignupTemplateParams{
+		Locale:      req.Locale,
+		Categories:  req.Categories,
+		AccountName: req.AccountName,
+		AccountType: req.AccountType,
+		Currency:    req.Currency,
+	}
+	template, err := s.repo.CreateSignupTemplate(ctx.Context(), args)
+	if err != nil {
+		return s.signupTemplateError(ctx, err)
+	}
+	s.logs.Info("signup template created successfully")
+	return ctx.Status(http.StatusCreated).JSON(template)
+}
+
+func (s *Server) getSignupTemplate(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "signup_templates.go -> getSignupTemplate()").Debug()
+	templateID := model.SignupTemplateID(ctx.Params("templateID"))
+	template, err := s.repo.GetSignupTemplateByID(ctx.Context(), templateID)
+	if err != nil {
+		return s.signupTemplateError(ctx, err)
+	}
+	s.logs.Info("signup template returned successfully")
+	return ctx.Status(http.StatusOK).JSON(template)
+}
+
+func (s *Server) listSignupTemplates(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "signup_templates.go -> listSignupTemplates()").Debug()
+	templates, err := s.repo.ListSignupTemplates(ctx.Context())
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if len(templates) == 0 {
+		status = http.StatusNotFound
+		return ctx.Status(status).JSON(errorResponse(status, signupTemplateNotFound))
+	}
+	s.logs.Info("signup templates returned successfully")
+	return ctx.Status(http.StatusOK).JSON(templates)
+}
+
+// updateSignupTemplate replaces a signup template, users who signed up with it keep what it gave them
+func (s *Server) updateSignupTemplate(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "signup_templates.go -> updateSignupTemplate()").Debug()
+	var req signupTemplateRequest
+	if ok, err := s.parseSignupTemplateRequest(ctx, &req); !ok {
+		return err
+	}
+	args := db.UpdateSignupTemplateParams{
+		TemplateID:  model.SignupTemplateID(ctx.Params("templateID")),
+		Locale:      req.Locale,
+		Categories:  req.Categories,
+		AccountName: req.AccountName,
+		AccountType: req.AccountType,
+		Currency:    req.Currency,
+	}
+	template, err := s.repo.UpdateSignupTemplate(ctx.Context(), args)
+	if err != nil {
+		return s.signupTemplateError(ctx, err)
+	}
+	s.logs.Info("signup template updated successfully")
+	return ctx.Status(http.StatusOK).JSON(template)
+}
+
+func (s *Server) deleteSignupTemplate(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "signup_templates.go -> deleteSignupTemplate()").Debug()
+	templateID := model.SignupTemplateID(ctx.Params("templateID"))
+	deletedAt, err := s.repo.DeleteSignupTemplate(ctx.Context(), templateID)
+	if err != nil {
+		return s.signupTemplateError(ctx, err)
+	}
+	s.logs.Info("signup template deleted successfully")
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(signupTemplateDeletedMSG, deletedAt.Format(time.ANSIC))})
+}
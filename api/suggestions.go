@@ -0,0 +1,121 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/suggest"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"time"
+)
+
+const (
+	// suggestionTTL is how long a trained classifier is used before it learns the latest transactions
+	suggestionTTL = 10 * time.Minute
+	// suggestionHistory is how many of the latest transactions of a user the classifier learns from
+	suggestionHistory = 5000
+	// suggestionCount is how many categories are suggested for a description
+	suggestionCount = 3
+	// importSuggestionScore is how sure the classifier has to be of a category for an import to use it
+	importSuggestionScore = 0.6
+)
+
+// categoryClassifier returns the classifier trained on the categorized transactions of the user, it is trained
+// again once the one in the cache expires
+func (s *Server) categoryClassifier(ctx *fiber.Ctx, userID model.UserID) (*suggest.Classifier, error) {
+	now := time.Now()
+	if classifier, ok := s.suggestions.Get(string(userID), now); ok {
+		return classifier, nil
+	}
+	transactions, err := s.repo.ListCategorizedTransactions(ctx.Context(), db.ListCategorizedTransactionsParams{
+		UserID: userID,
+		Limit:  suggestionHistory,
+	})
+	if err != nil {
+		return nil, err
+	}
+	examples := make([]suggest.Example, 0, len(transactions))
+	for _, transaction := range transactions {
+		examples = append(examples, suggest.Example{
+			Text:     transaction.Name,
+			Merchant: transaction.Merchant,
+			Label:    string(transaction.CategoryID),
+		})
+	}
+	classifier := suggest.Train(examples)
+	s.suggestions.Put(string(userID), classifier, now)
+	return classifier, nil
+}
+
+type suggestCategoriesRequest struct {
+	Query string `query:"q" validate:"required,max=255"`
+	// Merchant is the name of the merchant of the transaction, when it is known
+	Merchant string `query:"merchant" validate:"max=255"`
+}
+
+// suggestCategories suggests the categories a transaction description is most likely filed under, learnt from
+// the categories the user gave their earlier transactions. Descriptions with no word the user has used before
+// get no suggestion
+func (s *Server) suggestCategories(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "suggestions.go -> suggestCategories()").Debug()
+	var req suggestCategoriesRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	classifier, err := s.categoryClassifier(ctx, userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	categories, err := s.repo.ListAllCategories(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	names := make(map[model.CategoryID]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	// the classifier may have learnt categories deleted since it was trained, they are passed over
+	suggestions := []model.CategorySuggestion{}
+	for _, suggestion := range classifier.Suggest(req.Query, req.Merchant, len(names)) {
+		name, ok := names[model.CategoryID(suggestion.Label)]
+		if !ok {
+			continue
+		}
+		suggestions = append(suggestions, model.CategorySuggestion{
+			CategoryID: model.CategoryID(suggestion.Label),
+			Name:       name,
+			Score:      suggestion.Score,
+		})
+		if len(suggestions) == suggestionCount {
+			break
+		}
+	}
+	s.logs.Info("category suggestions returned successfully")
+	return ctx.Status(http.StatusOK).JSON(suggestions)
+}
+
+// suggestedCategory returns the category the classifier is sure enough a description and merchant go under,
+// empty when it is not or there is no classifier
+func suggestedCategory(classifier *suggest.Classifier, text, merchant string) model.CategoryID {
+	if classifier == nil {
+		return ""
+	}
+	suggestions := classifier.Suggest(text, merchant, 1)
+	if len(suggestions) == 0 || suggestions[0].Score < importSuggestionScore {
+		return ""
+	}
+	return model.CategoryID(suggestions[0].Label)
+}
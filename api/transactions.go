@@ -3,6 +3,7 @@ package api
 import (
 	model "FiberFinanceAPI/database/models"
 	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -14,17 +15,63 @@ import (
 
 var (
 	transactionNotFound   = errors.New("transaction(s) not found or deleted")
+	categoryRequired      = errors.New("category_id is required when no rule gives the transaction a category")
+	mixedCurrencies       = errors.New("amounts must be given in a single currency")
 	transactionDeletedMSG = "transaction successfully deleted at %s"
 )
 
+type splitRequest struct {
+	CategoryID model.CategoryID `json:"category_id" validate:"required,uuid"`
+	Amount     utils.Money      `json:"amount" validate:"required,gt=0"`
+	Notes      string           `json:"notes"`
+}
+
+// transactionRequest creates or updates a transaction, category_id can be left out of a split transaction and
+// of a new transaction a rule gives a category. Amounts are in minor units or Money, the currency of Money has to
+// be the currency of the account
 type transactionRequest struct {
 	AccountID       model.AccountID       `json:"account_id" validate:"required"`
-	CategoryID      model.CategoryID      `json:"category_id" validate:"required"`
+	CategoryID      model.CategoryID      `json:"category_id" validate:"omitempty,uuid"`
 	Name            string                `json:"name" validate:"required"`
-	TransactionType model.TransactionType `json:"transaction_type" validate:"required"`
-	Amount          int64                 `json:"amount" validate:"required"`
+	TransactionType model.TransactionType `json:"transaction_type" validate:"required,oneof=income expense"`
+	Amount          utils.Money           `json:"amount" validate:"required,gt=0"`
 	Notes           string                `json:"notes"`
 	Date            time.Time             `json:"date" validate:"required"`
+	MerchantID      model.MerchantID      `json:"merchant_id"`
+	Splits          []splitRequest        `json:"splits" validate:"omitempty,dive"`
+}
+
+// splitParams returns the requested splits, a split transaction sent without a category is given the
+// category of its first split
+func (req *transactionRequest) splitParams() []db.CreateSplitParams {
+	var splits []db.CreateSplitParams
+	for _, split := range req.Splits {
+		splits = append(splits, db.CreateSplitParams{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount.Amount,
+			Notes:      split.Notes,
+		})
+	}
+	if req.CategoryID == "" && len(req.Splits) > 0 {
+		req.CategoryID = req.Splits[0].CategoryID
+	}
+	return splits
+}
+
+// currency returns the currency the amounts of the request were given in, amounts given without a currency
+// are taken in the currency of the others
+func (req *transactionRequest) currency() (utils.CurrencyCode, error) {
+	currency := req.Amount.Currency
+	for _, split := range req.Splits {
+		switch {
+		case split.Amount.Currency == "":
+		case currency == "":
+			currency = split.Amount.Currency
+		case split.Amount.Currency != currency:
+			return "", mixedCurrencies
+		}
+	}
+	return currency, nil
 }
 
 func (s *Server) createTransaction(ctx *fiber.Ctx) error {
@@ -41,21 +88,73 @@ func (s *Server) createTransaction(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
+	splits := req.splitParams()
+	currency, err := req.currency()
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	rules, err := s.repo.ListRules(ctx.Context(), userID)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
 	args := db.CreateTransactionParams{
 		UserID:          userID,
 		AccountID:       req.AccountID,
 		CategoryID:      req.CategoryID,
 		Name:            req.Name,
 		TransactionType: req.TransactionType,
-		Amount:          req.Amount,
+		Amount:          req.Amount.Amount,
+		Notes:           req.Notes,
 		Date:            req.Date,
+		MerchantID:      req.MerchantID,
+		Currency:        currency,
+		Splits:          splits,
 	}
-	transaction, err := s.repo.CreateTransaction(ctx.Context(), args)
+	db.ApplyTransactionRules(rules, &args)
+	if args.CategoryID == "" {
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, categoryRequired))
+	}
+	transaction, err := s.repo.CreateTransactionTx(ctx.Context(), args)
 	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrAccountNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		case errors.Is(err, db.ErrAccountNotOwned):
+			s.logs.WithError(err).Warn()
+			status = http.StatusForbidden
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrSplitsUnbalanced), errors.Is(err, db.ErrAmountCurrency):
+			s.logs.WithError(err).Warn()
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrMerchantNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, merchantNotFound))
+		case errors.Is(err, db.ErrCategoryNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
+		}
 		s.logs.WithError(err).Warn()
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
+	pairs, err := s.possibleDuplicates(ctx, transaction.AccountID, []model.Transaction{transaction})
+	if err != nil {
+		// the transaction is created either way, it is only returned without its possible duplicates
+		s.logs.WithError(err).Warn("could not look for duplicates")
+	}
+	for _, pair := range pairs {
+		transaction.PossibleDuplicates = append(transaction.PossibleDuplicates, pair.Transaction.ID)
+	}
 	s.logs.Info("Transaction created successfully")
 	return ctx.Status(http.StatusCreated).JSON(transaction)
 }
@@ -82,6 +181,17 @@ func (s *Server) updateTransaction(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
+	splits := req.splitParams()
+	if req.CategoryID == "" {
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, categoryRequired))
+	}
+	currency, err := req.currency()
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
 	args := db.UpdateTransactionParams{
 		TransactionID:   transactionID,
 		UserID:          userID,
@@ -89,15 +199,45 @@ func (s *Server) updateTransaction(ctx *fiber.Ctx) error {
 		CategoryID:      req.CategoryID,
 		Name:            req.Name,
 		TransactionType: req.TransactionType,
-		Amount:          req.Amount,
+		Amount:          req.Amount.Amount,
+		Notes:           req.Notes,
 		Date:            req.Date,
+		MerchantID:      req.MerchantID,
+		Currency:        currency,
+		Unlock:          ctx.Query("unlock") == "true",
+		Splits:          splits,
 	}
-	transaction, err := s.repo.UpdateTransaction(ctx.Context(), args)
+	transaction, err := s.repo.UpdateTransactionTx(ctx.Context(), args)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
 			s.logs.WithError(err).Warn()
 			status = http.StatusNotFound
 			return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+		case errors.Is(err, db.ErrAccountNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		case errors.Is(err, db.ErrAccountNotOwned):
+			s.logs.WithError(err).Warn()
+			status = http.StatusForbidden
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrSplitsUnbalanced), errors.Is(err, db.ErrAmountCurrency):
+			s.logs.WithError(err).Warn()
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrMerchantNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, merchantNotFound))
+		case errors.Is(err, db.ErrCategoryNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, categoryNotFound))
+		case errors.Is(err, db.ErrTransferLeg), errors.Is(err, db.ErrTransactionReconciled):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
 		}
 		s.logs.WithError(err).Warn("could not update transaction")
 		status = http.StatusInternalServerError
@@ -116,6 +256,9 @@ func (s *Server) getTransaction(ctx *fiber.Ctx) error {
 		return ctx.Status(status).JSON(errorResponse(status, errors.New("transactionID not provided")))
 	}
 	transaction, err := s.repo.GetTransactionByID(ctx.Context(), transactionID)
+	if err == nil {
+		transaction.Splits, err = s.repo.ListSplitsByTransactionID(ctx.Context(), transactionID)
+	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			s.logs.WithError(err).Warn()
@@ -131,8 +274,9 @@ func (s *Server) getTransaction(ctx *fiber.Ctx) error {
 }
 
 type listTransactionsRequest struct {
-	PageID   int32     `query:"page_id" validate:"required,min=1"`
-	PageSize int32     `query:"page_size" validate:"required,min=5,max=10"`
+	// Cursor is the next_cursor of the previous page, the first page is returned without it
+	Cursor   string    `query:"cursor"`
+	PageSize int32     `query:"page_size" validate:"omitempty,min=1,max=100"`
 	From     time.Time `json:"from" validate:"required"`
 	To       time.Time `json:"to" validate:"required"`
 }
@@ -152,27 +296,138 @@ func (s *Server) listTransactionsByUserID(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
 	args := db.ListTxByUserIDParams{
 		UserID: userID,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  size + 1,
 		From:   req.From,
 		To:     req.To,
+		Cursor: cursor,
 	}
 	transactions, err := s.repo.ListTransactionsByUserID(ctx.Context(), args)
 	if err != nil {
 		s.logs.WithError(err).Warn()
 		status = http.StatusInternalServerError
-		return ctx.Status(status).JSON(errorResponse(status, errors.New("no transactions available")))
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	total, err := s.repo.CountTransactionsByUserID(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	if len(transactions) == 0 {
-		status = http.StatusNotFound
-		return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+	response := page{Total: total}
+	if len(transactions) > int(size) {
+		transactions = transactions[:size]
+		last := transactions[size-1]
+		response.NextCursor = model.Cursor{Date: last.Date, ID: string(last.ID)}.Encode()
 	}
+	if transactions == nil {
+		transactions = []model.Transaction{}
+	}
+	response.Items = transactions
 	s.logs.Info("transactions returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
 
-	return ctx.Status(http.StatusOK).JSON(transactions)
+// searchTransactionsRequest filters the transactions of a user, filters left out match every transaction
+type searchTransactionsRequest struct {
+	Cursor          string                `query:"cursor"`
+	PageSize        int32                 `query:"page_size" validate:"omitempty,min=1,max=100"`
+	AccountID       model.AccountID       `query:"account_id" validate:"omitempty,uuid"`
+	CategoryID      model.CategoryID      `query:"category_id" validate:"omitempty,uuid"`
+	MerchantID      model.MerchantID      `query:"merchant_id" validate:"omitempty,uuid"`
+	TransactionType model.TransactionType `query:"type" validate:"omitempty,oneof=income expense transfer_out transfer_in"`
+	// MinAmount and MaxAmount are in minor units of the account currency
+	MinAmount *int64    `query:"min_amount" validate:"omitempty,min=0"`
+	MaxAmount *int64    `query:"max_amount" validate:"omitempty,min=0"`
+	From      time.Time `query:"from"`
+	To        time.Time `query:"to"`
+	// Query is searched for in the names and notes, every word has to start a word of either
+	Query string `query:"q" validate:"max=255"`
+	// Sort is date or amount, Order asc or desc. The latest transactions come first when they are not given
+	Sort  model.TransactionSort `query:"sort" validate:"omitempty,oneof=date amount"`
+	Order string                `query:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+// searchTransactions returns the transactions of the user matching the filters of the request
+func (s *Server) searchTransactions(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "transactions.go -> searchTransactions()").Debug()
+	var req searchTransactionsRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("min_amount is above max_amount")))
+	}
+	if !req.From.IsZero() && !req.To.IsZero() && !req.To.After(req.From) {
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("to has to be after from")))
+	}
+	if req.Sort == "" {
+		req.Sort = model.SortByDate
+	}
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
+	args := db.SearchTransactionsParams{
+		UserID:          userID,
+		AccountID:       req.AccountID,
+		CategoryID:      req.CategoryID,
+		MerchantID:      req.MerchantID,
+		TransactionType: req.TransactionType,
+		MinAmount:       req.MinAmount,
+		MaxAmount:       req.MaxAmount,
+		From:            req.From,
+		To:              req.To,
+		Query:           req.Query,
+		Sort:            req.Sort,
+		Descending:      req.Order != "asc",
+		Limit:           size + 1,
+		Cursor:          cursor,
+	}
+	transactions, err := s.repo.SearchTransactions(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	total, err := s.repo.CountSearchTransactions(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := page{Total: total}
+	if len(transactions) > int(size) {
+		transactions = transactions[:size]
+		last := transactions[size-1]
+		response.NextCursor = model.Cursor{Date: last.Date, Amount: last.Amount, ID: string(last.ID)}.Encode()
+	}
+	if transactions == nil {
+		transactions = []model.Transaction{}
+	}
+	response.Items = transactions
+	s.logs.Info("transactions searched successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 func (s *Server) listTransactionsByAccountID(ctx *fiber.Ctx) error {
@@ -194,13 +449,18 @@ func (s *Server) listTransactionsByAccountID(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
 	args := db.ListTxByAccountIDParams{
 		AccountID: accountID,
-		Limit:     req.PageSize,
-		Offset:    (req.PageID - 1) * req.PageSize,
+		Limit:     size + 1,
 		From:      req.From,
 		To:        req.To,
+		Cursor:    cursor,
 	}
 	transactions, err := s.repo.ListTransactionsByAccountID(ctx.Context(), args)
 	if err != nil {
@@ -208,13 +468,24 @@ func (s *Server) listTransactionsByAccountID(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	if len(transactions) == 0 {
-		status = http.StatusNotFound
-		return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+	total, err := s.repo.CountTransactionsByAccountID(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
+	response := page{Total: total}
+	if len(transactions) > int(size) {
+		transactions = transactions[:size]
+		last := transactions[size-1]
+		response.NextCursor = model.Cursor{Date: last.Date, ID: string(last.ID)}.Encode()
+	}
+	if transactions == nil {
+		transactions = []model.Transaction{}
+	}
+	response.Items = transactions
 	s.logs.Info("transactions returned successfully")
-
-	return ctx.Status(http.StatusOK).JSON(transactions)
+	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 func (s *Server) listTransactionsByCategoryID(ctx *fiber.Ctx) error {
@@ -236,13 +507,18 @@ func (s *Server) listTransactionsByCategoryID(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
 	args := db.ListTxByCategoryIDParams{
 		CategoryID: categoryID,
-		Limit:      req.PageSize,
-		Offset:     (req.PageID - 1) * req.PageSize,
+		Limit:      size + 1,
 		From:       req.From,
 		To:         req.To,
+		Cursor:     cursor,
 	}
 	transactions, err := s.repo.ListTransactionsByCategoryID(ctx.Context(), args)
 	if err != nil {
@@ -250,12 +526,24 @@ func (s *Server) listTransactionsByCategoryID(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	if len(transactions) == 0 {
-		status = http.StatusNotFound
-		return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+	total, err := s.repo.CountTransactionsByCategoryID(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
+	response := page{Total: total}
+	if len(transactions) > int(size) {
+		transactions = transactions[:size]
+		last := transactions[size-1]
+		response.NextCursor = model.Cursor{Date: last.Date, ID: string(last.ID)}.Encode()
+	}
+	if transactions == nil {
+		transactions = []model.Transaction{}
+	}
+	response.Items = transactions
 	s.logs.Info("transactions returned successfully")
-	return ctx.Status(http.StatusOK).JSON(transactions)
+	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 func (s *Server) deleteTransaction(ctx *fiber.Ctx) error {
@@ -267,12 +555,23 @@ func (s *Server) deleteTransaction(ctx *fiber.Ctx) error {
 		return ctx.Status(status).JSON(errorResponse(status, errors.New("transactionID not provided")))
 	}
 
-	deletedAt, err := s.repo.DeleteTransaction(ctx.Context(), transactionID)
+	userID := ctx.Locals("userID").(model.UserID)
+	args := db.DeleteTransactionParams{
+		TransactionID: transactionID,
+		UserID:        userID,
+		Unlock:        ctx.Query("unlock") == "true",
+	}
+	deletedAt, err := s.repo.DeleteTransactionTx(ctx.Context(), args)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
 			s.logs.WithError(err).Warn()
 			status = http.StatusNotFound
 			return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+		case errors.Is(err, db.ErrTransferLeg), errors.Is(err, db.ErrTransactionReconciled):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
 		}
 		s.logs.WithError(err).Warn()
 		status = http.StatusInternalServerError
@@ -282,3 +581,51 @@ func (s *Server) deleteTransaction(ctx *fiber.Ctx) error {
 
 	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(transactionDeletedMSG, deletedAt.Format(time.ANSIC))})
 }
+
+// transactionStatusRequest moves transactions to a status, reconciled transactions are only moved back to another
+// status when unlock is set
+type transactionStatusRequest struct {
+	TransactionIDs []model.TransactionID   `json:"transaction_ids" validate:"required,min=1,max=500,dive,uuid"`
+	Status         model.TransactionStatus `json:"status" validate:"required,oneof=pending cleared reconciled"`
+	Unlock         bool                    `json:"unlock"`
+}
+
+// setTransactionStatus marks transactions pending, cleared or reconciled, either all of them are marked or none are
+func (s *Server) setTransactionStatus(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "transactions.go -> setTransactionStatus()").Debug()
+	var req transactionStatusRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	transactions, err := s.repo.SetTransactionStatusTx(ctx.Context(), db.SetTransactionStatusParams{
+		UserID:         userID,
+		TransactionIDs: req.TransactionIDs,
+		Status:         req.Status,
+		Unlock:         req.Unlock,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, transactionNotFound))
+		case errors.Is(err, db.ErrTransactionReconciled):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn("could not change transaction status")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("transaction status changed successfully")
+	return ctx.Status(http.StatusOK).JSON(transactions)
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	model "FiberFinanceAPI/database/models"
+	db "FiberFinanceAPI/database/sqlc"
+	"FiberFinanceAPI/utils"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+var (
+	transferNotFound   = errors.New("transfer(s) not found or deleted")
+	transferDeletedMSG = "transfer successfully deleted at %s"
+)
+
+// transferRequest moves an amount between two accounts of the same currency, the amount is in minor units or
+// Money in the currency of the accounts
+type transferRequest struct {
+	FromAccountID model.AccountID `json:"from_account_id" validate:"required"`
+	ToAccountID   model.AccountID `json:"to_account_id" validate:"required,nefield=FromAccountID"`
+	Amount        utils.Money     `json:"amount" validate:"required,gt=0"`
+	Notes         string          `json:"notes"`
+	Date          time.Time       `json:"date" validate:"required"`
+}
+
+func (s *Server) createTransfer(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "transfers.go -> createTransfer()").Debug()
+	var req transferRequest
+	userID := ctx.Locals("userID").(model.UserID)
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	args := db.CreateTransferParams{
+		UserID:        userID,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount.Amount,
+		Notes:         req.Notes,
+		Date:          req.Date,
+		Currency:      req.Amount.Currency,
+	}
+	transfer, err := s.repo.CreateTransferTx(ctx.Context(), args)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrAccountNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		case errors.Is(err, db.ErrAccountNotOwned):
+			s.logs.WithError(err).Warn()
+			status = http.StatusForbidden
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrCurrencyMismatch), errors.Is(err, db.ErrAmountCurrency):
+			s.logs.WithError(err).Warn()
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Transfer created successfully")
+	return ctx.Status(http.StatusCreated).JSON(transfer)
+}
+
+func (s *Server) updateTransfer(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "transfers.go -> updateTransfer()").Debug()
+	var req transferRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	transferID := model.TransferID(ctx.Params("transferID"))
+	if transferID == "" {
+		s.logs.WithField("transferID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("transferID not provided")))
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	args := db.UpdateTransferParams{
+		TransferID:    transferID,
+		UserID:        userID,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount.Amount,
+		Notes:         req.Notes,
+		Date:          req.Date,
+		Currency:      req.Amount.Currency,
+		Unlock:        ctx.Query("unlock") == "true",
+	}
+	transfer, err := s.repo.UpdateTransferTx(ctx.Context(), args)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, transferNotFound))
+		case errors.Is(err, db.ErrAccountNotFound):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, accountNotFound))
+		case errors.Is(err, db.ErrAccountNotOwned):
+			s.logs.WithError(err).Warn()
+			status = http.StatusForbidden
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrCurrencyMismatch), errors.Is(err, db.ErrAmountCurrency):
+			s.logs.WithError(err).Warn()
+			status = http.StatusBadRequest
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		case errors.Is(err, db.ErrTransactionReconciled):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn("could not update transfer")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Transfer updated successfully")
+	return ctx.Status(http.StatusOK).JSON(transfer)
+}
+
+func (s *Server) getTransfer(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "transfers.go -> getTransfer()").Debug()
+	userID := ctx.Locals("userID").(model.UserID)
+	transferID := model.TransferID(ctx.Params("transferID"))
+	if transferID == "" {
+		s.logs.WithField("transferID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("transferID not provided")))
+	}
+	transfer, err := s.repo.GetTransferByID(ctx.Context(), transferID)
+	if err == nil && transfer.UserID != userID {
+		err = sql.ErrNoRows
+	}
+	if err == nil {
+		transfer.Transactions, err = s.repo.ListTransactionsByTransferID(ctx.Context(), transferID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, transferNotFound))
+		}
+		s.logs.WithError(err).Warn("could not get transfer")
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("Transfer returned successfully")
+	return ctx.Status(http.StatusOK).JSON(transfer)
+}
+
+func (s *Server) listTransfers(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "transfers.go -> listTransfers()").Debug()
+	var req listTransactionsRequest
+	userID := ctx.Locals("userID").(model.UserID)
+
+	if err := ctx.QueryParser(&req); err != nil {
+		s.logs.WithError(err).Warn("cannot decode parameters")
+		status = http.StatusUnprocessableEntity
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	if errs := s.validate.validateRequests(&req); len(errs) > 0 {
+		s.logs.Warn("request data is invalid")
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errs)
+	}
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
+	args := db.ListTransfersParams{
+		UserID: userID,
+		Limit:  size + 1,
+		From:   req.From,
+		To:     req.To,
+		Cursor: cursor,
+	}
+	transfers, err := s.repo.ListTransfers(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	total, err := s.repo.CountTransfers(ctx.Context(), args)
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := page{Total: total}
+	if len(transfers) > int(size) {
+		transfers = transfers[:size]
+		last := transfers[size-1]
+		response.NextCursor = model.Cursor{Date: last.Date, ID: string(last.ID)}.Encode()
+	}
+	if transfers == nil {
+		transfers = []model.Transfer{}
+	}
+	response.Items = transfers
+	s.logs.Info("transfers returned successfully")
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+func (s *Server) deleteTransfer(ctx *fiber.Ctx) error {
+	s.logs.WithField("func", "transfers.go -> deleteTransfer()").Debug()
+	transferID := model.TransferID(ctx.Params("transferID"))
+	if transferID == "" {
+		s.logs.WithField("transferID", "not provided").Debug()
+		status = http.StatusBadRequest
+		return ctx.Status(status).JSON(errorResponse(status, errors.New("transferID not provided")))
+	}
+
+	userID := ctx.Locals("userID").(model.UserID)
+	args := db.DeleteTransferParams{
+		TransferID: transferID,
+		UserID:     userID,
+		Unlock:     ctx.Query("unlock") == "true",
+	}
+	deletedAt, err := s.repo.DeleteTransferTx(ctx.Context(), args)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			s.logs.WithError(err).Warn()
+			status = http.StatusNotFound
+			return ctx.Status(status).JSON(errorResponse(status, transferNotFound))
+		case errors.Is(err, db.ErrTransactionReconciled):
+			s.logs.WithError(err).Warn()
+			status = http.StatusConflict
+			return ctx.Status(status).JSON(errorResponse(status, err))
+		}
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	s.logs.Info("transfer deleted successfully")
+
+	return ctx.Status(http.StatusOK).JSON(fiber.Map{"message": fmt.Sprintf(transferDeletedMSG, deletedAt.Format(time.ANSIC))})
+}
@@ -28,6 +28,13 @@ type createUserRequest struct {
 	model.SessionDeviceID
 	Email    string `json:"email"  validate:"required,max=155,email"`
 	Password string `json:"password" validate:"required,min=6,max=55"`
+	// BaseCurrency is the currency totals are converted to, the currency of the signup template of the locale or of
+	// the first account of the user when it is not given
+	BaseCurrency utils.CurrencyCode `json:"base_currency" validate:"omitempty,currency"`
+	// Locale picks the starter categories and account the user is given, e.g. en-KE
+	Locale string `json:"locale" validate:"omitempty,bcp47_language_tag"`
+	// SkipTemplate leaves the user with no categories or account
+	SkipTemplate bool `json:"skip_template"`
 }
 
 // createUser request to be stored in our database
@@ -55,11 +62,16 @@ func (s *Server) createUser(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	args := db.CreateUserParams{
-		Email:        req.Email,
-		PasswordHash: hashPassword,
+	args := db.SignupParams{
+		User: db.CreateUserParams{
+			Email:        req.Email,
+			PasswordHash: hashPassword,
+			BaseCurrency: req.BaseCurrency,
+		},
+		Locale:       req.Locale,
+		SkipTemplate: req.SkipTemplate,
 	}
-	user, err := s.repo.CreateUser(ctx.Context(), args)
+	user, err := s.repo.CreateUserTx(ctx.Context(), args)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			s.logs.WithField(string(pqErr.Code), pqErr.Code.Name()).Debug("postgres codes")
@@ -176,8 +188,9 @@ func (s *Server) getUserByID(ctx *fiber.Ctx) error {
 }
 
 type listUsersRequest struct {
-	PageID   int32 `query:"page_id" validate:"required,min=1"`
-	PageSize int32 `query:"page_size" validate:"required,min=5,max=10"`
+	// Cursor is the next_cursor of the previous page, the first page is returned without it
+	Cursor   string `query:"cursor"`
+	PageSize int32  `query:"page_size" validate:"omitempty,min=1,max=100"`
 }
 
 func (s *Server) listUsers(ctx *fiber.Ctx) error {
@@ -193,10 +206,15 @@ func (s *Server) listUsers(ctx *fiber.Ctx) error {
 		status = http.StatusBadRequest
 		return ctx.Status(status).JSON(errs)
 	}
-	s.logs.WithFields(logrus.Fields{"limit": req.PageSize, "offset": (req.PageID - 1) * req.PageSize}).Debug()
+	cursor, ok, err := s.parseCursor(ctx, req.Cursor)
+	if !ok {
+		return err
+	}
+	size := pageSize(req.PageSize)
+	s.logs.WithFields(logrus.Fields{"limit": size, "cursor": req.Cursor}).Debug()
 	args := db.ListUserParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Limit:  size + 1,
+		Cursor: cursor,
 	}
 	users, err := s.repo.ListUsers(ctx.Context(), args)
 	if err != nil {
@@ -204,13 +222,24 @@ func (s *Server) listUsers(ctx *fiber.Ctx) error {
 		status = http.StatusInternalServerError
 		return ctx.Status(status).JSON(errorResponse(status, err))
 	}
-	if len(users) == 0 {
-		status = http.StatusNotFound
-		return ctx.Status(status).JSON(errorResponse(status, userNotFound))
+	total, err := s.repo.CountUsers(ctx.Context())
+	if err != nil {
+		s.logs.WithError(err).Warn()
+		status = http.StatusInternalServerError
+		return ctx.Status(status).JSON(errorResponse(status, err))
+	}
+	response := page{Total: total}
+	if len(users) > int(size) {
+		users = users[:size]
+		last := users[size-1]
+		response.NextCursor = model.Cursor{Date: last.CreatedAt, ID: string(last.ID)}.Encode()
 	}
+	if users == nil {
+		users = []model.User{}
+	}
+	response.Items = users
 	s.logs.Info("users returned successfully")
-
-	return ctx.Status(http.StatusOK).JSON(users)
+	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 type changePasswordRequest struct {
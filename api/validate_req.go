@@ -9,11 +9,13 @@ import (
 	enTranslation "github.com/go-playground/validator/v10/translations/en"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
+	"reflect"
 )
 
 const (
 	//{0} should be the field, and {1} should be the param if provided
 	invalidCurrencyMSG = "{0} provided is currently not supported"
+	invalidRuleMSG     = "{0} is not a supported recurrence rule"
 )
 
 // validates is our request validate interface
@@ -39,6 +41,12 @@ func newValidator(logs *utils.StandardLogger) validates {
 		v.logs.WithError(err).Warn("could not register validation")
 		return nil
 	}
+	err = v.validate.RegisterValidation("rrule", validRule)
+	if err != nil {
+		v.logs.WithError(err).Warn("could not register validation")
+		return nil
+	}
+	v.validate.RegisterCustomTypeFunc(moneyAmount, utils.Money{})
 	return v
 }
 
@@ -50,6 +58,21 @@ var validCurrency validator.Func = func(fl validator.FieldLevel) bool {
 	return false
 }
 
+// moneyAmount Register Money to be validated on its amount in minor units, so gt and min apply to the amount.
+// The currency of the amount is checked against the account it is posted to
+var moneyAmount validator.CustomTypeFunc = func(field reflect.Value) interface{} {
+	if money, ok := field.Interface().(utils.Money); ok {
+		return money.Amount
+	}
+	return nil
+}
+
+// validRule Register our validator for the recurrence rules supported
+var validRule validator.Func = func(fl validator.FieldLevel) bool {
+	_, err := utils.ParseRule(fl.Field().String())
+	return err == nil
+}
+
 // validateRequests validates our struct requests
 func (v *validateRequest) validateRequests(req interface{}) (errs []fiber.Map) {
 	v.logs.WithField("func", "validate_req.go -> validateRequests()").Debug()
@@ -65,6 +88,7 @@ func (v *validateRequest) validateRequests(req interface{}) (errs []fiber.Map) {
 			return nil
 		}
 		v.addTranslation("currency", invalidCurrencyMSG)
+		v.addTranslation("rrule", invalidRuleMSG)
 		_ = enTranslation.RegisterDefaultTranslations(v.validate, v.translator)
 		errs = v.translateError(err)
 	}
@@ -0,0 +1,229 @@
+// Package backup writes a user's data to a versioned archive and reads it back for a restore. An archive is
+// a zip of JSON documents, manifest.json tells which schema version the documents follow
+package backup
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+// SchemaVersion is the version of the documents written to new archives. It goes up whenever a document
+// changes in a way older readers cannot handle, archives of any version up to it can be restored
+const SchemaVersion = 1
+
+const (
+	manifestFile       = "manifest.json"
+	accountsFile       = "accounts.json"
+	categoriesFile     = "categories.json"
+	merchantsFile      = "merchants.json"
+	transfersFile      = "transfers.json"
+	budgetsFile        = "budgets.json"
+	recurringFile      = "recurring.json"
+	importMappingsFile = "import_mappings.json"
+	transactionsFile   = "transactions.json"
+)
+
+var (
+	// ErrInvalidArchive is returned when a file is not a backup archive or one of its documents is malformed
+	ErrInvalidArchive = errors.New("invalid backup archive")
+	// ErrUnsupportedVersion is returned for archives written by a newer version of the schema
+	ErrUnsupportedVersion = errors.New("backup archive schema version is not supported")
+)
+
+// Manifest describes an archive
+type Manifest struct {
+	SchemaVersion int          `json:"schema_version"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UserID        model.UserID `json:"user_id"`
+}
+
+// Data is what an archive holds besides its transactions, which are written a page at a time
+type Data struct {
+	Accounts       []model.Account              `json:"accounts"`
+	Categories     []model.Category             `json:"categories"`
+	Merchants      []model.Merchant             `json:"merchants"`
+	Transfers      []model.Transfer             `json:"transfers"`
+	Budgets        []model.Budget               `json:"budgets"`
+	Recurring      []model.RecurringTransaction `json:"recurring"`
+	ImportMappings []model.ImportMapping        `json:"import_mappings"`
+}
+
+// Archive is a backup as it was read
+type Archive struct {
+	Manifest     Manifest
+	Data         Data
+	Transactions []model.Transaction
+}
+
+// Writer writes an archive. Begin writes the manifest and every document but the transactions, which are
+// streamed by Transactions in as many pages as needed, Close finishes the archive
+type Writer struct {
+	zip   *zip.Writer
+	entry io.Writer
+	first bool
+}
+
+// NewWriter returns a Writer writing an archive to w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{zip: zip.NewWriter(w), first: true}
+}
+
+func (w *Writer) Begin(manifest Manifest, data Data) error {
+	manifest.SchemaVersion = SchemaVersion
+	for _, doc := range []struct {
+		name string
+		v    interface{}
+	}{
+		{manifestFile, manifest},
+		{accountsFile, data.Accounts},
+		{categoriesFile, data.Categories},
+		{merchantsFile, data.Merchants},
+		{transfersFile, data.Transfers},
+		{budgetsFile, data.Budgets},
+		{recurringFile, data.Recurring},
+		{importMappingsFile, data.ImportMappings},
+	} {
+		if err := w.create(doc.name); err != nil {
+			return err
+		}
+		if err := json.NewEncoder(w.entry).Encode(doc.v); err != nil {
+			return err
+		}
+	}
+	if err := w.create(transactionsFile); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.entry, "[")
+	return err
+}
+
+func (w *Writer) Transactions(transactions []model.Transaction) error {
+	for _, transaction := range transactions {
+		b, err := json.Marshal(transaction)
+		if err != nil {
+			return err
+		}
+		if !w.first {
+			b = append([]byte{','}, b...)
+		}
+		w.first = false
+		if _, err = w.entry.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Writer) Close() error {
+	if _, err := io.WriteString(w.entry, "]\n"); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// create starts the next document of the archive
+func (w *Writer) create(name string) error {
+	entry, err := w.zip.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	w.entry = entry
+	return nil
+}
+
+// Read reads an archive of size bytes, checking its schema version and that every identifier in it is one
+func Read(r io.ReaderAt, size int64) (Archive, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return Archive{}, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	files := make(map[string]*zip.File, len(z.File))
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+	var archive Archive
+	if err = readDocument(files, manifestFile, &archive.Manifest); err != nil {
+		return Archive{}, err
+	}
+	if archive.Manifest.SchemaVersion < 1 {
+		return Archive{}, fmt.Errorf("%w: manifest has no schema version", ErrInvalidArchive)
+	}
+	if archive.Manifest.SchemaVersion > SchemaVersion {
+		return Archive{}, fmt.Errorf("%w: %d, newest supported is %d", ErrUnsupportedVersion,
+			archive.Manifest.SchemaVersion, SchemaVersion)
+	}
+	for _, doc := range []struct {
+		name string
+		v    interface{}
+	}{
+		{accountsFile, &archive.Data.Accounts},
+		{categoriesFile, &archive.Data.Categories},
+		{merchantsFile, &archive.Data.Merchants},
+		{transfersFile, &archive.Data.Transfers},
+		{budgetsFile, &archive.Data.Budgets},
+		{recurringFile, &archive.Data.Recurring},
+		{importMappingsFile, &archive.Data.ImportMappings},
+		{transactionsFile, &archive.Transactions},
+	} {
+		if err = readDocument(files, doc.name, doc.v); err != nil {
+			return Archive{}, err
+		}
+	}
+	return archive, archive.check()
+}
+
+// readDocument decodes the document name of the archive into v
+func readDocument(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%w: %s is missing", ErrInvalidArchive, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+	defer rc.Close()
+	if err = json.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+	return nil
+}
+
+// check makes sure every identifier of the archive is a UUID, so a restore never sends anything else to the
+// database. References may be empty
+func (a Archive) check() error {
+	var ids []string
+	for _, account := range a.Data.Accounts {
+		ids = append(ids, string(account.AccountID))
+	}
+	for _, category := range a.Data.Categories {
+		ids = append(ids, string(category.ID))
+	}
+	for _, merchant := range a.Data.Merchants {
+		ids = append(ids, string(merchant.ID))
+	}
+	for _, transfer := range a.Data.Transfers {
+		ids = append(ids, string(transfer.ID), string(transfer.FromAccountID), string(transfer.ToAccountID))
+	}
+	for _, budget := range a.Data.Budgets {
+		ids = append(ids, string(budget.ID), string(budget.CategoryID))
+	}
+	for _, recurring := range a.Data.Recurring {
+		ids = append(ids, string(recurring.ID), string(recurring.AccountID))
+	}
+	for _, transaction := range a.Transactions {
+		ids = append(ids, string(transaction.ID), string(transaction.AccountID))
+	}
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("%w: %q is not an identifier", ErrInvalidArchive, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1 @@
+-- the stale balances are not put back
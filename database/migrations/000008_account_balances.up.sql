@@ -0,0 +1,8 @@
+-- balances were never kept up to date before transactions applied their amounts, every account is brought to the
+-- signed sum of its live transactions so the journal written next and the account balances agree
+UPDATE accounts a SET balance = COALESCE((
+    SELECT SUM(CASE WHEN t.transaction_type = 'expense' THEN -t.amount ELSE t.amount END)
+    FROM transactions t
+    WHERE t.account_id = a.account_id
+    AND t.deleted_at = '0001-01-01 00:00:00Z'
+), 0);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS transactions_transfer_idx;
+
+DELETE FROM transactions WHERE transfer_id IS NOT NULL;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS "transactions_transfer_id_fkey";
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
+ALTER TABLE transactions ALTER COLUMN category_id SET NOT NULL;
+
+ALTER TABLE transfers DROP CONSTRAINT IF EXISTS "transfer_accounts_check";
+DROP TABLE IF EXISTS transfers;
+
+-- postgres cannot drop enum values, 'transfer_out' and 'transfer_in' remain in transactions_type
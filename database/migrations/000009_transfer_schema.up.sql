@@ -0,0 +1,23 @@
+-- a transfer is stored once in transfers and as two linked transactions, one leg on each account
+ALTER TYPE transactions_type ADD VALUE IF NOT EXISTS 'transfer_out';
+ALTER TYPE transactions_type ADD VALUE IF NOT EXISTS 'transfer_in';
+
+CREATE TABLE IF NOT EXISTS transfers(
+    transfer_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users,
+    from_account_id UUID NOT NULL REFERENCES accounts,
+    to_account_id UUID NOT NULL REFERENCES accounts,
+    amount BIGINT NOT NULL,
+    notes VARCHAR NOT NULL DEFAULT '',
+    date TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+ALTER TABLE transfers ADD CONSTRAINT "transfer_accounts_check" CHECK (from_account_id <> to_account_id);
+
+-- transfer legs have no category
+ALTER TABLE transactions ALTER COLUMN category_id DROP NOT NULL;
+ALTER TABLE transactions ADD COLUMN transfer_id UUID REFERENCES transfers;
+
+CREATE INDEX transactions_transfer_idx ON transactions(transfer_id);
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS postings_balanced ON postings;
+DROP FUNCTION IF EXISTS check_journal_balanced();
+
+ALTER TABLE postings DROP CONSTRAINT IF EXISTS "postings_transaction_id_fkey";
+ALTER TABLE postings DROP CONSTRAINT IF EXISTS "postings_user_id_fkey";
+ALTER TABLE postings DROP CONSTRAINT IF EXISTS "postings_account_id_fkey";
+ALTER TABLE postings DROP CONSTRAINT IF EXISTS "postings_category_id_fkey";
+
+DROP TABLE IF EXISTS postings;
+DROP TYPE IF EXISTS ledger_account_type;
@@ -0,0 +1,57 @@
+-- every transaction is a journal entry made up of postings that sum to zero.
+-- one side posts to the user's account (asset for cash, liability for credit) and the other side
+-- posts to the income or expense category, transfer legs post against the transfer clearing account
+
+CREATE TYPE ledger_account_type AS ENUM (
+    'asset',
+    'liability',
+    'income',
+    'expense',
+    'transfer'
+);
+
+CREATE TABLE IF NOT EXISTS postings(
+    posting_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL REFERENCES transactions,
+    user_id UUID NOT NULL REFERENCES users,
+    ledger_account ledger_account_type NOT NULL,
+    account_id UUID REFERENCES accounts,
+    category_id UUID REFERENCES categories,
+    amount BIGINT NOT NULL, -- debits are positive, credits negative
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX postings_transaction_idx ON postings(transaction_id);
+CREATE INDEX postings_user_idx ON postings(user_id, ledger_account);
+
+-- a journal entry must balance when the database transaction that wrote it commits
+CREATE OR REPLACE FUNCTION check_journal_balanced() RETURNS TRIGGER AS $$
+BEGIN
+    IF (SELECT COALESCE(SUM(amount), 0) FROM postings WHERE transaction_id = NEW.transaction_id) <> 0 THEN
+        RAISE EXCEPTION 'journal entry % does not balance', NEW.transaction_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER postings_balanced
+    AFTER INSERT OR UPDATE ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE PROCEDURE check_journal_balanced();
+
+-- journal the transactions recorded before the ledger existed
+INSERT INTO postings (transaction_id, user_id, ledger_account, account_id, amount)
+SELECT t.transaction_id, t.user_id,
+       CASE WHEN a.account_type = 'credit' THEN 'liability' ELSE 'asset' END::ledger_account_type,
+       t.account_id,
+       CASE WHEN t.transaction_type IN ('expense', 'transfer_out') THEN -t.amount ELSE t.amount END
+FROM transactions t JOIN accounts a ON a.account_id = t.account_id
+WHERE t.deleted_at = '0001-01-01 00:00:00Z';
+
+INSERT INTO postings (transaction_id, user_id, ledger_account, category_id, amount)
+SELECT t.transaction_id, t.user_id,
+       CASE WHEN t.transaction_type IN ('income', 'expense') THEN t.transaction_type::text ELSE 'transfer' END::ledger_account_type,
+       t.category_id,
+       CASE WHEN t.transaction_type IN ('expense', 'transfer_out') THEN t.amount ELSE -t.amount END
+FROM transactions t
+WHERE t.deleted_at = '0001-01-01 00:00:00Z';
@@ -0,0 +1,6 @@
+DROP VIEW IF EXISTS transaction_category_amounts;
+
+ALTER TABLE transaction_splits DROP CONSTRAINT IF EXISTS "transaction_splits_transaction_id_fkey";
+ALTER TABLE transaction_splits DROP CONSTRAINT IF EXISTS "transaction_splits_category_id_fkey";
+
+DROP TABLE IF EXISTS transaction_splits;
@@ -0,0 +1,19 @@
+-- a transaction can be split across several categories, the split amounts sum to the transaction amount
+CREATE TABLE IF NOT EXISTS transaction_splits(
+    split_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL REFERENCES transactions,
+    category_id UUID NOT NULL REFERENCES categories,
+    amount BIGINT NOT NULL,
+    notes VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX transaction_splits_transaction_idx ON transaction_splits(transaction_id);
+CREATE INDEX transaction_splits_category_idx ON transaction_splits(category_id);
+
+-- the amount each transaction contributes to a category, one row per split or the whole amount when it is not split
+CREATE OR REPLACE VIEW transaction_category_amounts AS
+SELECT t.transaction_id, t.user_id, t.account_id, COALESCE(s.category_id, t.category_id) AS category_id,
+       t.transaction_type, COALESCE(s.amount, t.amount) AS amount, t.date, t.deleted_at
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.transaction_id;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS transactions_merchant_idx;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS "transactions_merchant_id_fkey";
+ALTER TABLE transactions DROP COLUMN IF EXISTS merchant_id;
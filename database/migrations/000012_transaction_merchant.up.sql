@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN merchant_id UUID REFERENCES merchant;
+
+CREATE INDEX transactions_merchant_idx ON transactions(merchant_id);
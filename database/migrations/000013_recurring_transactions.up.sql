@@ -0,0 +1,24 @@
+-- a template a transaction is posted from every time its rule falls due, the rule is a subset of an RFC 5545 RRULE
+CREATE TABLE IF NOT EXISTS recurring_transactions(
+    recurring_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users,
+    account_id UUID NOT NULL REFERENCES accounts,
+    category_id UUID NOT NULL REFERENCES categories,
+    merchant_id UUID REFERENCES merchant,
+    name VARCHAR NOT NULL,
+    transaction_type transactions_type NOT NULL,
+    amount BIGINT NOT NULL,
+    notes VARCHAR NOT NULL DEFAULT '',
+    rule VARCHAR NOT NULL,
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+    skip_dates DATE[] NOT NULL DEFAULT '{}',
+    -- the date the next occurrence is due on, zero once the rule has no occurrences left
+    next_run TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+CREATE INDEX recurring_transactions_user_idx ON recurring_transactions(user_id);
+CREATE INDEX recurring_transactions_next_run_idx ON recurring_transactions(next_run)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
@@ -0,0 +1,16 @@
+-- what a user plans to spend in a category in a month, month is the first day of the month
+CREATE TABLE IF NOT EXISTS budgets(
+    budget_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users,
+    category_id UUID NOT NULL REFERENCES categories,
+    month DATE NOT NULL CHECK (EXTRACT(DAY FROM month) = 1),
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    -- whatever is left over, or overspent, moves on to the budget of the category for the following month
+    rollover BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+CREATE UNIQUE INDEX budgets_category_month_uiq ON budgets(category_id, month)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX budgets_user_month_idx ON budgets(user_id, month);
@@ -0,0 +1,12 @@
+-- the columns of a user's CSV statements, saved so the same bank export can be imported again without remapping
+CREATE TABLE IF NOT EXISTS import_mappings(
+    mapping_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users,
+    name VARCHAR NOT NULL,
+    mapping JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+CREATE UNIQUE INDEX import_mappings_user_name_uiq ON import_mappings(user_id, name)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
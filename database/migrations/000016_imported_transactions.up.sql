@@ -0,0 +1,8 @@
+-- the identifier a bank gave each imported entry (the OFX FITID), so a statement imported again is not duplicated
+CREATE TABLE IF NOT EXISTS imported_transactions(
+    account_id UUID NOT NULL REFERENCES accounts,
+    external_id VARCHAR NOT NULL,
+    transaction_id UUID NOT NULL REFERENCES transactions,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (account_id, external_id)
+);
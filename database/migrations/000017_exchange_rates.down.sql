@@ -0,0 +1,5 @@
+DROP FUNCTION IF EXISTS convert_amount(BIGINT, VARCHAR, VARCHAR, DATE);
+DROP FUNCTION IF EXISTS exchange_rate(VARCHAR, VARCHAR, DATE);
+DROP FUNCTION IF EXISTS pair_rate(VARCHAR, VARCHAR, DATE);
+ALTER TABLE users DROP COLUMN IF EXISTS base_currency;
+DROP TABLE IF EXISTS exchange_rates;
@@ -0,0 +1,58 @@
+-- daily exchange rates, one unit of base is worth rate units of quote on date
+CREATE TABLE IF NOT EXISTS exchange_rates(
+    base VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    date DATE NOT NULL,
+    rate NUMERIC NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (base, quote, date)
+);
+
+CREATE INDEX exchange_rates_quote_idx ON exchange_rates(quote, date);
+
+-- the currency totals across a user's accounts are converted to
+ALTER TABLE users ADD COLUMN base_currency VARCHAR(10) NOT NULL DEFAULT 'USD';
+
+-- the latest rate of a pair on or before a date
+CREATE OR REPLACE FUNCTION pair_rate(base_currency VARCHAR, quote_currency VARCHAR, on_date DATE) RETURNS NUMERIC AS $$
+    SELECT rate FROM exchange_rates
+    WHERE base = base_currency AND quote = quote_currency AND date <= on_date
+    ORDER BY date DESC
+    LIMIT 1
+$$ LANGUAGE sql STABLE;
+
+-- the rate from one currency to another on a date, from the pair itself, its inverse or a cross rate through
+-- the base either currency was last quoted against. NULL when no rate is known on or before the date
+CREATE OR REPLACE FUNCTION exchange_rate(from_currency VARCHAR, to_currency VARCHAR, on_date DATE) RETURNS NUMERIC AS $$
+DECLARE
+    hub VARCHAR;
+    rate NUMERIC;
+BEGIN
+    IF from_currency = to_currency THEN
+        RETURN 1;
+    END IF;
+    rate := pair_rate(from_currency, to_currency, on_date);
+    IF rate IS NOT NULL THEN
+        RETURN rate;
+    END IF;
+    rate := pair_rate(to_currency, from_currency, on_date);
+    IF rate IS NOT NULL THEN
+        RETURN 1 / rate;
+    END IF;
+    FOREACH hub IN ARRAY ARRAY[
+        (SELECT base FROM exchange_rates WHERE quote = from_currency AND date <= on_date ORDER BY date DESC LIMIT 1),
+        (SELECT base FROM exchange_rates WHERE quote = to_currency AND date <= on_date ORDER BY date DESC LIMIT 1)
+    ] LOOP
+        rate := pair_rate(hub, to_currency, on_date) / pair_rate(hub, from_currency, on_date);
+        IF rate IS NOT NULL THEN
+            RETURN rate;
+        END IF;
+    END LOOP;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- an amount in minor units converted at the rate of a date, NULL when there is no rate
+CREATE OR REPLACE FUNCTION convert_amount(amount BIGINT, from_currency VARCHAR, to_currency VARCHAR, on_date DATE) RETURNS BIGINT AS $$
+    SELECT ROUND(amount * exchange_rate(from_currency, to_currency, on_date))::BIGINT
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,7 @@
+CREATE OR REPLACE FUNCTION convert_amount(amount BIGINT, from_currency VARCHAR, to_currency VARCHAR, on_date DATE) RETURNS BIGINT AS $$
+    SELECT ROUND(amount * exchange_rate(from_currency, to_currency, on_date))::BIGINT
+$$ LANGUAGE sql STABLE;
+DROP FUNCTION IF EXISTS currency_minor_units(VARCHAR);
+DROP TRIGGER IF EXISTS transactions_currency ON transactions;
+DROP FUNCTION IF EXISTS transaction_currency();
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
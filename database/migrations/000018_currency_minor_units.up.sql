@@ -0,0 +1,36 @@
+-- the currency of the account a transaction was made on, kept on the transaction so its amount can be read in
+-- the right minor units without joining its account
+ALTER TABLE transactions ADD COLUMN currency VARCHAR(10) NOT NULL DEFAULT '';
+
+UPDATE transactions t SET currency = a.currency
+FROM accounts a
+WHERE a.account_id = t.account_id;
+
+CREATE OR REPLACE FUNCTION transaction_currency() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.currency := (SELECT currency FROM accounts WHERE account_id = NEW.account_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_currency
+    BEFORE INSERT OR UPDATE OF account_id ON transactions
+    FOR EACH ROW EXECUTE PROCEDURE transaction_currency();
+
+-- the ISO 4217 minor units of a currency, mirrors utils.MinorUnits
+CREATE OR REPLACE FUNCTION currency_minor_units(currency VARCHAR) RETURNS INTEGER AS $$
+    SELECT CASE
+        WHEN currency IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI',
+                          'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 0
+        WHEN currency IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 3
+        WHEN currency IN ('CLF', 'UYW') THEN 4
+        ELSE 2
+    END
+$$ LANGUAGE sql IMMUTABLE;
+
+-- rates are between major units, so amounts in minor units are scaled by the difference in minor units of the
+-- two currencies, 100 USD cents at 3800 UGX a dollar are 3800 shillings
+CREATE OR REPLACE FUNCTION convert_amount(amount BIGINT, from_currency VARCHAR, to_currency VARCHAR, on_date DATE) RETURNS BIGINT AS $$
+    SELECT ROUND(amount * exchange_rate(from_currency, to_currency, on_date)
+        * power(10::NUMERIC, currency_minor_units(to_currency) - currency_minor_units(from_currency)))::BIGINT
+$$ LANGUAGE sql STABLE;
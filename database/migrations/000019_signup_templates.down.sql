@@ -0,0 +1 @@
+DROP TABLE IF EXISTS signup_templates;
@@ -0,0 +1,34 @@
+-- starter categories and account a new user is given, picked by the locale the user signs up with
+CREATE TABLE IF NOT EXISTS signup_templates(
+    template_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    locale VARCHAR NOT NULL,
+    categories JSONB NOT NULL DEFAULT '[]',
+    account_name VARCHAR NOT NULL DEFAULT 'Cash',
+    account_type VARCHAR NOT NULL DEFAULT 'cash',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+CREATE UNIQUE INDEX signup_templates_locale_uiq ON signup_templates(lower(locale))
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+
+-- en is the template users fall back to when there is none for their locale
+INSERT INTO signup_templates (locale, categories) VALUES
+('en', '[
+    {"name": "Food", "categories": [{"name": "Groceries"}, {"name": "Eating Out"}]},
+    {"name": "Transport", "categories": [{"name": "Public Transport"}, {"name": "Fuel"}]},
+    {"name": "Housing", "categories": [{"name": "Rent"}, {"name": "Utilities"}]},
+    {"name": "Health"},
+    {"name": "Entertainment"},
+    {"name": "Income", "categories": [{"name": "Salary"}]}
+]'),
+('en-KE', '[
+    {"name": "Food", "categories": [{"name": "Groceries"}, {"name": "Eating Out"}]},
+    {"name": "Transport", "categories": [{"name": "Matatu"}, {"name": "Boda Boda"}, {"name": "Fuel"}]},
+    {"name": "Housing", "categories": [{"name": "Rent"}, {"name": "Electricity"}, {"name": "Water"}]},
+    {"name": "Airtime & Data"},
+    {"name": "M-Pesa Charges"},
+    {"name": "Health"},
+    {"name": "Family Support"},
+    {"name": "Income", "categories": [{"name": "Salary"}, {"name": "Business"}]}
+]');
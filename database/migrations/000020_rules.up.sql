@@ -0,0 +1,19 @@
+-- rules give the transactions matching all of their conditions a category, merchant and notes, they run in
+-- order of position
+CREATE TABLE IF NOT EXISTS rules(
+    rule_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users,
+    name VARCHAR NOT NULL,
+    position INT NOT NULL,
+    conditions JSONB NOT NULL,
+    category_id UUID REFERENCES categories,
+    merchant_id UUID REFERENCES merchant,
+    notes VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+CREATE INDEX rules_user_position_idx ON rules(user_id, position)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX rules_category_idx ON rules(category_id);
+CREATE INDEX rules_merchant_idx ON rules(merchant_id);
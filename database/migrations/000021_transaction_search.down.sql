@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_user_date_idx;
+DROP INDEX IF EXISTS transactions_search_idx;
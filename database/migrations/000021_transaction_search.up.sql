@@ -0,0 +1,9 @@
+-- transaction searches match the words of the name and notes, the 'simple' configuration keeps words as they
+-- are written since names mix languages, M-Pesa references and merchant names no dictionary knows
+CREATE INDEX transactions_search_idx ON transactions
+    USING GIN (to_tsvector('simple', name || ' ' || notes))
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+
+-- searches of a user are ordered by date with the transaction ID breaking ties
+CREATE INDEX transactions_user_date_idx ON transactions(user_id, date DESC, transaction_id)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
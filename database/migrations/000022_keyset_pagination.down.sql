@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS users_created_idx;
+DROP INDEX IF EXISTS merchant_user_created_idx;
+DROP INDEX IF EXISTS categories_user_created_idx;
+DROP INDEX IF EXISTS accounts_user_created_idx;
+DROP INDEX IF EXISTS transfers_user_date_idx;
+DROP INDEX IF EXISTS transactions_merchant_date_idx;
+DROP INDEX IF EXISTS transactions_account_date_idx;
+
+DROP INDEX IF EXISTS transactions_user_date_idx;
+CREATE INDEX transactions_user_date_idx ON transactions(user_id, date DESC, transaction_id)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
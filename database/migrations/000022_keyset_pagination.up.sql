@@ -0,0 +1,20 @@
+-- lists page through their rows after the last row of the previous page instead of skipping an offset, these
+-- indexes hold the rows of each list in the order it returns them
+DROP INDEX IF EXISTS transactions_user_date_idx;
+CREATE INDEX transactions_user_date_idx ON transactions(user_id, date DESC, transaction_id DESC)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX transactions_account_date_idx ON transactions(account_id, date DESC, transaction_id DESC)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX transactions_merchant_date_idx ON transactions(merchant_id, date DESC, transaction_id DESC)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX transfers_user_date_idx ON transfers(user_id, date DESC, transfer_id DESC)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+
+CREATE INDEX accounts_user_created_idx ON accounts(user_id, created_at, account_id)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX categories_user_created_idx ON categories(user_id, created_at, category_id)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX merchant_user_created_idx ON merchant(user_id, created_at, merchant_id)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX users_created_idx ON users(created_at, user_id)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
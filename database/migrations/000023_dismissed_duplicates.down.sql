@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_account_amount_idx;
+DROP TABLE IF EXISTS dismissed_duplicates;
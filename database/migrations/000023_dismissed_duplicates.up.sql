@@ -0,0 +1,14 @@
+-- pairs of transactions the user reviewed and kept both of, they are not reported as duplicates again. The pair
+-- is saved with the lower ID first so it is found whichever way round it is dismissed
+CREATE TABLE IF NOT EXISTS dismissed_duplicates(
+    user_id UUID NOT NULL REFERENCES users,
+    transaction_id UUID NOT NULL REFERENCES transactions,
+    duplicate_id UUID NOT NULL REFERENCES transactions,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (transaction_id, duplicate_id),
+    CHECK (transaction_id < duplicate_id)
+);
+
+-- duplicates are looked for among the transactions of an account with the same amount
+CREATE INDEX transactions_account_amount_idx ON transactions(account_id, amount, date)
+    WHERE deleted_at = '0001-01-01 00:00:00Z' AND transfer_id IS NULL;
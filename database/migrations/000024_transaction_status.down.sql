@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_account_pending_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- pending transactions are not on the account statement yet, cleared ones are and reconciled ones are in a
+-- statement balance the account was reconciled with
+-- transactions recorded before statuses were kept are already in the account balances, so they are cleared and
+-- only new transactions start out pending
+ALTER TABLE transactions ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'cleared'
+    CHECK (status IN ('pending', 'cleared', 'reconciled'));
+ALTER TABLE transactions ALTER COLUMN status SET DEFAULT 'pending';
+
+-- the cleared balance of an account is its balance without its pending transactions
+CREATE INDEX transactions_account_pending_idx ON transactions(account_id)
+    WHERE deleted_at = '0001-01-01 00:00:00Z' AND status = 'pending';
@@ -0,0 +1,30 @@
+-- a reconciliation checks the transactions of an account against the ending balance of a statement, the ones
+-- that make up the balance are reconciled when it is completed. Completed reconciliations are the history of the
+-- account, opening_balance is the balance of the transactions reconciled before
+CREATE TABLE IF NOT EXISTS reconciliations(
+    reconciliation_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users,
+    account_id UUID NOT NULL REFERENCES accounts,
+    statement_date DATE NOT NULL,
+    statement_balance BIGINT NOT NULL,
+    opening_balance BIGINT NOT NULL DEFAULT 0,
+    transaction_count INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed')),
+    currency VARCHAR(10) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+-- an account is reconciled against one statement at a time
+CREATE UNIQUE INDEX reconciliations_open_uiq ON reconciliations(account_id)
+    WHERE status = 'open' AND deleted_at = '0001-01-01 00:00:00Z';
+CREATE INDEX reconciliations_account_date_idx ON reconciliations(account_id, statement_date DESC, reconciliation_id DESC)
+    WHERE deleted_at = '0001-01-01 00:00:00Z';
+
+-- the transactions a reconciliation reconciled
+CREATE TABLE IF NOT EXISTS reconciliation_transactions(
+    reconciliation_id UUID NOT NULL REFERENCES reconciliations,
+    transaction_id UUID NOT NULL REFERENCES transactions,
+    PRIMARY KEY (reconciliation_id, transaction_id)
+);
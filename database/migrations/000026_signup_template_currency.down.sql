@@ -0,0 +1 @@
+ALTER TABLE signup_templates DROP COLUMN IF EXISTS currency;
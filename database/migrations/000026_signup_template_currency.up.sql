@@ -0,0 +1,6 @@
+-- base currency of users signing up with the locale who do not give one, empty when the locale has none
+ALTER TABLE signup_templates ADD COLUMN IF NOT EXISTS currency VARCHAR(10) NOT NULL DEFAULT '';
+
+UPDATE signup_templates SET currency = 'KES'
+WHERE lower(locale) = 'en-ke'
+AND deleted_at = '0001-01-01 00:00:00Z';
@@ -0,0 +1,2 @@
+UPDATE users SET base_currency = 'USD' WHERE base_currency = '';
+ALTER TABLE users ALTER COLUMN base_currency SET DEFAULT 'USD';
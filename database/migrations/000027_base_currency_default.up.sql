@@ -0,0 +1,23 @@
+-- users who sign up without a base currency take that of their locale or of their first account, not USD
+ALTER TABLE users ALTER COLUMN base_currency SET DEFAULT '';
+
+-- users given USD by the old default who have no USD account take the currency of their oldest account
+UPDATE users u SET base_currency = (
+    SELECT a.currency FROM accounts a
+    WHERE a.user_id = u.user_id
+    AND a.deleted_at = '0001-01-01 00:00:00Z'
+    ORDER BY a.created_at
+    LIMIT 1
+)
+WHERE u.base_currency = 'USD'
+AND EXISTS (
+    SELECT 1 FROM accounts a
+    WHERE a.user_id = u.user_id
+    AND a.deleted_at = '0001-01-01 00:00:00Z'
+)
+AND NOT EXISTS (
+    SELECT 1 FROM accounts a
+    WHERE a.user_id = u.user_id
+    AND a.currency = 'USD'
+    AND a.deleted_at = '0001-01-01 00:00:00Z'
+);
@@ -0,0 +1,11 @@
+-- amounts are int64 minor units everywhere else, the view reading transactions.amount has to be dropped while its
+-- type changes
+DROP VIEW IF EXISTS transaction_category_amounts;
+
+ALTER TABLE transactions ALTER COLUMN amount TYPE BIGINT;
+
+CREATE OR REPLACE VIEW transaction_category_amounts AS
+SELECT t.transaction_id, t.user_id, t.account_id, COALESCE(s.category_id, t.category_id) AS category_id,
+       t.transaction_type, COALESCE(s.amount, t.amount) AS amount, t.date, t.deleted_at
+FROM transactions t
+LEFT JOIN transaction_splits s ON s.transaction_id = t.transaction_id;
@@ -5,9 +5,11 @@
 package mockdb
 
 import (
+	models "FiberFinanceAPI/database/models"
 	database "FiberFinanceAPI/database/sqlc"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,47 +37,2570 @@ func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
 	return m.recorder
 }
 
+// AddAccountBalance mocks base method.
+func (m *MockRepo) AddAccountBalance(arg0 context.Context, arg1 database.AddAccountBalanceParams) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAccountBalance", arg0, arg1)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAccountBalance indicates an expected call of AddAccountBalance.
+func (mr *MockRepoMockRecorder) AddAccountBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockRepo)(nil).AddAccountBalance), arg0, arg1)
+}
+
+// AddReconciliationTransaction mocks base method.
+func (m *MockRepo) AddReconciliationTransaction(arg0 context.Context, arg1 models.ReconciliationID, arg2 models.TransactionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReconciliationTransaction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReconciliationTransaction indicates an expected call of AddReconciliationTransaction.
+func (mr *MockRepoMockRecorder) AddReconciliationTransaction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReconciliationTransaction", reflect.TypeOf((*MockRepo)(nil).AddReconciliationTransaction), arg0, arg1, arg2)
+}
+
+// ApplyRuleChange mocks base method.
+func (m *MockRepo) ApplyRuleChange(arg0 context.Context, arg1 models.RuleChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyRuleChange", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyRuleChange indicates an expected call of ApplyRuleChange.
+func (mr *MockRepoMockRecorder) ApplyRuleChange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRuleChange", reflect.TypeOf((*MockRepo)(nil).ApplyRuleChange), arg0, arg1)
+}
+
+// ApplyRulesTx mocks base method.
+func (m *MockRepo) ApplyRulesTx(arg0 context.Context, arg1 database.ApplyRulesParams) ([]models.RuleChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyRulesTx", arg0, arg1)
+	ret0, _ := ret[0].([]models.RuleChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyRulesTx indicates an expected call of ApplyRulesTx.
+func (mr *MockRepoMockRecorder) ApplyRulesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRulesTx", reflect.TypeOf((*MockRepo)(nil).ApplyRulesTx), arg0, arg1)
+}
+
+// CategorySpendReport mocks base method.
+func (m *MockRepo) CategorySpendReport(arg0 context.Context, arg1 database.CategorySpendReportParams) ([]models.CategorySpend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategorySpendReport", arg0, arg1)
+	ret0, _ := ret[0].([]models.CategorySpend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategorySpendReport indicates an expected call of CategorySpendReport.
+func (mr *MockRepoMockRecorder) CategorySpendReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategorySpendReport", reflect.TypeOf((*MockRepo)(nil).CategorySpendReport), arg0, arg1)
+}
+
+// CompleteReconciliation mocks base method.
+func (m *MockRepo) CompleteReconciliation(arg0 context.Context, arg1 database.CompleteReconciliationParams) (models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteReconciliation", arg0, arg1)
+	ret0, _ := ret[0].(models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteReconciliation indicates an expected call of CompleteReconciliation.
+func (mr *MockRepoMockRecorder) CompleteReconciliation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReconciliation", reflect.TypeOf((*MockRepo)(nil).CompleteReconciliation), arg0, arg1)
+}
+
+// CompleteReconciliationTx mocks base method.
+func (m *MockRepo) CompleteReconciliationTx(arg0 context.Context, arg1 database.CompleteReconciliationTxParams) (models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteReconciliationTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteReconciliationTx indicates an expected call of CompleteReconciliationTx.
+func (mr *MockRepoMockRecorder) CompleteReconciliationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReconciliationTx", reflect.TypeOf((*MockRepo)(nil).CompleteReconciliationTx), arg0, arg1)
+}
+
+// ConvertAmount mocks base method.
+func (m *MockRepo) ConvertAmount(arg0 context.Context, arg1 database.ConvertAmountParams) (models.Conversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertAmount", arg0, arg1)
+	ret0, _ := ret[0].(models.Conversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertAmount indicates an expected call of ConvertAmount.
+func (mr *MockRepoMockRecorder) ConvertAmount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertAmount", reflect.TypeOf((*MockRepo)(nil).ConvertAmount), arg0, arg1)
+}
+
+// CountAccounts mocks base method.
+func (m *MockRepo) CountAccounts(arg0 context.Context, arg1 models.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccounts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccounts indicates an expected call of CountAccounts.
+func (mr *MockRepoMockRecorder) CountAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccounts", reflect.TypeOf((*MockRepo)(nil).CountAccounts), arg0, arg1)
+}
+
+// CountCategories mocks base method.
+func (m *MockRepo) CountCategories(arg0 context.Context, arg1 models.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCategories", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCategories indicates an expected call of CountCategories.
+func (mr *MockRepoMockRecorder) CountCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCategories", reflect.TypeOf((*MockRepo)(nil).CountCategories), arg0, arg1)
+}
+
+// CountCategoryUsage mocks base method.
+func (m *MockRepo) CountCategoryUsage(arg0 context.Context, arg1 models.CategoryID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCategoryUsage", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCategoryUsage indicates an expected call of CountCategoryUsage.
+func (mr *MockRepoMockRecorder) CountCategoryUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCategoryUsage", reflect.TypeOf((*MockRepo)(nil).CountCategoryUsage), arg0, arg1)
+}
+
+// CountMerchantTransactions mocks base method.
+func (m *MockRepo) CountMerchantTransactions(arg0 context.Context, arg1 database.ListTxByMerchantIDParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMerchantTransactions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMerchantTransactions indicates an expected call of CountMerchantTransactions.
+func (mr *MockRepoMockRecorder) CountMerchantTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMerchantTransactions", reflect.TypeOf((*MockRepo)(nil).CountMerchantTransactions), arg0, arg1)
+}
+
+// CountMerchants mocks base method.
+func (m *MockRepo) CountMerchants(arg0 context.Context, arg1 models.UserID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMerchants", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMerchants indicates an expected call of CountMerchants.
+func (mr *MockRepoMockRecorder) CountMerchants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMerchants", reflect.TypeOf((*MockRepo)(nil).CountMerchants), arg0, arg1)
+}
+
+// CountReconciliations mocks base method.
+func (m *MockRepo) CountReconciliations(arg0 context.Context, arg1 models.AccountID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReconciliations", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReconciliations indicates an expected call of CountReconciliations.
+func (mr *MockRepoMockRecorder) CountReconciliations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReconciliations", reflect.TypeOf((*MockRepo)(nil).CountReconciliations), arg0, arg1)
+}
+
+// CountSearchTransactions mocks base method.
+func (m *MockRepo) CountSearchTransactions(arg0 context.Context, arg1 database.SearchTransactionsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSearchTransactions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSearchTransactions indicates an expected call of CountSearchTransactions.
+func (mr *MockRepoMockRecorder) CountSearchTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSearchTransactions", reflect.TypeOf((*MockRepo)(nil).CountSearchTransactions), arg0, arg1)
+}
+
+// CountTransactionsByAccountID mocks base method.
+func (m *MockRepo) CountTransactionsByAccountID(arg0 context.Context, arg1 database.ListTxByAccountIDParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransactionsByAccountID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransactionsByAccountID indicates an expected call of CountTransactionsByAccountID.
+func (mr *MockRepoMockRecorder) CountTransactionsByAccountID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactionsByAccountID", reflect.TypeOf((*MockRepo)(nil).CountTransactionsByAccountID), arg0, arg1)
+}
+
+// CountTransactionsByCategoryID mocks base method.
+func (m *MockRepo) CountTransactionsByCategoryID(arg0 context.Context, arg1 database.ListTxByCategoryIDParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransactionsByCategoryID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransactionsByCategoryID indicates an expected call of CountTransactionsByCategoryID.
+func (mr *MockRepoMockRecorder) CountTransactionsByCategoryID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactionsByCategoryID", reflect.TypeOf((*MockRepo)(nil).CountTransactionsByCategoryID), arg0, arg1)
+}
+
+// CountTransactionsByMerchantID mocks base method.
+func (m *MockRepo) CountTransactionsByMerchantID(arg0 context.Context, arg1 models.MerchantID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransactionsByMerchantID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransactionsByMerchantID indicates an expected call of CountTransactionsByMerchantID.
+func (mr *MockRepoMockRecorder) CountTransactionsByMerchantID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactionsByMerchantID", reflect.TypeOf((*MockRepo)(nil).CountTransactionsByMerchantID), arg0, arg1)
+}
+
+// CountTransactionsByUserID mocks base method.
+func (m *MockRepo) CountTransactionsByUserID(arg0 context.Context, arg1 database.ListTxByUserIDParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransactionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransactionsByUserID indicates an expected call of CountTransactionsByUserID.
+func (mr *MockRepoMockRecorder) CountTransactionsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactionsByUserID", reflect.TypeOf((*MockRepo)(nil).CountTransactionsByUserID), arg0, arg1)
+}
+
+// CountTransfers mocks base method.
+func (m *MockRepo) CountTransfers(arg0 context.Context, arg1 database.ListTransfersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransfers", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransfers indicates an expected call of CountTransfers.
+func (mr *MockRepoMockRecorder) CountTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransfers", reflect.TypeOf((*MockRepo)(nil).CountTransfers), arg0, arg1)
+}
+
+// CountUsers mocks base method.
+func (m *MockRepo) CountUsers(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockRepoMockRecorder) CountUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockRepo)(nil).CountUsers), arg0)
+}
+
+// CreateAccount mocks base method.
+func (m *MockRepo) CreateAccount(arg0 context.Context, arg1 database.CreateAccountParams) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockRepoMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockRepo)(nil).CreateAccount), arg0, arg1)
+}
+
+// CreateBudget mocks base method.
+func (m *MockRepo) CreateBudget(arg0 context.Context, arg1 database.CreateBudgetParams) (models.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudget", arg0, arg1)
+	ret0, _ := ret[0].(models.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudget indicates an expected call of CreateBudget.
+func (mr *MockRepoMockRecorder) CreateBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockRepo)(nil).CreateBudget), arg0, arg1)
+}
+
+// CreateCategory mocks base method.
+func (m *MockRepo) CreateCategory(arg0 context.Context, arg1 database.CreateCategoryParams) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockRepoMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockRepo)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateImportMapping mocks base method.
+func (m *MockRepo) CreateImportMapping(arg0 context.Context, arg1 database.CreateImportMappingParams) (models.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportMapping", arg0, arg1)
+	ret0, _ := ret[0].(models.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImportMapping indicates an expected call of CreateImportMapping.
+func (mr *MockRepoMockRecorder) CreateImportMapping(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportMapping", reflect.TypeOf((*MockRepo)(nil).CreateImportMapping), arg0, arg1)
+}
+
+// CreateImportedTransaction mocks base method.
+func (m *MockRepo) CreateImportedTransaction(arg0 context.Context, arg1 database.CreateImportedTransactionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportedTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImportedTransaction indicates an expected call of CreateImportedTransaction.
+func (mr *MockRepoMockRecorder) CreateImportedTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportedTransaction", reflect.TypeOf((*MockRepo)(nil).CreateImportedTransaction), arg0, arg1)
+}
+
+// CreateMerchant mocks base method.
+func (m *MockRepo) CreateMerchant(arg0 context.Context, arg1 database.CreateMerchantParams) (models.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchant", arg0, arg1)
+	ret0, _ := ret[0].(models.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchant indicates an expected call of CreateMerchant.
+func (mr *MockRepoMockRecorder) CreateMerchant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockRepo)(nil).CreateMerchant), arg0, arg1)
+}
+
+// CreatePosting mocks base method.
+func (m *MockRepo) CreatePosting(arg0 context.Context, arg1 database.CreatePostingParams) (models.Posting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePosting", arg0, arg1)
+	ret0, _ := ret[0].(models.Posting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePosting indicates an expected call of CreatePosting.
+func (mr *MockRepoMockRecorder) CreatePosting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePosting", reflect.TypeOf((*MockRepo)(nil).CreatePosting), arg0, arg1)
+}
+
+// CreateReconciliation mocks base method.
+func (m *MockRepo) CreateReconciliation(arg0 context.Context, arg1 database.CreateReconciliationParams) (models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReconciliation", arg0, arg1)
+	ret0, _ := ret[0].(models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReconciliation indicates an expected call of CreateReconciliation.
+func (mr *MockRepoMockRecorder) CreateReconciliation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReconciliation", reflect.TypeOf((*MockRepo)(nil).CreateReconciliation), arg0, arg1)
+}
+
+// CreateRecurring mocks base method.
+func (m *MockRepo) CreateRecurring(arg0 context.Context, arg1 database.CreateRecurringParams) (models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurring", arg0, arg1)
+	ret0, _ := ret[0].(models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurring indicates an expected call of CreateRecurring.
+func (mr *MockRepoMockRecorder) CreateRecurring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurring", reflect.TypeOf((*MockRepo)(nil).CreateRecurring), arg0, arg1)
+}
+
+// CreateRecurringTx mocks base method.
+func (m *MockRepo) CreateRecurringTx(arg0 context.Context, arg1 database.CreateRecurringParams) (models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringTx", arg0, arg1)
+	ret0, _ := ret[0].(models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringTx indicates an expected call of CreateRecurringTx.
+func (mr *MockRepoMockRecorder) CreateRecurringTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringTx", reflect.TypeOf((*MockRepo)(nil).CreateRecurringTx), arg0, arg1)
+}
+
+// CreateRule mocks base method.
+func (m *MockRepo) CreateRule(arg0 context.Context, arg1 database.CreateRuleParams) (models.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", arg0, arg1)
+	ret0, _ := ret[0].(models.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockRepoMockRecorder) CreateRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockRepo)(nil).CreateRule), arg0, arg1)
+}
+
+// CreateSignupTemplate mocks base method.
+func (m *MockRepo) CreateSignupTemplate(arg0 context.Context, arg1 database.CreateSignupTemplateParams) (models.SignupTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSignupTemplate", arg0, arg1)
+	ret0, _ := ret[0].(models.SignupTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSignupTemplate indicates an expected call of CreateSignupTemplate.
+func (mr *MockRepoMockRecorder) CreateSignupTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSignupTemplate", reflect.TypeOf((*MockRepo)(nil).CreateSignupTemplate), arg0, arg1)
+}
+
+// CreateSplit mocks base method.
+func (m *MockRepo) CreateSplit(arg0 context.Context, arg1 database.CreateSplitParams) (models.TransactionSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSplit", arg0, arg1)
+	ret0, _ := ret[0].(models.TransactionSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSplit indicates an expected call of CreateSplit.
+func (mr *MockRepoMockRecorder) CreateSplit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSplit", reflect.TypeOf((*MockRepo)(nil).CreateSplit), arg0, arg1)
+}
+
+// CreateTransaction mocks base method.
+func (m *MockRepo) CreateTransaction(arg0 context.Context, arg1 database.CreateTransactionParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransaction", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransaction indicates an expected call of CreateTransaction.
+func (mr *MockRepoMockRecorder) CreateTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockRepo)(nil).CreateTransaction), arg0, arg1)
+}
+
+// CreateTransactionTx mocks base method.
+func (m *MockRepo) CreateTransactionTx(arg0 context.Context, arg1 database.CreateTransactionParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransactionTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransactionTx indicates an expected call of CreateTransactionTx.
+func (mr *MockRepoMockRecorder) CreateTransactionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransactionTx", reflect.TypeOf((*MockRepo)(nil).CreateTransactionTx), arg0, arg1)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockRepo) CreateTransfer(arg0 context.Context, arg1 database.CreateTransferParams) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockRepoMockRecorder) CreateTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockRepo)(nil).CreateTransfer), arg0, arg1)
+}
+
+// CreateTransferTx mocks base method.
+func (m *MockRepo) CreateTransferTx(arg0 context.Context, arg1 database.CreateTransferParams) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferTx indicates an expected call of CreateTransferTx.
+func (mr *MockRepoMockRecorder) CreateTransferTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferTx", reflect.TypeOf((*MockRepo)(nil).CreateTransferTx), arg0, arg1)
+}
+
 // CreateUser mocks base method.
-func (m *MockRepo) CreateUser(arg0 context.Context, arg1 database.CreateUserParams) (database.User, error) {
+func (m *MockRepo) CreateUser(arg0 context.Context, arg1 database.CreateUserParams) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockRepoMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepo)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateUserTx mocks base method.
+func (m *MockRepo) CreateUserTx(arg0 context.Context, arg1 database.SignupParams) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTx", arg0, arg1)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockRepoMockRecorder) CreateUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockRepo)(nil).CreateUserTx), arg0, arg1)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockRepo) DeleteAccount(arg0 context.Context, arg1 models.AccountID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockRepoMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockRepo)(nil).DeleteAccount), arg0, arg1)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockRepo) DeleteBudget(arg0 context.Context, arg1 database.DeleteBudgetParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockRepoMockRecorder) DeleteBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockRepo)(nil).DeleteBudget), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockRepo) DeleteCategory(arg0 context.Context, arg1 models.CategoryID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockRepoMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockRepo)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteCategoryTx mocks base method.
+func (m *MockRepo) DeleteCategoryTx(arg0 context.Context, arg1 database.DeleteCategoryParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategoryTx indicates an expected call of DeleteCategoryTx.
+func (mr *MockRepoMockRecorder) DeleteCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryTx", reflect.TypeOf((*MockRepo)(nil).DeleteCategoryTx), arg0, arg1)
+}
+
+// DeleteImportMapping mocks base method.
+func (m *MockRepo) DeleteImportMapping(arg0 context.Context, arg1 database.DeleteImportMappingParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImportMapping", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteImportMapping indicates an expected call of DeleteImportMapping.
+func (mr *MockRepoMockRecorder) DeleteImportMapping(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImportMapping", reflect.TypeOf((*MockRepo)(nil).DeleteImportMapping), arg0, arg1)
+}
+
+// DeleteMerchant mocks base method.
+func (m *MockRepo) DeleteMerchant(arg0 context.Context, arg1 models.MerchantID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMerchant", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMerchant indicates an expected call of DeleteMerchant.
+func (mr *MockRepoMockRecorder) DeleteMerchant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMerchant", reflect.TypeOf((*MockRepo)(nil).DeleteMerchant), arg0, arg1)
+}
+
+// DeleteMerchantTx mocks base method.
+func (m *MockRepo) DeleteMerchantTx(arg0 context.Context, arg1 database.DeleteMerchantParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMerchantTx", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMerchantTx indicates an expected call of DeleteMerchantTx.
+func (mr *MockRepoMockRecorder) DeleteMerchantTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMerchantTx", reflect.TypeOf((*MockRepo)(nil).DeleteMerchantTx), arg0, arg1)
+}
+
+// DeleteReconciliation mocks base method.
+func (m *MockRepo) DeleteReconciliation(arg0 context.Context, arg1 models.ReconciliationID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReconciliation", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReconciliation indicates an expected call of DeleteReconciliation.
+func (mr *MockRepoMockRecorder) DeleteReconciliation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReconciliation", reflect.TypeOf((*MockRepo)(nil).DeleteReconciliation), arg0, arg1)
+}
+
+// DeleteRecurring mocks base method.
+func (m *MockRepo) DeleteRecurring(arg0 context.Context, arg1 database.DeleteRecurringParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurring", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRecurring indicates an expected call of DeleteRecurring.
+func (mr *MockRepoMockRecorder) DeleteRecurring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurring", reflect.TypeOf((*MockRepo)(nil).DeleteRecurring), arg0, arg1)
+}
+
+// DeleteRule mocks base method.
+func (m *MockRepo) DeleteRule(arg0 context.Context, arg1 database.DeleteRuleParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRepoMockRecorder) DeleteRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRepo)(nil).DeleteRule), arg0, arg1)
+}
+
+// DeleteSignupTemplate mocks base method.
+func (m *MockRepo) DeleteSignupTemplate(arg0 context.Context, arg1 models.SignupTemplateID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSignupTemplate", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSignupTemplate indicates an expected call of DeleteSignupTemplate.
+func (mr *MockRepoMockRecorder) DeleteSignupTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSignupTemplate", reflect.TypeOf((*MockRepo)(nil).DeleteSignupTemplate), arg0, arg1)
+}
+
+// DeleteSplitsByTransactionID mocks base method.
+func (m *MockRepo) DeleteSplitsByTransactionID(arg0 context.Context, arg1 models.TransactionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSplitsByTransactionID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSplitsByTransactionID indicates an expected call of DeleteSplitsByTransactionID.
+func (mr *MockRepoMockRecorder) DeleteSplitsByTransactionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSplitsByTransactionID", reflect.TypeOf((*MockRepo)(nil).DeleteSplitsByTransactionID), arg0, arg1)
+}
+
+// DeleteTransaction mocks base method.
+func (m *MockRepo) DeleteTransaction(arg0 context.Context, arg1 models.TransactionID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransaction", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransaction indicates an expected call of DeleteTransaction.
+func (mr *MockRepoMockRecorder) DeleteTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransaction", reflect.TypeOf((*MockRepo)(nil).DeleteTransaction), arg0, arg1)
+}
+
+// DeleteTransactionTx mocks base method.
+func (m *MockRepo) DeleteTransactionTx(arg0 context.Context, arg1 database.DeleteTransactionParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransactionTx", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransactionTx indicates an expected call of DeleteTransactionTx.
+func (mr *MockRepoMockRecorder) DeleteTransactionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionTx", reflect.TypeOf((*MockRepo)(nil).DeleteTransactionTx), arg0, arg1)
+}
+
+// DeleteTransactionsByTransferID mocks base method.
+func (m *MockRepo) DeleteTransactionsByTransferID(arg0 context.Context, arg1 models.TransferID) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransactionsByTransferID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransactionsByTransferID indicates an expected call of DeleteTransactionsByTransferID.
+func (mr *MockRepoMockRecorder) DeleteTransactionsByTransferID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionsByTransferID", reflect.TypeOf((*MockRepo)(nil).DeleteTransactionsByTransferID), arg0, arg1)
+}
+
+// DeleteTransfer mocks base method.
+func (m *MockRepo) DeleteTransfer(arg0 context.Context, arg1 models.TransferID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransfer", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransfer indicates an expected call of DeleteTransfer.
+func (mr *MockRepoMockRecorder) DeleteTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockRepo)(nil).DeleteTransfer), arg0, arg1)
+}
+
+// DeleteTransferTx mocks base method.
+func (m *MockRepo) DeleteTransferTx(arg0 context.Context, arg1 database.DeleteTransferParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransferTx indicates an expected call of DeleteTransferTx.
+func (mr *MockRepoMockRecorder) DeleteTransferTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransferTx", reflect.TypeOf((*MockRepo)(nil).DeleteTransferTx), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockRepo) DeleteUser(arg0 context.Context, arg1 models.UserID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockRepoMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepo)(nil).DeleteUser), arg0, arg1)
+}
+
+// DismissDuplicate mocks base method.
+func (m *MockRepo) DismissDuplicate(arg0 context.Context, arg1 database.DismissDuplicateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissDuplicate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissDuplicate indicates an expected call of DismissDuplicate.
+func (mr *MockRepoMockRecorder) DismissDuplicate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissDuplicate", reflect.TypeOf((*MockRepo)(nil).DismissDuplicate), arg0, arg1)
+}
+
+// ExportAccounts mocks base method.
+func (m *MockRepo) ExportAccounts(arg0 context.Context, arg1 models.UserID) ([]models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccounts indicates an expected call of ExportAccounts.
+func (mr *MockRepoMockRecorder) ExportAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccounts", reflect.TypeOf((*MockRepo)(nil).ExportAccounts), arg0, arg1)
+}
+
+// ExportBudgets mocks base method.
+func (m *MockRepo) ExportBudgets(arg0 context.Context, arg1 models.UserID) ([]models.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBudgets", arg0, arg1)
+	ret0, _ := ret[0].([]models.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportBudgets indicates an expected call of ExportBudgets.
+func (mr *MockRepoMockRecorder) ExportBudgets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBudgets", reflect.TypeOf((*MockRepo)(nil).ExportBudgets), arg0, arg1)
+}
+
+// ExportCategories mocks base method.
+func (m *MockRepo) ExportCategories(arg0 context.Context, arg1 models.UserID) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCategories", arg0, arg1)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCategories indicates an expected call of ExportCategories.
+func (mr *MockRepoMockRecorder) ExportCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCategories", reflect.TypeOf((*MockRepo)(nil).ExportCategories), arg0, arg1)
+}
+
+// ExportMerchants mocks base method.
+func (m *MockRepo) ExportMerchants(arg0 context.Context, arg1 models.UserID) ([]models.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMerchants", arg0, arg1)
+	ret0, _ := ret[0].([]models.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportMerchants indicates an expected call of ExportMerchants.
+func (mr *MockRepoMockRecorder) ExportMerchants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMerchants", reflect.TypeOf((*MockRepo)(nil).ExportMerchants), arg0, arg1)
+}
+
+// ExportRecurring mocks base method.
+func (m *MockRepo) ExportRecurring(arg0 context.Context, arg1 models.UserID) ([]models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportRecurring", arg0, arg1)
+	ret0, _ := ret[0].([]models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportRecurring indicates an expected call of ExportRecurring.
+func (mr *MockRepoMockRecorder) ExportRecurring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRecurring", reflect.TypeOf((*MockRepo)(nil).ExportRecurring), arg0, arg1)
+}
+
+// ExportTransactions mocks base method.
+func (m *MockRepo) ExportTransactions(arg0 context.Context, arg1 database.ExportTransactionsParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTransactions indicates an expected call of ExportTransactions.
+func (mr *MockRepoMockRecorder) ExportTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransactions", reflect.TypeOf((*MockRepo)(nil).ExportTransactions), arg0, arg1)
+}
+
+// ExportTransfers mocks base method.
+func (m *MockRepo) ExportTransfers(arg0 context.Context, arg1 models.UserID) ([]models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTransfers indicates an expected call of ExportTransfers.
+func (mr *MockRepoMockRecorder) ExportTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransfers", reflect.TypeOf((*MockRepo)(nil).ExportTransfers), arg0, arg1)
+}
+
+// FillTransactionDetails mocks base method.
+func (m *MockRepo) FillTransactionDetails(arg0 context.Context, arg1 database.FillTransactionDetailsParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FillTransactionDetails", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FillTransactionDetails indicates an expected call of FillTransactionDetails.
+func (mr *MockRepoMockRecorder) FillTransactionDetails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FillTransactionDetails", reflect.TypeOf((*MockRepo)(nil).FillTransactionDetails), arg0, arg1)
+}
+
+// GetAccountAmountAfter mocks base method.
+func (m *MockRepo) GetAccountAmountAfter(arg0 context.Context, arg1 database.GetAccountAmountAfterParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountAmountAfter", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountAmountAfter indicates an expected call of GetAccountAmountAfter.
+func (mr *MockRepoMockRecorder) GetAccountAmountAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountAmountAfter", reflect.TypeOf((*MockRepo)(nil).GetAccountAmountAfter), arg0, arg1)
+}
+
+// GetAccountByID mocks base method.
+func (m *MockRepo) GetAccountByID(arg0 context.Context, arg1 models.AccountID) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByID", arg0, arg1)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByID indicates an expected call of GetAccountByID.
+func (mr *MockRepoMockRecorder) GetAccountByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockRepo)(nil).GetAccountByID), arg0, arg1)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockRepo) GetAccountForUpdate(arg0 context.Context, arg1 models.AccountID) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockRepoMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockRepo)(nil).GetAccountForUpdate), arg0, arg1)
+}
+
+// GetCategoryByID mocks base method.
+func (m *MockRepo) GetCategoryByID(arg0 context.Context, arg1 models.CategoryID) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByID", arg0, arg1)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByID indicates an expected call of GetCategoryByID.
+func (mr *MockRepoMockRecorder) GetCategoryByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockRepo)(nil).GetCategoryByID), arg0, arg1)
+}
+
+// GetImportMappingByID mocks base method.
+func (m *MockRepo) GetImportMappingByID(arg0 context.Context, arg1 models.ImportMappingID) (models.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportMappingByID", arg0, arg1)
+	ret0, _ := ret[0].(models.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportMappingByID indicates an expected call of GetImportMappingByID.
+func (mr *MockRepoMockRecorder) GetImportMappingByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportMappingByID", reflect.TypeOf((*MockRepo)(nil).GetImportMappingByID), arg0, arg1)
+}
+
+// GetMerchantByID mocks base method.
+func (m *MockRepo) GetMerchantByID(arg0 context.Context, arg1 models.MerchantID) (models.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantByID", arg0, arg1)
+	ret0, _ := ret[0].(models.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantByID indicates an expected call of GetMerchantByID.
+func (mr *MockRepoMockRecorder) GetMerchantByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantByID", reflect.TypeOf((*MockRepo)(nil).GetMerchantByID), arg0, arg1)
+}
+
+// GetOrCreateMerchant mocks base method.
+func (m *MockRepo) GetOrCreateMerchant(arg0 context.Context, arg1 database.CreateMerchantParams) (models.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateMerchant", arg0, arg1)
+	ret0, _ := ret[0].(models.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateMerchant indicates an expected call of GetOrCreateMerchant.
+func (mr *MockRepoMockRecorder) GetOrCreateMerchant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateMerchant", reflect.TypeOf((*MockRepo)(nil).GetOrCreateMerchant), arg0, arg1)
+}
+
+// GetPendingAmount mocks base method.
+func (m *MockRepo) GetPendingAmount(arg0 context.Context, arg1 models.AccountID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingAmount", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingAmount indicates an expected call of GetPendingAmount.
+func (mr *MockRepoMockRecorder) GetPendingAmount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingAmount", reflect.TypeOf((*MockRepo)(nil).GetPendingAmount), arg0, arg1)
+}
+
+// GetReconciliationByID mocks base method.
+func (m *MockRepo) GetReconciliationByID(arg0 context.Context, arg1 models.ReconciliationID) (models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationByID", arg0, arg1)
+	ret0, _ := ret[0].(models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationByID indicates an expected call of GetReconciliationByID.
+func (mr *MockRepoMockRecorder) GetReconciliationByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationByID", reflect.TypeOf((*MockRepo)(nil).GetReconciliationByID), arg0, arg1)
+}
+
+// GetReconciliationForUpdate mocks base method.
+func (m *MockRepo) GetReconciliationForUpdate(arg0 context.Context, arg1 models.ReconciliationID) (models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationForUpdate indicates an expected call of GetReconciliationForUpdate.
+func (mr *MockRepoMockRecorder) GetReconciliationForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationForUpdate", reflect.TypeOf((*MockRepo)(nil).GetReconciliationForUpdate), arg0, arg1)
+}
+
+// GetRecurringByID mocks base method.
+func (m *MockRepo) GetRecurringByID(arg0 context.Context, arg1 models.RecurringID) (models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringByID", arg0, arg1)
+	ret0, _ := ret[0].(models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringByID indicates an expected call of GetRecurringByID.
+func (mr *MockRepoMockRecorder) GetRecurringByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringByID", reflect.TypeOf((*MockRepo)(nil).GetRecurringByID), arg0, arg1)
+}
+
+// GetRecurringForUpdate mocks base method.
+func (m *MockRepo) GetRecurringForUpdate(arg0 context.Context, arg1 models.RecurringID) (models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringForUpdate indicates an expected call of GetRecurringForUpdate.
+func (mr *MockRepoMockRecorder) GetRecurringForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringForUpdate", reflect.TypeOf((*MockRepo)(nil).GetRecurringForUpdate), arg0, arg1)
+}
+
+// GetRuleByID mocks base method.
+func (m *MockRepo) GetRuleByID(arg0 context.Context, arg1 models.RuleID) (models.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleByID", arg0, arg1)
+	ret0, _ := ret[0].(models.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleByID indicates an expected call of GetRuleByID.
+func (mr *MockRepoMockRecorder) GetRuleByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleByID", reflect.TypeOf((*MockRepo)(nil).GetRuleByID), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockRepo) GetSession(arg0 context.Context, arg1 database.GetSessionsParams) (models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockRepoMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockRepo)(nil).GetSession), arg0, arg1)
+}
+
+// GetSignupTemplateByID mocks base method.
+func (m *MockRepo) GetSignupTemplateByID(arg0 context.Context, arg1 models.SignupTemplateID) (models.SignupTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignupTemplateByID", arg0, arg1)
+	ret0, _ := ret[0].(models.SignupTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignupTemplateByID indicates an expected call of GetSignupTemplateByID.
+func (mr *MockRepoMockRecorder) GetSignupTemplateByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignupTemplateByID", reflect.TypeOf((*MockRepo)(nil).GetSignupTemplateByID), arg0, arg1)
+}
+
+// GetSignupTemplateForLocale mocks base method.
+func (m *MockRepo) GetSignupTemplateForLocale(arg0 context.Context, arg1 []string) (models.SignupTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignupTemplateForLocale", arg0, arg1)
+	ret0, _ := ret[0].(models.SignupTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignupTemplateForLocale indicates an expected call of GetSignupTemplateForLocale.
+func (mr *MockRepoMockRecorder) GetSignupTemplateForLocale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignupTemplateForLocale", reflect.TypeOf((*MockRepo)(nil).GetSignupTemplateForLocale), arg0, arg1)
+}
+
+// GetTransactionByID mocks base method.
+func (m *MockRepo) GetTransactionByID(arg0 context.Context, arg1 models.TransactionID) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByID", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByID indicates an expected call of GetTransactionByID.
+func (mr *MockRepoMockRecorder) GetTransactionByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByID", reflect.TypeOf((*MockRepo)(nil).GetTransactionByID), arg0, arg1)
+}
+
+// GetTransactionForUpdate mocks base method.
+func (m *MockRepo) GetTransactionForUpdate(arg0 context.Context, arg1 models.TransactionID) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionForUpdate indicates an expected call of GetTransactionForUpdate.
+func (mr *MockRepoMockRecorder) GetTransactionForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionForUpdate", reflect.TypeOf((*MockRepo)(nil).GetTransactionForUpdate), arg0, arg1)
+}
+
+// GetTransferByID mocks base method.
+func (m *MockRepo) GetTransferByID(arg0 context.Context, arg1 models.TransferID) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferByID", arg0, arg1)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferByID indicates an expected call of GetTransferByID.
+func (mr *MockRepoMockRecorder) GetTransferByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferByID", reflect.TypeOf((*MockRepo)(nil).GetTransferByID), arg0, arg1)
+}
+
+// GetTransferForUpdate mocks base method.
+func (m *MockRepo) GetTransferForUpdate(arg0 context.Context, arg1 models.TransferID) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferForUpdate indicates an expected call of GetTransferForUpdate.
+func (mr *MockRepoMockRecorder) GetTransferForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferForUpdate", reflect.TypeOf((*MockRepo)(nil).GetTransferForUpdate), arg0, arg1)
+}
+
+// GetUnreconciledAmount mocks base method.
+func (m *MockRepo) GetUnreconciledAmount(arg0 context.Context, arg1 models.AccountID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreconciledAmount", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreconciledAmount indicates an expected call of GetUnreconciledAmount.
+func (mr *MockRepoMockRecorder) GetUnreconciledAmount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreconciledAmount", reflect.TypeOf((*MockRepo)(nil).GetUnreconciledAmount), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockRepo) GetUserByEmail(arg0 context.Context, arg1 string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockRepoMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockRepo)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetUserByID mocks base method.
+func (m *MockRepo) GetUserByID(arg0 context.Context, arg1 models.UserID) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockRepoMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockRepo)(nil).GetUserByID), arg0, arg1)
+}
+
+// GetUserRoleByID mocks base method.
+func (m *MockRepo) GetUserRoleByID(arg0 context.Context, arg1 models.UserID) (models.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoleByID", arg0, arg1)
+	ret0, _ := ret[0].(models.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoleByID indicates an expected call of GetUserRoleByID.
+func (mr *MockRepoMockRecorder) GetUserRoleByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoleByID", reflect.TypeOf((*MockRepo)(nil).GetUserRoleByID), arg0, arg1)
+}
+
+// GrantRole mocks base method.
+func (m *MockRepo) GrantRole(arg0 context.Context, arg1 database.RoleParams) (models.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", arg0, arg1)
+	ret0, _ := ret[0].(models.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockRepoMockRecorder) GrantRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockRepo)(nil).GrantRole), arg0, arg1)
+}
+
+// ImportExchangeRatesTx mocks base method.
+func (m *MockRepo) ImportExchangeRatesTx(arg0 context.Context, arg1 []models.ExchangeRate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportExchangeRatesTx", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportExchangeRatesTx indicates an expected call of ImportExchangeRatesTx.
+func (mr *MockRepoMockRecorder) ImportExchangeRatesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportExchangeRatesTx", reflect.TypeOf((*MockRepo)(nil).ImportExchangeRatesTx), arg0, arg1)
+}
+
+// ImportTransactionsTx mocks base method.
+func (m *MockRepo) ImportTransactionsTx(arg0 context.Context, arg1 database.ImportTransactionsParams) (models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTransactionsTx", arg0, arg1)
+	ret0, _ := ret[0].(models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTransactionsTx indicates an expected call of ImportTransactionsTx.
+func (mr *MockRepoMockRecorder) ImportTransactionsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTransactionsTx", reflect.TypeOf((*MockRepo)(nil).ImportTransactionsTx), arg0, arg1)
+}
+
+// ListAccountImbalances mocks base method.
+func (m *MockRepo) ListAccountImbalances(arg0 context.Context, arg1 models.UserID) ([]models.AccountImbalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountImbalances", arg0, arg1)
+	ret0, _ := ret[0].([]models.AccountImbalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountImbalances indicates an expected call of ListAccountImbalances.
+func (mr *MockRepoMockRecorder) ListAccountImbalances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountImbalances", reflect.TypeOf((*MockRepo)(nil).ListAccountImbalances), arg0, arg1)
+}
+
+// ListAccounts mocks base method.
+func (m *MockRepo) ListAccounts(arg0 context.Context, arg1 database.ListAccountParams) ([]models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockRepoMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockRepo)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListAllCategories mocks base method.
+func (m *MockRepo) ListAllCategories(arg0 context.Context, arg1 models.UserID) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllCategories", arg0, arg1)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllCategories indicates an expected call of ListAllCategories.
+func (mr *MockRepoMockRecorder) ListAllCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllCategories", reflect.TypeOf((*MockRepo)(nil).ListAllCategories), arg0, arg1)
+}
+
+// ListBudgetHistory mocks base method.
+func (m *MockRepo) ListBudgetHistory(arg0 context.Context, arg1 database.ListBudgetHistoryParams) ([]models.BudgetProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBudgetHistory", arg0, arg1)
+	ret0, _ := ret[0].([]models.BudgetProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBudgetHistory indicates an expected call of ListBudgetHistory.
+func (mr *MockRepoMockRecorder) ListBudgetHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBudgetHistory", reflect.TypeOf((*MockRepo)(nil).ListBudgetHistory), arg0, arg1)
+}
+
+// ListCategories mocks base method.
+func (m *MockRepo) ListCategories(arg0 context.Context, arg1 database.ListCategoryParams) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockRepoMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockRepo)(nil).ListCategories), arg0, arg1)
+}
+
+// ListCategorizedTransactions mocks base method.
+func (m *MockRepo) ListCategorizedTransactions(arg0 context.Context, arg1 database.ListCategorizedTransactionsParams) ([]models.CategorizedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategorizedTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.CategorizedTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategorizedTransactions indicates an expected call of ListCategorizedTransactions.
+func (mr *MockRepoMockRecorder) ListCategorizedTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategorizedTransactions", reflect.TypeOf((*MockRepo)(nil).ListCategorizedTransactions), arg0, arg1)
+}
+
+// ListConvertedBalances mocks base method.
+func (m *MockRepo) ListConvertedBalances(arg0 context.Context, arg1 database.ListConvertedBalancesParams) ([]models.ConvertedBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConvertedBalances", arg0, arg1)
+	ret0, _ := ret[0].([]models.ConvertedBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConvertedBalances indicates an expected call of ListConvertedBalances.
+func (mr *MockRepoMockRecorder) ListConvertedBalances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConvertedBalances", reflect.TypeOf((*MockRepo)(nil).ListConvertedBalances), arg0, arg1)
+}
+
+// ListDueRecurring mocks base method.
+func (m *MockRepo) ListDueRecurring(arg0 context.Context, arg1 database.ListDueRecurringParams) ([]models.RecurringID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueRecurring", arg0, arg1)
+	ret0, _ := ret[0].([]models.RecurringID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueRecurring indicates an expected call of ListDueRecurring.
+func (mr *MockRepoMockRecorder) ListDueRecurring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueRecurring", reflect.TypeOf((*MockRepo)(nil).ListDueRecurring), arg0, arg1)
+}
+
+// ListDuplicateCandidates mocks base method.
+func (m *MockRepo) ListDuplicateCandidates(arg0 context.Context, arg1 database.ListDuplicateCandidatesParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDuplicateCandidates", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDuplicateCandidates indicates an expected call of ListDuplicateCandidates.
+func (mr *MockRepoMockRecorder) ListDuplicateCandidates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuplicateCandidates", reflect.TypeOf((*MockRepo)(nil).ListDuplicateCandidates), arg0, arg1)
+}
+
+// ListDuplicatePairs mocks base method.
+func (m *MockRepo) ListDuplicatePairs(arg0 context.Context, arg1 database.ListDuplicatePairsParams) ([]models.DuplicatePair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDuplicatePairs", arg0, arg1)
+	ret0, _ := ret[0].([]models.DuplicatePair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDuplicatePairs indicates an expected call of ListDuplicatePairs.
+func (mr *MockRepoMockRecorder) ListDuplicatePairs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuplicatePairs", reflect.TypeOf((*MockRepo)(nil).ListDuplicatePairs), arg0, arg1)
+}
+
+// ListImportMappings mocks base method.
+func (m *MockRepo) ListImportMappings(arg0 context.Context, arg1 models.UserID) ([]models.ImportMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImportMappings", arg0, arg1)
+	ret0, _ := ret[0].([]models.ImportMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImportMappings indicates an expected call of ListImportMappings.
+func (mr *MockRepoMockRecorder) ListImportMappings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImportMappings", reflect.TypeOf((*MockRepo)(nil).ListImportMappings), arg0, arg1)
+}
+
+// ListImportedExternalIDs mocks base method.
+func (m *MockRepo) ListImportedExternalIDs(arg0 context.Context, arg1 database.ListImportedExternalIDsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImportedExternalIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImportedExternalIDs indicates an expected call of ListImportedExternalIDs.
+func (mr *MockRepoMockRecorder) ListImportedExternalIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImportedExternalIDs", reflect.TypeOf((*MockRepo)(nil).ListImportedExternalIDs), arg0, arg1)
+}
+
+// ListMerchantSpend mocks base method.
+func (m *MockRepo) ListMerchantSpend(arg0 context.Context, arg1 database.ListMerchantSpendParams) ([]models.MerchantSpend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantSpend", arg0, arg1)
+	ret0, _ := ret[0].([]models.MerchantSpend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantSpend indicates an expected call of ListMerchantSpend.
+func (mr *MockRepoMockRecorder) ListMerchantSpend(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantSpend", reflect.TypeOf((*MockRepo)(nil).ListMerchantSpend), arg0, arg1)
+}
+
+// ListMerchants mocks base method.
+func (m *MockRepo) ListMerchants(arg0 context.Context, arg1 database.ListMerchantParams) ([]models.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchants", arg0, arg1)
+	ret0, _ := ret[0].([]models.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchants indicates an expected call of ListMerchants.
+func (mr *MockRepoMockRecorder) ListMerchants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchants", reflect.TypeOf((*MockRepo)(nil).ListMerchants), arg0, arg1)
+}
+
+// ListPostingsByTransactionID mocks base method.
+func (m *MockRepo) ListPostingsByTransactionID(arg0 context.Context, arg1 models.TransactionID) ([]models.Posting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostingsByTransactionID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Posting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostingsByTransactionID indicates an expected call of ListPostingsByTransactionID.
+func (mr *MockRepoMockRecorder) ListPostingsByTransactionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostingsByTransactionID", reflect.TypeOf((*MockRepo)(nil).ListPostingsByTransactionID), arg0, arg1)
+}
+
+// ListReconciliationTransactions mocks base method.
+func (m *MockRepo) ListReconciliationTransactions(arg0 context.Context, arg1 models.ReconciliationID) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReconciliationTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReconciliationTransactions indicates an expected call of ListReconciliationTransactions.
+func (mr *MockRepoMockRecorder) ListReconciliationTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReconciliationTransactions", reflect.TypeOf((*MockRepo)(nil).ListReconciliationTransactions), arg0, arg1)
+}
+
+// ListReconciliations mocks base method.
+func (m *MockRepo) ListReconciliations(arg0 context.Context, arg1 database.ListReconciliationsParams) ([]models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReconciliations", arg0, arg1)
+	ret0, _ := ret[0].([]models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReconciliations indicates an expected call of ListReconciliations.
+func (mr *MockRepoMockRecorder) ListReconciliations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReconciliations", reflect.TypeOf((*MockRepo)(nil).ListReconciliations), arg0, arg1)
+}
+
+// ListRecurring mocks base method.
+func (m *MockRepo) ListRecurring(arg0 context.Context, arg1 database.ListRecurringParams) ([]models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurring", arg0, arg1)
+	ret0, _ := ret[0].([]models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurring indicates an expected call of ListRecurring.
+func (mr *MockRepoMockRecorder) ListRecurring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurring", reflect.TypeOf((*MockRepo)(nil).ListRecurring), arg0, arg1)
+}
+
+// ListRuleTransactions mocks base method.
+func (m *MockRepo) ListRuleTransactions(arg0 context.Context, arg1 database.ListRuleTransactionsParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleTransactions indicates an expected call of ListRuleTransactions.
+func (mr *MockRepoMockRecorder) ListRuleTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleTransactions", reflect.TypeOf((*MockRepo)(nil).ListRuleTransactions), arg0, arg1)
+}
+
+// ListRules mocks base method.
+func (m *MockRepo) ListRules(arg0 context.Context, arg1 models.UserID) ([]models.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", arg0, arg1)
+	ret0, _ := ret[0].([]models.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockRepoMockRecorder) ListRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockRepo)(nil).ListRules), arg0, arg1)
+}
+
+// ListSignupTemplates mocks base method.
+func (m *MockRepo) ListSignupTemplates(arg0 context.Context) ([]models.SignupTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSignupTemplates", arg0)
+	ret0, _ := ret[0].([]models.SignupTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSignupTemplates indicates an expected call of ListSignupTemplates.
+func (mr *MockRepoMockRecorder) ListSignupTemplates(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSignupTemplates", reflect.TypeOf((*MockRepo)(nil).ListSignupTemplates), arg0)
+}
+
+// ListSplitsByTransactionID mocks base method.
+func (m *MockRepo) ListSplitsByTransactionID(arg0 context.Context, arg1 models.TransactionID) ([]models.TransactionSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSplitsByTransactionID", arg0, arg1)
+	ret0, _ := ret[0].([]models.TransactionSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSplitsByTransactionID indicates an expected call of ListSplitsByTransactionID.
+func (mr *MockRepoMockRecorder) ListSplitsByTransactionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSplitsByTransactionID", reflect.TypeOf((*MockRepo)(nil).ListSplitsByTransactionID), arg0, arg1)
+}
+
+// ListSplitsByTransactionIDs mocks base method.
+func (m *MockRepo) ListSplitsByTransactionIDs(arg0 context.Context, arg1 []models.TransactionID) ([]models.TransactionSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSplitsByTransactionIDs", arg0, arg1)
+	ret0, _ := ret[0].([]models.TransactionSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSplitsByTransactionIDs indicates an expected call of ListSplitsByTransactionIDs.
+func (mr *MockRepoMockRecorder) ListSplitsByTransactionIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSplitsByTransactionIDs", reflect.TypeOf((*MockRepo)(nil).ListSplitsByTransactionIDs), arg0, arg1)
+}
+
+// ListTransactionsByAccountID mocks base method.
+func (m *MockRepo) ListTransactionsByAccountID(arg0 context.Context, arg1 database.ListTxByAccountIDParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsByAccountID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsByAccountID indicates an expected call of ListTransactionsByAccountID.
+func (mr *MockRepoMockRecorder) ListTransactionsByAccountID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsByAccountID", reflect.TypeOf((*MockRepo)(nil).ListTransactionsByAccountID), arg0, arg1)
+}
+
+// ListTransactionsByCategoryID mocks base method.
+func (m *MockRepo) ListTransactionsByCategoryID(arg0 context.Context, arg1 database.ListTxByCategoryIDParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsByCategoryID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsByCategoryID indicates an expected call of ListTransactionsByCategoryID.
+func (mr *MockRepoMockRecorder) ListTransactionsByCategoryID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsByCategoryID", reflect.TypeOf((*MockRepo)(nil).ListTransactionsByCategoryID), arg0, arg1)
+}
+
+// ListTransactionsByMerchantID mocks base method.
+func (m *MockRepo) ListTransactionsByMerchantID(arg0 context.Context, arg1 database.ListTxByMerchantIDParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsByMerchantID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsByMerchantID indicates an expected call of ListTransactionsByMerchantID.
+func (mr *MockRepoMockRecorder) ListTransactionsByMerchantID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsByMerchantID", reflect.TypeOf((*MockRepo)(nil).ListTransactionsByMerchantID), arg0, arg1)
+}
+
+// ListTransactionsByTransferID mocks base method.
+func (m *MockRepo) ListTransactionsByTransferID(arg0 context.Context, arg1 models.TransferID) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsByTransferID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsByTransferID indicates an expected call of ListTransactionsByTransferID.
+func (mr *MockRepoMockRecorder) ListTransactionsByTransferID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsByTransferID", reflect.TypeOf((*MockRepo)(nil).ListTransactionsByTransferID), arg0, arg1)
+}
+
+// ListTransactionsByUserID mocks base method.
+func (m *MockRepo) ListTransactionsByUserID(arg0 context.Context, arg1 database.ListTxByUserIDParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactionsByUserID indicates an expected call of ListTransactionsByUserID.
+func (mr *MockRepoMockRecorder) ListTransactionsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactionsByUserID", reflect.TypeOf((*MockRepo)(nil).ListTransactionsByUserID), arg0, arg1)
+}
+
+// ListTransfers mocks base method.
+func (m *MockRepo) ListTransfers(arg0 context.Context, arg1 database.ListTransfersParams) ([]models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockRepoMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockRepo)(nil).ListTransfers), arg0, arg1)
+}
+
+// ListUnreconciledTransactions mocks base method.
+func (m *MockRepo) ListUnreconciledTransactions(arg0 context.Context, arg1 database.ListUnreconciledTransactionsParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnreconciledTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnreconciledTransactions indicates an expected call of ListUnreconciledTransactions.
+func (mr *MockRepoMockRecorder) ListUnreconciledTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnreconciledTransactions", reflect.TypeOf((*MockRepo)(nil).ListUnreconciledTransactions), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockRepo) ListUsers(arg0 context.Context, arg1 database.ListUserParams) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockRepoMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRepo)(nil).ListUsers), arg0, arg1)
+}
+
+// ListUsersByRole mocks base method.
+func (m *MockRepo) ListUsersByRole(arg0 context.Context, arg1 database.ListUserRoleParams) ([]models.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersByRole", arg0, arg1)
+	ret0, _ := ret[0].([]models.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersByRole indicates an expected call of ListUsersByRole.
+func (mr *MockRepoMockRecorder) ListUsersByRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByRole", reflect.TypeOf((*MockRepo)(nil).ListUsersByRole), arg0, arg1)
+}
+
+// LockUserCategories mocks base method.
+func (m *MockRepo) LockUserCategories(arg0 context.Context, arg1 models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUserCategories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUserCategories indicates an expected call of LockUserCategories.
+func (mr *MockRepoMockRecorder) LockUserCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUserCategories", reflect.TypeOf((*MockRepo)(nil).LockUserCategories), arg0, arg1)
+}
+
+// MergeCategoriesTx mocks base method.
+func (m *MockRepo) MergeCategoriesTx(arg0 context.Context, arg1 database.MergeCategoriesParams) (models.CategoryMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCategoriesTx", arg0, arg1)
+	ret0, _ := ret[0].(models.CategoryMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeCategoriesTx indicates an expected call of MergeCategoriesTx.
+func (mr *MockRepoMockRecorder) MergeCategoriesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCategoriesTx", reflect.TypeOf((*MockRepo)(nil).MergeCategoriesTx), arg0, arg1)
+}
+
+// MergeCategoryBudgets mocks base method.
+func (m *MockRepo) MergeCategoryBudgets(arg0 context.Context, arg1 database.ReassignCategoryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCategoryBudgets", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeCategoryBudgets indicates an expected call of MergeCategoryBudgets.
+func (mr *MockRepoMockRecorder) MergeCategoryBudgets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCategoryBudgets", reflect.TypeOf((*MockRepo)(nil).MergeCategoryBudgets), arg0, arg1)
+}
+
+// MergeDuplicateTx mocks base method.
+func (m *MockRepo) MergeDuplicateTx(arg0 context.Context, arg1 database.MergeDuplicateParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeDuplicateTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeDuplicateTx indicates an expected call of MergeDuplicateTx.
+func (mr *MockRepoMockRecorder) MergeDuplicateTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeDuplicateTx", reflect.TypeOf((*MockRepo)(nil).MergeDuplicateTx), arg0, arg1)
+}
+
+// MoveCategory mocks base method.
+func (m *MockRepo) MoveCategory(arg0 context.Context, arg1 database.MoveCategoryParams) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCategory", arg0, arg1)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCategory indicates an expected call of MoveCategory.
+func (mr *MockRepoMockRecorder) MoveCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCategory", reflect.TypeOf((*MockRepo)(nil).MoveCategory), arg0, arg1)
+}
+
+// MoveCategoryTx mocks base method.
+func (m *MockRepo) MoveCategoryTx(arg0 context.Context, arg1 database.MoveCategoryParams) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCategoryTx indicates an expected call of MoveCategoryTx.
+func (mr *MockRepoMockRecorder) MoveCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCategoryTx", reflect.TypeOf((*MockRepo)(nil).MoveCategoryTx), arg0, arg1)
+}
+
+// MoveImportedTransactions mocks base method.
+func (m *MockRepo) MoveImportedTransactions(arg0 context.Context, arg1, arg2 models.TransactionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveImportedTransactions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveImportedTransactions indicates an expected call of MoveImportedTransactions.
+func (mr *MockRepoMockRecorder) MoveImportedTransactions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveImportedTransactions", reflect.TypeOf((*MockRepo)(nil).MoveImportedTransactions), arg0, arg1, arg2)
+}
+
+// PostRecurringTx mocks base method.
+func (m *MockRepo) PostRecurringTx(arg0 context.Context, arg1 database.PostRecurringParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostRecurringTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostRecurringTx indicates an expected call of PostRecurringTx.
+func (mr *MockRepoMockRecorder) PostRecurringTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostRecurringTx", reflect.TypeOf((*MockRepo)(nil).PostRecurringTx), arg0, arg1)
+}
+
+// ReassignCategory mocks base method.
+func (m *MockRepo) ReassignCategory(arg0 context.Context, arg1 database.ReassignCategoryParams) (models.CategoryMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignCategory", arg0, arg1)
+	ret0, _ := ret[0].(models.CategoryMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignCategory indicates an expected call of ReassignCategory.
+func (mr *MockRepoMockRecorder) ReassignCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignCategory", reflect.TypeOf((*MockRepo)(nil).ReassignCategory), arg0, arg1)
+}
+
+// ReassignMerchant mocks base method.
+func (m *MockRepo) ReassignMerchant(arg0 context.Context, arg1 database.ReassignMerchantParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignMerchant", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignMerchant indicates an expected call of ReassignMerchant.
+func (mr *MockRepoMockRecorder) ReassignMerchant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignMerchant", reflect.TypeOf((*MockRepo)(nil).ReassignMerchant), arg0, arg1)
+}
+
+// ReassignRuleCategory mocks base method.
+func (m *MockRepo) ReassignRuleCategory(arg0 context.Context, arg1 database.ReassignCategoryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignRuleCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignRuleCategory indicates an expected call of ReassignRuleCategory.
+func (mr *MockRepoMockRecorder) ReassignRuleCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignRuleCategory", reflect.TypeOf((*MockRepo)(nil).ReassignRuleCategory), arg0, arg1)
+}
+
+// ReassignRuleMerchant mocks base method.
+func (m *MockRepo) ReassignRuleMerchant(arg0 context.Context, arg1 database.ReassignMerchantParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignRuleMerchant", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignRuleMerchant indicates an expected call of ReassignRuleMerchant.
+func (mr *MockRepoMockRecorder) ReassignRuleMerchant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignRuleMerchant", reflect.TypeOf((*MockRepo)(nil).ReassignRuleMerchant), arg0, arg1)
+}
+
+// ReorderRulesTx mocks base method.
+func (m *MockRepo) ReorderRulesTx(arg0 context.Context, arg1 database.ReorderRulesParams) ([]models.Rule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(database.User)
+	ret := m.ctrl.Call(m, "ReorderRulesTx", arg0, arg1)
+	ret0, _ := ret[0].([]models.Rule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockRepoMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// ReorderRulesTx indicates an expected call of ReorderRulesTx.
+func (mr *MockRepoMockRecorder) ReorderRulesTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepo)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderRulesTx", reflect.TypeOf((*MockRepo)(nil).ReorderRulesTx), arg0, arg1)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockRepo) GetUserByEmail(arg0 context.Context, arg1 string) (database.User, error) {
+// ReparentCategories mocks base method.
+func (m *MockRepo) ReparentCategories(arg0 context.Context, arg1 database.ReparentCategoriesParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
-	ret0, _ := ret[0].(database.User)
+	ret := m.ctrl.Call(m, "ReparentCategories", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockRepoMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+// ReparentCategories indicates an expected call of ReparentCategories.
+func (mr *MockRepoMockRecorder) ReparentCategories(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockRepo)(nil).GetUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReparentCategories", reflect.TypeOf((*MockRepo)(nil).ReparentCategories), arg0, arg1)
 }
 
-// GetUserByID mocks base method.
-func (m *MockRepo) GetUserByID(arg0 context.Context, arg1 string) (database.User, error) {
+// RestoreAccount mocks base method.
+func (m *MockRepo) RestoreAccount(arg0 context.Context, arg1 database.CreateAccountParams) (database.RestoreAccountRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
-	ret0, _ := ret[0].(database.User)
+	ret := m.ctrl.Call(m, "RestoreAccount", arg0, arg1)
+	ret0, _ := ret[0].(database.RestoreAccountRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockRepoMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+// RestoreAccount indicates an expected call of RestoreAccount.
+func (mr *MockRepoMockRecorder) RestoreAccount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockRepo)(nil).GetUserByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAccount", reflect.TypeOf((*MockRepo)(nil).RestoreAccount), arg0, arg1)
+}
+
+// RestoreBackupTx mocks base method.
+func (m *MockRepo) RestoreBackupTx(arg0 context.Context, arg1 database.RestoreBackupParams) (models.RestoreResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBackupTx", arg0, arg1)
+	ret0, _ := ret[0].(models.RestoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBackupTx indicates an expected call of RestoreBackupTx.
+func (mr *MockRepoMockRecorder) RestoreBackupTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBackupTx", reflect.TypeOf((*MockRepo)(nil).RestoreBackupTx), arg0, arg1)
+}
+
+// RestoreBudget mocks base method.
+func (m *MockRepo) RestoreBudget(arg0 context.Context, arg1 database.CreateBudgetParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBudget", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBudget indicates an expected call of RestoreBudget.
+func (mr *MockRepoMockRecorder) RestoreBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBudget", reflect.TypeOf((*MockRepo)(nil).RestoreBudget), arg0, arg1)
+}
+
+// RestoreCategory mocks base method.
+func (m *MockRepo) RestoreCategory(arg0 context.Context, arg1 database.CreateCategoryParams) (database.RestoreCategoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", arg0, arg1)
+	ret0, _ := ret[0].(database.RestoreCategoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory.
+func (mr *MockRepoMockRecorder) RestoreCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockRepo)(nil).RestoreCategory), arg0, arg1)
+}
+
+// RestoreImportMapping mocks base method.
+func (m *MockRepo) RestoreImportMapping(arg0 context.Context, arg1 database.CreateImportMappingParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreImportMapping", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreImportMapping indicates an expected call of RestoreImportMapping.
+func (mr *MockRepoMockRecorder) RestoreImportMapping(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreImportMapping", reflect.TypeOf((*MockRepo)(nil).RestoreImportMapping), arg0, arg1)
+}
+
+// RestoreMerchant mocks base method.
+func (m *MockRepo) RestoreMerchant(arg0 context.Context, arg1 database.CreateMerchantParams) (database.RestoreMerchantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMerchant", arg0, arg1)
+	ret0, _ := ret[0].(database.RestoreMerchantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreMerchant indicates an expected call of RestoreMerchant.
+func (mr *MockRepoMockRecorder) RestoreMerchant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMerchant", reflect.TypeOf((*MockRepo)(nil).RestoreMerchant), arg0, arg1)
+}
+
+// ReversePostingsByTransactionID mocks base method.
+func (m *MockRepo) ReversePostingsByTransactionID(arg0 context.Context, arg1 models.TransactionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReversePostingsByTransactionID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReversePostingsByTransactionID indicates an expected call of ReversePostingsByTransactionID.
+func (mr *MockRepoMockRecorder) ReversePostingsByTransactionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReversePostingsByTransactionID", reflect.TypeOf((*MockRepo)(nil).ReversePostingsByTransactionID), arg0, arg1)
+}
+
+// RevokeRole mocks base method.
+func (m *MockRepo) RevokeRole(arg0 context.Context, arg1 database.RoleParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockRepoMockRecorder) RevokeRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockRepo)(nil).RevokeRole), arg0, arg1)
+}
+
+// SaveRefreshToken mocks base method.
+func (m *MockRepo) SaveRefreshToken(arg0 context.Context, arg1 database.SaveRefreshTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRefreshToken indicates an expected call of SaveRefreshToken.
+func (mr *MockRepoMockRecorder) SaveRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRefreshToken", reflect.TypeOf((*MockRepo)(nil).SaveRefreshToken), arg0, arg1)
+}
+
+// SearchTransactions mocks base method.
+func (m *MockRepo) SearchTransactions(arg0 context.Context, arg1 database.SearchTransactionsParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTransactions indicates an expected call of SearchTransactions.
+func (mr *MockRepoMockRecorder) SearchTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTransactions", reflect.TypeOf((*MockRepo)(nil).SearchTransactions), arg0, arg1)
+}
+
+// SetDefaultBaseCurrency mocks base method.
+func (m *MockRepo) SetDefaultBaseCurrency(arg0 context.Context, arg1 database.UpdateBaseCurrencyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultBaseCurrency", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefaultBaseCurrency indicates an expected call of SetDefaultBaseCurrency.
+func (mr *MockRepoMockRecorder) SetDefaultBaseCurrency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultBaseCurrency", reflect.TypeOf((*MockRepo)(nil).SetDefaultBaseCurrency), arg0, arg1)
+}
+
+// SetRecurringNextRun mocks base method.
+func (m *MockRepo) SetRecurringNextRun(arg0 context.Context, arg1 database.SetRecurringNextRunParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecurringNextRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecurringNextRun indicates an expected call of SetRecurringNextRun.
+func (mr *MockRepoMockRecorder) SetRecurringNextRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecurringNextRun", reflect.TypeOf((*MockRepo)(nil).SetRecurringNextRun), arg0, arg1)
+}
+
+// SetRulePosition mocks base method.
+func (m *MockRepo) SetRulePosition(arg0 context.Context, arg1 database.SetRulePositionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRulePosition", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRulePosition indicates an expected call of SetRulePosition.
+func (mr *MockRepoMockRecorder) SetRulePosition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRulePosition", reflect.TypeOf((*MockRepo)(nil).SetRulePosition), arg0, arg1)
+}
+
+// SetTransactionStatusTx mocks base method.
+func (m *MockRepo) SetTransactionStatusTx(arg0 context.Context, arg1 database.SetTransactionStatusParams) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransactionStatusTx", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTransactionStatusTx indicates an expected call of SetTransactionStatusTx.
+func (mr *MockRepoMockRecorder) SetTransactionStatusTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransactionStatusTx", reflect.TypeOf((*MockRepo)(nil).SetTransactionStatusTx), arg0, arg1)
+}
+
+// SummaryReport mocks base method.
+func (m *MockRepo) SummaryReport(arg0 context.Context, arg1 database.SummaryReportParams) ([]models.PeriodSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummaryReport", arg0, arg1)
+	ret0, _ := ret[0].([]models.PeriodSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummaryReport indicates an expected call of SummaryReport.
+func (mr *MockRepoMockRecorder) SummaryReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummaryReport", reflect.TypeOf((*MockRepo)(nil).SummaryReport), arg0, arg1)
+}
+
+// TrialBalance mocks base method.
+func (m *MockRepo) TrialBalance(arg0 context.Context, arg1 database.TrialBalanceParams) ([]models.TrialBalanceLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrialBalance", arg0, arg1)
+	ret0, _ := ret[0].([]models.TrialBalanceLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrialBalance indicates an expected call of TrialBalance.
+func (mr *MockRepoMockRecorder) TrialBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrialBalance", reflect.TypeOf((*MockRepo)(nil).TrialBalance), arg0, arg1)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockRepo) UpdateAccount(arg0 context.Context, arg1 database.UpdateAccountParams) (models.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", arg0, arg1)
+	ret0, _ := ret[0].(models.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockRepoMockRecorder) UpdateAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockRepo)(nil).UpdateAccount), arg0, arg1)
+}
+
+// UpdateBaseCurrency mocks base method.
+func (m *MockRepo) UpdateBaseCurrency(arg0 context.Context, arg1 database.UpdateBaseCurrencyParams) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBaseCurrency", arg0, arg1)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBaseCurrency indicates an expected call of UpdateBaseCurrency.
+func (mr *MockRepoMockRecorder) UpdateBaseCurrency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBaseCurrency", reflect.TypeOf((*MockRepo)(nil).UpdateBaseCurrency), arg0, arg1)
+}
+
+// UpdateBudget mocks base method.
+func (m *MockRepo) UpdateBudget(arg0 context.Context, arg1 database.UpdateBudgetParams) (models.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBudget", arg0, arg1)
+	ret0, _ := ret[0].(models.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBudget indicates an expected call of UpdateBudget.
+func (mr *MockRepoMockRecorder) UpdateBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBudget", reflect.TypeOf((*MockRepo)(nil).UpdateBudget), arg0, arg1)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockRepo) UpdateCategory(arg0 context.Context, arg1 database.UpdateCategoryParams) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockRepoMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockRepo)(nil).UpdateCategory), arg0, arg1)
+}
+
+// UpdateMerchant mocks base method.
+func (m *MockRepo) UpdateMerchant(arg0 context.Context, arg1 database.UpdateMerchantParams) (models.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMerchant", arg0, arg1)
+	ret0, _ := ret[0].(models.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMerchant indicates an expected call of UpdateMerchant.
+func (mr *MockRepoMockRecorder) UpdateMerchant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchant", reflect.TypeOf((*MockRepo)(nil).UpdateMerchant), arg0, arg1)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockRepo) UpdatePassword(arg0 context.Context, arg1 database.UpdatePasswordParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockRepoMockRecorder) UpdatePassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockRepo)(nil).UpdatePassword), arg0, arg1)
+}
+
+// UpdateRecurring mocks base method.
+func (m *MockRepo) UpdateRecurring(arg0 context.Context, arg1 database.UpdateRecurringParams) (models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurring", arg0, arg1)
+	ret0, _ := ret[0].(models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecurring indicates an expected call of UpdateRecurring.
+func (mr *MockRepoMockRecorder) UpdateRecurring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurring", reflect.TypeOf((*MockRepo)(nil).UpdateRecurring), arg0, arg1)
+}
+
+// UpdateRecurringTx mocks base method.
+func (m *MockRepo) UpdateRecurringTx(arg0 context.Context, arg1 database.UpdateRecurringParams) (models.RecurringTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringTx", arg0, arg1)
+	ret0, _ := ret[0].(models.RecurringTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecurringTx indicates an expected call of UpdateRecurringTx.
+func (mr *MockRepoMockRecorder) UpdateRecurringTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringTx", reflect.TypeOf((*MockRepo)(nil).UpdateRecurringTx), arg0, arg1)
+}
+
+// UpdateRule mocks base method.
+func (m *MockRepo) UpdateRule(arg0 context.Context, arg1 database.UpdateRuleParams) (models.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", arg0, arg1)
+	ret0, _ := ret[0].(models.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockRepoMockRecorder) UpdateRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockRepo)(nil).UpdateRule), arg0, arg1)
+}
+
+// UpdateSignupTemplate mocks base method.
+func (m *MockRepo) UpdateSignupTemplate(arg0 context.Context, arg1 database.UpdateSignupTemplateParams) (models.SignupTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSignupTemplate", arg0, arg1)
+	ret0, _ := ret[0].(models.SignupTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSignupTemplate indicates an expected call of UpdateSignupTemplate.
+func (mr *MockRepoMockRecorder) UpdateSignupTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSignupTemplate", reflect.TypeOf((*MockRepo)(nil).UpdateSignupTemplate), arg0, arg1)
+}
+
+// UpdateTransaction mocks base method.
+func (m *MockRepo) UpdateTransaction(arg0 context.Context, arg1 database.UpdateTransactionParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransaction", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransaction indicates an expected call of UpdateTransaction.
+func (mr *MockRepoMockRecorder) UpdateTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransaction", reflect.TypeOf((*MockRepo)(nil).UpdateTransaction), arg0, arg1)
+}
+
+// UpdateTransactionStatus mocks base method.
+func (m *MockRepo) UpdateTransactionStatus(arg0 context.Context, arg1 database.UpdateTransactionStatusParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionStatus", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionStatus indicates an expected call of UpdateTransactionStatus.
+func (mr *MockRepoMockRecorder) UpdateTransactionStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionStatus", reflect.TypeOf((*MockRepo)(nil).UpdateTransactionStatus), arg0, arg1)
+}
+
+// UpdateTransactionTx mocks base method.
+func (m *MockRepo) UpdateTransactionTx(arg0 context.Context, arg1 database.UpdateTransactionParams) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionTx indicates an expected call of UpdateTransactionTx.
+func (mr *MockRepoMockRecorder) UpdateTransactionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionTx", reflect.TypeOf((*MockRepo)(nil).UpdateTransactionTx), arg0, arg1)
+}
+
+// UpdateTransfer mocks base method.
+func (m *MockRepo) UpdateTransfer(arg0 context.Context, arg1 database.UpdateTransferParams) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransfer indicates an expected call of UpdateTransfer.
+func (mr *MockRepoMockRecorder) UpdateTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransfer", reflect.TypeOf((*MockRepo)(nil).UpdateTransfer), arg0, arg1)
+}
+
+// UpdateTransferTx mocks base method.
+func (m *MockRepo) UpdateTransferTx(arg0 context.Context, arg1 database.UpdateTransferParams) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferTx indicates an expected call of UpdateTransferTx.
+func (mr *MockRepoMockRecorder) UpdateTransferTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferTx", reflect.TypeOf((*MockRepo)(nil).UpdateTransferTx), arg0, arg1)
+}
+
+// UpsertExchangeRates mocks base method.
+func (m *MockRepo) UpsertExchangeRates(arg0 context.Context, arg1 []models.ExchangeRate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExchangeRates", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExchangeRates indicates an expected call of UpsertExchangeRates.
+func (mr *MockRepoMockRecorder) UpsertExchangeRates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExchangeRates", reflect.TypeOf((*MockRepo)(nil).UpsertExchangeRates), arg0, arg1)
 }
@@ -2,6 +2,7 @@ package models
 
 import (
 	"FiberFinanceAPI/utils"
+	"encoding/json"
 	"time"
 )
 
@@ -27,3 +28,26 @@ type Account struct {
 	CreatedAt time.Time          `json:"created_at"`
 	DeletedAt time.Time          `json:"-"`
 }
+
+// MarshalJSON writes the balance as Money so it is read in the minor units of the account currency
+func (a Account) MarshalJSON() ([]byte, error) {
+	type account Account
+	return json.Marshal(struct {
+		account
+		Balance utils.Money `json:"balance"`
+	}{account(a), utils.NewMoney(a.Balance, a.Currency)})
+}
+
+// UnmarshalJSON reads an account written by MarshalJSON or with a bare balance in minor units
+func (a *Account) UnmarshalJSON(data []byte) error {
+	type account Account
+	aux := struct {
+		*account
+		Balance utils.Money `json:"balance"`
+	}{account: (*account)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.Balance = aux.Balance.Amount
+	return nil
+}
@@ -0,0 +1,25 @@
+package models
+
+// RestoreCount is what a restore did with the records of one kind in a backup
+type RestoreCount struct {
+	// Created records were not found for the user and were added
+	Created int `json:"created"`
+	// Existing records were matched to ones the user already has, by identifier or by name
+	Existing int `json:"existing"`
+	// Skipped records refer to an account or category the backup does not hold
+	Skipped int `json:"skipped"`
+}
+
+// RestoreResult is what restoring a backup did for each kind of record
+type RestoreResult struct {
+	Accounts       RestoreCount `json:"accounts"`
+	Categories     RestoreCount `json:"categories"`
+	Merchants      RestoreCount `json:"merchants"`
+	Transfers      RestoreCount `json:"transfers"`
+	Transactions   RestoreCount `json:"transactions"`
+	Budgets        RestoreCount `json:"budgets"`
+	Recurring      RestoreCount `json:"recurring"`
+	ImportMappings RestoreCount `json:"import_mappings"`
+	// Adjustments are the transactions that brought restored accounts to the balance they had in the backup
+	Adjustments []Transaction `json:"adjustments"`
+}
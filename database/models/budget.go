@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// BudgetID is our identifier for our budgets
+type BudgetID string
+
+// BudgetMonthLayout is the layout months are given in to budgets
+const BudgetMonthLayout = "2006-01"
+
+// Budget is what a user plans to spend in a category, and its children, in a month
+type Budget struct {
+	ID         BudgetID   `json:"id"`
+	UserID     UserID     `json:"user_id"`
+	CategoryID CategoryID `json:"category_id"`
+	Month      time.Time  `json:"month"`
+	Amount     int64      `json:"amount"`
+	Rollover   bool       `json:"rollover"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  time.Time  `json:"-"`
+}
+
+// BudgetProgress is how much of a budget has been spent. Spent counts the expenses of the category and every
+// category under it in the base currency of the user, CarriedOver is what was left over from the month before
+// when that budget rolls over
+type BudgetProgress struct {
+	BudgetID     BudgetID   `json:"budget_id"`
+	CategoryID   CategoryID `json:"category_id"`
+	CategoryName string     `json:"category_name"`
+	Month        time.Time  `json:"month"`
+	Rollover     bool       `json:"rollover"`
+	Budgeted     int64      `json:"budgeted"`
+	CarriedOver  int64      `json:"carried_over"`
+	Spent        int64      `json:"spent"`
+	Remaining    int64      `json:"remaining"`
+}
+
+// CarryOver works out the remaining amount of every budget in history, which has to be ordered by category
+// and month, and returns the progress of the budgets of month. The remaining amount of a rollover budget,
+// overspending included, is carried over to the budget of the same category for the month right after it
+func CarryOver(history []BudgetProgress, month time.Time) []BudgetProgress {
+	progress := []BudgetProgress{}
+	for i := range history {
+		budget := &history[i]
+		if i > 0 {
+			prev := history[i-1]
+			if prev.CategoryID == budget.CategoryID && prev.Rollover && prev.Month.AddDate(0, 1, 0).Equal(budget.Month) {
+				budget.CarriedOver = prev.Remaining
+			}
+		}
+		budget.Remaining = budget.Budgeted + budget.CarriedOver - budget.Spent
+		if budget.Month.Equal(month) {
+			progress = append(progress, *budget)
+		}
+	}
+	return progress
+}
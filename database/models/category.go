@@ -1,10 +1,32 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// nilCategoryID is the parent_id the database gives a category created without a parent
+const nilCategoryID CategoryID = "00000000-0000-0000-0000-000000000000"
 
 // CategoryID is our identifier for our category
 type CategoryID string
 
+// Scan implements sql.Scanner, transfer transactions have a NULL category_id
+func (c *CategoryID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = ""
+	case string:
+		*c = CategoryID(v)
+	case []byte:
+		*c = CategoryID(v)
+	default:
+		return fmt.Errorf("cannot scan %T into CategoryID", src)
+	}
+	return nil
+}
+
 // Category represents our user category model or structure
 type Category struct {
 	ID        CategoryID `json:"id"`
@@ -14,3 +36,83 @@ type Category struct {
 	CreatedAt time.Time  `json:"created_at"`
 	DeletedAt time.Time  `json:"-"`
 }
+
+// IsTopLevel reports whether the category has no parent
+func (c Category) IsTopLevel() bool {
+	return c.ParentID == "" || c.ParentID == nilCategoryID
+}
+
+// CategoryNode is a category with its subcategories
+type CategoryNode struct {
+	Category
+	Categories []CategoryNode `json:"categories"`
+}
+
+// CategoryTree nests the categories under their parents, categories whose parent is not among them are put at the
+// top level, as is one category of any cycle so every category appears exactly once
+func CategoryTree(categories []Category) []CategoryNode {
+	byID := make(map[CategoryID]Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	children := make(map[CategoryID][]Category)
+	var roots []Category
+	for _, category := range categories {
+		if _, ok := byID[category.ParentID]; category.IsTopLevel() || !ok {
+			roots = append(roots, category)
+			continue
+		}
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+	placed := make(map[CategoryID]bool, len(categories))
+	var build func(category Category) CategoryNode
+	build = func(category Category) CategoryNode {
+		placed[category.ID] = true
+		node := CategoryNode{Category: category, Categories: []CategoryNode{}}
+		for _, child := range children[category.ID] {
+			if !placed[child.ID] {
+				node.Categories = append(node.Categories, build(child))
+			}
+		}
+		return node
+	}
+	tree := []CategoryNode{}
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	// what is left hangs off a cycle of parents that never reaches the top level
+	for _, category := range categories {
+		if !placed[category.ID] {
+			tree = append(tree, build(category))
+		}
+	}
+	sort.SliceStable(tree, func(i, j int) bool { return tree[i].Name < tree[j].Name })
+	return tree
+}
+
+// CategoryMerge is what was moved from a merged category to the category it was merged into, Reconciled counts
+// the reconciled transactions that were left in the merged category as they were not unlocked
+type CategoryMerge struct {
+	Category      Category `json:"category"`
+	Transactions  int64    `json:"transactions"`
+	Splits        int64    `json:"splits"`
+	Recurring     int64    `json:"recurring"`
+	Budgets       int64    `json:"budgets"`
+	Subcategories int64    `json:"subcategories"`
+	Reconciled    int64    `json:"reconciled"`
+}
+
+// CategorizedTransaction is the description and merchant of a transaction filed under a category, what category
+// suggestions learn from
+type CategorizedTransaction struct {
+	Name       string     `json:"name"`
+	Merchant   string     `json:"merchant"`
+	CategoryID CategoryID `json:"category_id"`
+}
+
+// CategorySuggestion is a category suggested for a transaction description with the probability it is the one
+type CategorySuggestion struct {
+	CategoryID CategoryID `json:"category_id"`
+	Name       string     `json:"name"`
+	Score      float64    `json:"score"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ErrInvalidCursor is returned for a cursor that was not handed out with a page
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of the last row of a page, the next page starts right after it. Transactions are
+// ordered by date and the other lists by creation time, the ID breaks ties so no row is skipped or repeated
+// when rows are added or removed between pages
+type Cursor struct {
+	Date time.Time `json:"d"`
+	// Amount is only set by searches ordered by amount
+	Amount int64  `json:"a,omitempty"`
+	ID     string `json:"id"`
+}
+
+// IsZero reports whether the cursor is the start of a list
+func (c Cursor) IsZero() bool {
+	return c.ID == ""
+}
+
+// Encode returns the cursor as the opaque string handed out with a page
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor reads a cursor returned by Encode, an empty string is the start of a list
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+	if s == "" {
+		return c, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &c) != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	// the ID is compared with uuid columns, anything else would fail the query rather than the request
+	if _, err = uuid.Parse(c.ID); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
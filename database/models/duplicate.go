@@ -0,0 +1,100 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// DuplicateWindow is how far apart the dates of two transactions can be for one to be a duplicate of the other
+	DuplicateWindow = 3 * 24 * time.Hour
+	// duplicateSimilarity is how alike the names of two transactions have to be for one to be a duplicate
+	duplicateSimilarity = 0.5
+)
+
+// DuplicatePair is two transactions that are likely the same one entered twice, Duplicate is the one entered last
+type DuplicatePair struct {
+	Transaction Transaction `json:"transaction"`
+	Duplicate   Transaction `json:"duplicate"`
+	// Similarity is how alike their names are, from 0 to 1
+	Similarity float64 `json:"similarity"`
+}
+
+// LikelyDuplicate reports whether two transactions are likely the same one entered twice, they are when they are
+// on the same account with the same type and amount, their dates are within DuplicateWindow and their names are
+// alike or they have the same merchant. Transfer legs are never duplicates, they are changed through their
+// transfer
+func LikelyDuplicate(a, b Transaction) (float64, bool) {
+	if a.ID == b.ID || a.TransferID != "" || b.TransferID != "" || a.AccountID != b.AccountID ||
+		a.TransactionType != b.TransactionType || a.Amount != b.Amount {
+		return 0, false
+	}
+	gap := a.Date.Sub(b.Date)
+	if gap < 0 {
+		gap = -gap
+	}
+	if gap > DuplicateWindow {
+		return 0, false
+	}
+	similarity := NameSimilarity(a.Name, b.Name)
+	if a.MerchantID != "" && a.MerchantID == b.MerchantID {
+		similarity = 1
+	}
+	return similarity, similarity >= duplicateSimilarity
+}
+
+// FindDuplicates returns the pairs a new transaction makes with the candidates it is likely a duplicate of, the
+// candidate is the Transaction of each pair and the new transaction its Duplicate
+func FindDuplicates(transaction Transaction, candidates []Transaction) []DuplicatePair {
+	var pairs []DuplicatePair
+	for _, candidate := range candidates {
+		if similarity, ok := LikelyDuplicate(transaction, candidate); ok {
+			pairs = append(pairs, DuplicatePair{Transaction: candidate, Duplicate: transaction, Similarity: similarity})
+		}
+	}
+	return pairs
+}
+
+// NameSimilarity is the Dice coefficient of the letter pairs of two names, case, punctuation and words holding
+// digits, such as references and M-Pesa codes, are ignored. Identical names are 1 and names sharing no pair of
+// letters 0
+func NameSimilarity(a, b string) float64 {
+	if strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b)) {
+		return 1
+	}
+	pairs := letterPairs(a)
+	other := letterPairs(b)
+	if len(pairs) == 0 || len(other) == 0 {
+		return 0
+	}
+	counts := make(map[string]int, len(pairs))
+	for _, pair := range pairs {
+		counts[pair]++
+	}
+	shared := 0
+	for _, pair := range other {
+		if counts[pair] > 0 {
+			counts[pair]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(pairs)+len(other))
+}
+
+// letterPairs returns the adjacent letter pairs of the words of a name
+func letterPairs(name string) []string {
+	var pairs []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+			continue
+		}
+		runes := []rune(word)
+		for i := 0; i+1 < len(runes); i++ {
+			pairs = append(pairs, string(runes[i:i+2]))
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"FiberFinanceAPI/utils"
+	"time"
+)
+
+// ExchangeRate is what one unit of Base was worth in Quote on Date
+type ExchangeRate struct {
+	Base  utils.CurrencyCode `json:"base"`
+	Quote utils.CurrencyCode `json:"quote"`
+	Date  time.Time          `json:"date"`
+	Rate  float64            `json:"rate"`
+}
+
+// Conversion is an amount converted to another currency, Rate and Amount are nil when no rate was known
+type Conversion struct {
+	Currency utils.CurrencyCode `json:"currency"`
+	Rate     *float64           `json:"rate"`
+	Amount   *int64             `json:"amount"`
+}
+
+// ConvertedBalance is the balance of an account converted to the base currency of its user
+type ConvertedBalance struct {
+	AccountID AccountID          `json:"account_id"`
+	Name      string             `json:"account_name"`
+	Type      AccountType        `json:"account_type"`
+	Currency  utils.CurrencyCode `json:"currency"`
+	Balance   int64              `json:"balance"`
+	// Rate and ConvertedBalance are nil when there is no rate from Currency to the base currency
+	Rate             *float64 `json:"rate"`
+	ConvertedBalance *int64   `json:"converted_balance"`
+}
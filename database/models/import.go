@@ -0,0 +1,33 @@
+package models
+
+import (
+	"FiberFinanceAPI/importer"
+	"time"
+)
+
+// ImportMappingID is our identifier for our import mappings
+type ImportMappingID string
+
+// ImportMapping is a CSV column mapping a user saved under a name
+type ImportMapping struct {
+	ID        ImportMappingID     `json:"id"`
+	UserID    UserID              `json:"user_id"`
+	Name      string              `json:"name"`
+	Mapping   importer.CSVMapping `json:"mapping"`
+	CreatedAt time.Time           `json:"created_at"`
+	DeletedAt time.Time           `json:"-"`
+}
+
+// ImportResult is what importing a statement did to an account
+type ImportResult struct {
+	Transactions []Transaction `json:"transactions"`
+	// Duplicates are the external identifiers of entries imported before, they were skipped
+	Duplicates []string `json:"duplicates"`
+	// Adjustment is the transaction that brought the account to the balance of the statement, when one was needed
+	Adjustment *Transaction `json:"adjustment,omitempty"`
+	// Balance is the balance of the account after the import
+	Balance int64 `json:"balance"`
+	// PossibleDuplicates pair the imported transactions with the transactions of the account they are likely
+	// duplicates of, such as ones entered by hand before the statement was imported
+	PossibleDuplicates []DuplicatePair `json:"possible_duplicates,omitempty"`
+}
@@ -1,10 +1,28 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // MerchantID is our identifier for our merchant
 type MerchantID string
 
+// Scan implements sql.Scanner, transactions without a merchant have a NULL merchant_id
+func (m *MerchantID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = ""
+	case string:
+		*m = MerchantID(v)
+	case []byte:
+		*m = MerchantID(v)
+	default:
+		return fmt.Errorf("cannot scan %T into MerchantID", src)
+	}
+	return nil
+}
+
 // Merchant represents our user merchant model or structure
 type Merchant struct {
 	ID        MerchantID `json:"id"`
@@ -13,3 +31,15 @@ type Merchant struct {
 	CreatedAt time.Time  `json:"created_at"`
 	DeletedAt time.Time  `json:"-"`
 }
+
+// MerchantSpend is what a user spent at, and got refunded by, a merchant over a period. Spent and Refunded are in
+// the base currency of the user, converted at the rate of the day of each transaction. MissingRates counts the
+// days no rate was known for, they are left out
+type MerchantSpend struct {
+	MerchantID       MerchantID `json:"merchant_id"`
+	Name             string     `json:"name"`
+	TransactionCount int64      `json:"transaction_count"`
+	Spent            int64      `json:"spent"`
+	Refunded         int64      `json:"refunded"`
+	MissingRates     int64      `json:"missing_rates"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"FiberFinanceAPI/utils"
+	"time"
+)
+
+// PostingID is our identifier for our postings
+type PostingID string
+
+// LedgerAccount is the side of the books a posting is made to
+type LedgerAccount string
+
+const (
+	LedgerAsset     LedgerAccount = "asset"
+	LedgerLiability LedgerAccount = "liability"
+	LedgerIncome    LedgerAccount = "income"
+	LedgerExpense   LedgerAccount = "expense"
+	// LedgerTransfer is where transfer legs post their other side, it nets to zero once both legs are posted
+	LedgerTransfer LedgerAccount = "transfer"
+)
+
+// Ledger returns the ledger account an account of this type is kept in
+func (t AccountType) Ledger() LedgerAccount {
+	if t == Credit {
+		return LedgerLiability
+	}
+	return LedgerAsset
+}
+
+// Ledger returns the ledger account the other side of a transaction of this type is posted to
+func (t TransactionType) Ledger() LedgerAccount {
+	switch t {
+	case Income:
+		return LedgerIncome
+	case Expense:
+		return LedgerExpense
+	}
+	return LedgerTransfer
+}
+
+// Posting is one line of the journal entry of a Transaction, the postings of a transaction sum to zero.
+// Debits are positive and credits negative
+type Posting struct {
+	ID            PostingID     `json:"id"`
+	TransactionID TransactionID `json:"transaction_id"`
+	UserID        UserID        `json:"user_id"`
+	LedgerAccount LedgerAccount `json:"ledger_account"`
+	AccountID     AccountID     `json:"account_id,omitempty"`
+	CategoryID    CategoryID    `json:"category_id,omitempty"`
+	Amount        int64         `json:"amount"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// TrialBalanceLine is the total of the postings made to one ledger account in one currency, Debit and Credit are
+// in Currency. A category posted to from accounts of different currencies has a line per currency
+type TrialBalanceLine struct {
+	LedgerAccount LedgerAccount      `json:"ledger_account"`
+	AccountID     AccountID          `json:"account_id,omitempty"`
+	CategoryID    CategoryID         `json:"category_id,omitempty"`
+	Name          string             `json:"name"`
+	Currency      utils.CurrencyCode `json:"currency"`
+	Debit         int64              `json:"debit"`
+	Credit        int64              `json:"credit"`
+	// ConvertedDebit and ConvertedCredit are the total in the base currency of the user, converted at the rate
+	// of the day of each transaction. MissingRates counts the days no rate was known for, they are left out
+	ConvertedDebit  int64 `json:"converted_debit"`
+	ConvertedCredit int64 `json:"converted_credit"`
+	MissingRates    int64 `json:"missing_rates"`
+}
+
+// AccountImbalance is an account whose stored balance does not match the sum of its postings
+type AccountImbalance struct {
+	AccountID     AccountID `json:"account_id"`
+	Name          string    `json:"account_name"`
+	Balance       int64     `json:"balance"`
+	PostedBalance int64     `json:"posted_balance"`
+}
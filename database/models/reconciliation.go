@@ -0,0 +1,67 @@
+package models
+
+import (
+	"FiberFinanceAPI/utils"
+	"encoding/json"
+	"time"
+)
+
+// ReconciliationID is our identifier for our reconciliations
+type ReconciliationID string
+
+// ReconciliationDateLayout is the layout statement dates are given in to reconciliations
+const ReconciliationDateLayout = "2006-01-02"
+
+// ReconciliationStatus is whether a reconciliation is still being worked on
+type ReconciliationStatus string
+
+const (
+	ReconciliationOpen      ReconciliationStatus = "open"
+	ReconciliationCompleted ReconciliationStatus = "completed"
+)
+
+// Reconciliation checks the transactions of an account against the ending balance of a statement. An account has
+// at most one open reconciliation, completed ones are its reconciliation history
+type Reconciliation struct {
+	ID        ReconciliationID `json:"id"`
+	UserID    UserID           `json:"user_id"`
+	AccountID AccountID        `json:"account_id"`
+	// StatementDate is the last day of the statement, transactions made any time on it are on the statement
+	StatementDate    time.Time `json:"statement_date"`
+	StatementBalance int64     `json:"statement_balance"`
+	// OpeningBalance is the balance of the transactions reconciled before this one and TransactionCount how many
+	// transactions this one reconciled, both are set when it is completed
+	OpeningBalance   int64                `json:"opening_balance"`
+	TransactionCount int32                `json:"transaction_count"`
+	Status           ReconciliationStatus `json:"status"`
+	Currency         utils.CurrencyCode   `json:"currency"`
+	CreatedAt        time.Time            `json:"created_at"`
+	CompletedAt      time.Time            `json:"completed_at"`
+	DeletedAt        time.Time            `json:"-"`
+}
+
+// MarshalJSON writes the balances as Money so they are read in the minor units of the account currency
+func (r Reconciliation) MarshalJSON() ([]byte, error) {
+	type reconciliation Reconciliation
+	return json.Marshal(struct {
+		reconciliation
+		StatementBalance utils.Money `json:"statement_balance"`
+		OpeningBalance   utils.Money `json:"opening_balance"`
+	}{reconciliation(r), utils.NewMoney(r.StatementBalance, r.Currency), utils.NewMoney(r.OpeningBalance, r.Currency)})
+}
+
+// UnmarshalJSON reads a reconciliation written by MarshalJSON or with bare balances in minor units
+func (r *Reconciliation) UnmarshalJSON(data []byte) error {
+	type reconciliation Reconciliation
+	aux := struct {
+		*reconciliation
+		StatementBalance utils.Money `json:"statement_balance"`
+		OpeningBalance   utils.Money `json:"opening_balance"`
+	}{reconciliation: (*reconciliation)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.StatementBalance = aux.StatementBalance.Amount
+	r.OpeningBalance = aux.OpeningBalance.Amount
+	return nil
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"FiberFinanceAPI/utils"
+	"time"
+)
+
+// RecurringID is our identifier for our recurring transactions
+type RecurringID string
+
+// SkipDateLayout is the layout of the dates a recurring transaction skips
+const SkipDateLayout = "2006-01-02"
+
+// RecurringTransaction is a template a transaction is posted from every time its rule falls due.
+// A zero EndDate means it never ends and a zero NextRun means it has no occurrences left
+type RecurringTransaction struct {
+	ID              RecurringID     `json:"id"`
+	UserID          UserID          `json:"user_id"`
+	AccountID       AccountID       `json:"account_id"`
+	CategoryID      CategoryID      `json:"category_id"`
+	MerchantID      MerchantID      `json:"merchant_id,omitempty"`
+	Name            string          `json:"name"`
+	TransactionType TransactionType `json:"transaction_type"`
+	Amount          int64           `json:"amount"`
+	Notes           string          `json:"notes"`
+	Rule            string          `json:"rule"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         time.Time       `json:"end_date"`
+	SkipDates       []string        `json:"skip_dates"`
+	NextRun         time.Time       `json:"next_run"`
+	CreatedAt       time.Time       `json:"created_at"`
+	DeletedAt       time.Time       `json:"-"`
+}
+
+// Occurrences returns up to limit dates on or after from the recurring transaction is due on, leaving out
+// its skip dates and anything after the day of its end date
+func (r RecurringTransaction) Occurrences(from time.Time, limit int) ([]time.Time, error) {
+	rule, err := utils.ParseRule(r.Rule)
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[string]bool, len(r.SkipDates))
+	for _, date := range r.SkipDates {
+		skip[date] = true
+	}
+	// occurrences, the end date and skip dates are compared as calendar dates where the rule starts, wherever the
+	// database put the times they were read from
+	loc := r.StartDate.Location()
+	var end string
+	if !r.EndDate.IsZero() {
+		end = r.EndDate.In(loc).Format(SkipDateLayout)
+	}
+	var dates []time.Time
+	// every skip date can remove at most one occurrence
+	for _, date := range rule.Occurrences(r.StartDate, from, limit+len(skip)) {
+		day := date.In(loc).Format(SkipDateLayout)
+		if end != "" && day > end {
+			break
+		}
+		if skip[day] {
+			continue
+		}
+		dates = append(dates, date)
+		if len(dates) == limit {
+			break
+		}
+	}
+	return dates, nil
+}
+
+// NextOccurrence returns the first date after date the recurring transaction is due on, or the zero time
+// when it has none left
+func (r RecurringTransaction) NextOccurrence(date time.Time) (time.Time, error) {
+	dates, err := r.Occurrences(date.Add(time.Nanosecond), 1)
+	if err != nil || len(dates) == 0 {
+		return time.Time{}, err
+	}
+	return dates[0], nil
+}
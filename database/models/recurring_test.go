@@ -0,0 +1,105 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecurringTransactionOccurrences(t *testing.T) {
+	nairobi := time.FixedZone("EAT", 3*60*60)
+	at := func(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, loc)
+	}
+	tests := []struct {
+		name      string
+		recurring RecurringTransaction
+		from      time.Time
+		limit     int
+		want      []time.Time
+	}{
+		{
+			name:      "no end date",
+			recurring: RecurringTransaction{Rule: "FREQ=DAILY", StartDate: at(2021, 1, 1, 9, time.UTC)},
+			from:      at(2021, 1, 1, 0, time.UTC),
+			limit:     2,
+			want:      []time.Time{at(2021, 1, 1, 9, time.UTC), at(2021, 1, 2, 9, time.UTC)},
+		},
+		{
+			name: "end date includes the occurrence later on its day",
+			recurring: RecurringTransaction{
+				Rule:      "FREQ=DAILY",
+				StartDate: at(2021, 1, 1, 9, time.UTC),
+				EndDate:   at(2021, 1, 3, 0, time.UTC),
+			},
+			from:  at(2021, 1, 1, 0, time.UTC),
+			limit: 10,
+			want:  []time.Time{at(2021, 1, 1, 9, time.UTC), at(2021, 1, 2, 9, time.UTC), at(2021, 1, 3, 9, time.UTC)},
+		},
+		{
+			name: "end date read in UTC is the day where the rule starts",
+			recurring: RecurringTransaction{
+				Rule:      "FREQ=DAILY",
+				StartDate: at(2021, 1, 1, 1, nairobi),
+				// midnight of the 3rd in Nairobi
+				EndDate: at(2021, 1, 2, 21, time.UTC),
+			},
+			from:  at(2021, 1, 1, 0, nairobi),
+			limit: 10,
+			want:  []time.Time{at(2021, 1, 1, 1, nairobi), at(2021, 1, 2, 1, nairobi), at(2021, 1, 3, 1, nairobi)},
+		},
+		{
+			name: "skip dates are left out without shortening the limit",
+			recurring: RecurringTransaction{
+				Rule:      "FREQ=WEEKLY",
+				StartDate: at(2021, 3, 1, 9, time.UTC),
+				SkipDates: []string{"2021-03-08", "2021-03-22"},
+			},
+			from:  at(2021, 3, 1, 0, time.UTC),
+			limit: 3,
+			want:  []time.Time{at(2021, 3, 1, 9, time.UTC), at(2021, 3, 15, 9, time.UTC), at(2021, 3, 29, 9, time.UTC)},
+		},
+		{
+			name: "skip dates are days where the rule starts",
+			recurring: RecurringTransaction{
+				Rule: "FREQ=DAILY",
+				// already the 2nd in UTC
+				StartDate: at(2021, 1, 1, 1, nairobi),
+				SkipDates: []string{"2020-12-31", "2021-01-02"},
+			},
+			from:  at(2021, 1, 1, 0, nairobi),
+			limit: 2,
+			want:  []time.Time{at(2021, 1, 1, 1, nairobi), at(2021, 1, 3, 1, nairobi)},
+		},
+		{
+			name: "skipped end date",
+			recurring: RecurringTransaction{
+				Rule:      "FREQ=MONTHLY",
+				StartDate: at(2021, 1, 31, 9, time.UTC),
+				EndDate:   at(2021, 3, 31, 0, time.UTC),
+				SkipDates: []string{"2021-03-31"},
+			},
+			from:  at(2021, 1, 1, 0, time.UTC),
+			limit: 10,
+			want:  []time.Time{at(2021, 1, 31, 9, time.UTC), at(2021, 2, 28, 9, time.UTC)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.recurring.Occurrences(tt.from, tt.limit)
+			if err != nil {
+				t.Fatalf("Occurrences() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurringTransactionOccurrencesInvalidRule(t *testing.T) {
+	recurring := RecurringTransaction{Rule: "FREQ=HOURLY", StartDate: time.Now()}
+	if _, err := recurring.Occurrences(time.Now(), 1); err == nil {
+		t.Fatal("Occurrences() with an invalid rule returned no error")
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// ReportInterval is the length of the periods a report is broken into
+type ReportInterval string
+
+const (
+	Day     ReportInterval = "day"
+	Week    ReportInterval = "week"
+	Month   ReportInterval = "month"
+	Quarter ReportInterval = "quarter"
+	Year    ReportInterval = "year"
+)
+
+// Step returns the interval as a postgres interval, periods start where date_trunc truncates a date to
+func (i ReportInterval) Step() string {
+	if i == Quarter {
+		return "3 months"
+	}
+	return "1 " + string(i)
+}
+
+// PeriodSummary is the income and expense of a user over one period of a report, in their base currency at
+// the rate of the day of each transaction. Transfers between accounts are neither, and SavingsRate is the share
+// of the income that was not spent, nil when there was no income. MissingRates counts the days no rate was
+// known for, they are left out of the totals
+type PeriodSummary struct {
+	PeriodStart      time.Time `json:"period_start"`
+	TransactionCount int64     `json:"transaction_count"`
+	Income           int64     `json:"income"`
+	Expense          int64     `json:"expense"`
+	Net              int64     `json:"net"`
+	SavingsRate      *float64  `json:"savings_rate"`
+	MissingRates     int64     `json:"missing_rates"`
+}
+
+// SetNet works out the net and savings rate of the period from its income and expense
+func (p *PeriodSummary) SetNet() {
+	p.Net = p.Income - p.Expense
+	p.SavingsRate = nil
+	if p.Income > 0 {
+		rate := float64(p.Net) / float64(p.Income)
+		p.SavingsRate = &rate
+	}
+}
+
+// CategorySpend is what a user spent in a category over a period and the period of the same length before it, in
+// their base currency. Amount and PreviousAmount roll up the spend of every subcategory, OwnAmount is only what
+// was put in the category itself. Transactions without a category are reported with an empty CategoryID.
+// MissingRates counts the days no rate was known for, they are left out of the amounts
+type CategorySpend struct {
+	CategoryID     CategoryID `json:"category_id"`
+	ParentID       CategoryID `json:"parent_id"`
+	Name           string     `json:"name"`
+	Deleted        bool       `json:"deleted,omitempty"`
+	OwnAmount      int64      `json:"own_amount"`
+	Amount         int64      `json:"amount"`
+	PreviousAmount int64      `json:"previous_amount"`
+	MissingRates   int64      `json:"missing_rates"`
+	// Percentage is the share of the total of the period Amount makes up
+	Percentage float64 `json:"percentage"`
+	// Change is Amount less PreviousAmount, ChangeRate the change as a share of PreviousAmount, nil when nothing
+	// was spent in the previous period
+	Change     int64    `json:"change"`
+	ChangeRate *float64 `json:"change_rate"`
+}
@@ -0,0 +1,283 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule is returned when a categorization rule cannot be matched against transactions
+var ErrInvalidRule = errors.New("invalid rule")
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// RuleID is our identifier for our categorization rules
+type RuleID string
+
+// RuleField is the part of a transaction a RuleCondition looks at
+type RuleField string
+
+const (
+	RuleName     RuleField = "name"
+	RuleNotes    RuleField = "notes"
+	RuleAmount   RuleField = "amount"
+	RuleAccount  RuleField = "account"
+	RuleMerchant RuleField = "merchant"
+)
+
+// RuleOperator is how a RuleCondition compares its field
+type RuleOperator string
+
+const (
+	// Contains and Equals ignore case, Regex is case sensitive unless the pattern starts with (?i)
+	Contains RuleOperator = "contains"
+	Equals   RuleOperator = "equals"
+	Regex    RuleOperator = "regex"
+	// Between matches amounts from Min to Max inclusive, either bound can be left out
+	Between RuleOperator = "between"
+)
+
+// RuleCondition is one test a transaction has to pass for a rule to match it
+//
+//	name, notes        contains, equals or regex against Value
+//	amount             between Min and Max, in minor units of the account currency
+//	account, merchant  equals, Value is the ID
+type RuleCondition struct {
+	Field    RuleField    `json:"field"`
+	Operator RuleOperator `json:"operator"`
+	Value    string       `json:"value,omitempty"`
+	Min      *int64       `json:"min,omitempty"`
+	Max      *int64       `json:"max,omitempty"`
+	re       *regexp.Regexp
+}
+
+// RuleConditions are the conditions of a rule, a transaction has to pass all of them
+type RuleConditions []RuleCondition
+
+// Validate checks every condition can be matched and compiles the regular expressions
+func (c RuleConditions) Validate() error {
+	if len(c) == 0 {
+		return fmt.Errorf("%w: at least one condition is required", ErrInvalidRule)
+	}
+	for i := range c {
+		if err := c[i].compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RuleCondition) compile() error {
+	switch c.Field {
+	case RuleName, RuleNotes:
+		switch c.Operator {
+		case Contains, Equals:
+		case Regex:
+			re, err := regexp.Compile(c.Value)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+			}
+			c.re = re
+		default:
+			return fmt.Errorf("%w: %s supports contains, equals and regex", ErrInvalidRule, c.Field)
+		}
+		if c.Value == "" {
+			return fmt.Errorf("%w: %s %s needs a value", ErrInvalidRule, c.Field, c.Operator)
+		}
+	case RuleAmount:
+		if c.Operator != Between {
+			return fmt.Errorf("%w: amount supports between", ErrInvalidRule)
+		}
+		if c.Min == nil && c.Max == nil || c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+			return fmt.Errorf("%w: amount between needs a min, a max or both with min not above max", ErrInvalidRule)
+		}
+	case RuleAccount, RuleMerchant:
+		if c.Operator != Equals || !uuidPattern.MatchString(c.Value) {
+			return fmt.Errorf("%w: %s supports equals with an ID", ErrInvalidRule, c.Field)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported field %q", ErrInvalidRule, c.Field)
+	}
+	return nil
+}
+
+// matches reports whether the transaction passes the condition, conditions that do not validate never match
+func (c RuleCondition) matches(t Transaction) bool {
+	switch c.Field {
+	case RuleName:
+		return c.matchText(t.Name)
+	case RuleNotes:
+		return c.matchText(t.Notes)
+	case RuleAmount:
+		return c.Operator == Between && (c.Min != nil || c.Max != nil) &&
+			(c.Min == nil || t.Amount >= *c.Min) && (c.Max == nil || t.Amount <= *c.Max)
+	case RuleAccount:
+		return c.Operator == Equals && c.Value == string(t.AccountID)
+	case RuleMerchant:
+		return c.Operator == Equals && c.Value == string(t.MerchantID)
+	}
+	return false
+}
+
+func (c RuleCondition) matchText(text string) bool {
+	switch c.Operator {
+	case Contains:
+		return c.Value != "" && strings.Contains(strings.ToLower(text), strings.ToLower(c.Value))
+	case Equals:
+		return strings.EqualFold(strings.TrimSpace(text), strings.TrimSpace(c.Value))
+	case Regex:
+		if c.re == nil {
+			if err := c.compile(); err != nil {
+				return false
+			}
+		}
+		return c.re.MatchString(text)
+	}
+	return false
+}
+
+// Value implements driver.Valuer, conditions are saved as JSON
+func (c RuleConditions) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan implements sql.Scanner, the regular expressions are compiled once as the rule is read
+func (c *RuleConditions) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		err = json.Unmarshal(v, c)
+	case string:
+		err = json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into RuleConditions", src)
+	}
+	if err != nil {
+		return err
+	}
+	for i := range *c {
+		_ = (*c)[i].compile()
+	}
+	return nil
+}
+
+// RuleActions are what a rule gives the transactions it matches, empty actions are left out
+type RuleActions struct {
+	CategoryID CategoryID `json:"category_id,omitempty"`
+	MerchantID MerchantID `json:"merchant_id,omitempty"`
+	Notes      string     `json:"notes,omitempty"`
+}
+
+// IsEmpty reports whether the actions change nothing
+func (a RuleActions) IsEmpty() bool {
+	return a.CategoryID == "" && a.MerchantID == "" && a.Notes == ""
+}
+
+// Rule assigns a category, merchant and notes to the transactions matching all of its conditions. Rules run in
+// order of Position, each action is taken from the first matching rule that has it
+type Rule struct {
+	ID         RuleID         `json:"id"`
+	UserID     UserID         `json:"user_id"`
+	Name       string         `json:"name"`
+	Position   int32          `json:"position"`
+	Conditions RuleConditions `json:"conditions"`
+	RuleActions
+	CreatedAt time.Time `json:"created_at"`
+	DeletedAt time.Time `json:"-"`
+}
+
+// Matches reports whether the transaction passes all the conditions of the rule
+func (r Rule) Matches(t Transaction) bool {
+	if len(r.Conditions) == 0 {
+		return false
+	}
+	for _, condition := range r.Conditions {
+		if !condition.matches(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyRules gives the transaction the actions of the rules matching it, in order, and returns the rules that
+// changed it. Conditions are matched against the transaction as it was before any rule changed it. Without
+// overwrite only the category, merchant and notes the transaction does not have yet are set, and the category
+// of a split transaction is never changed as it is given by its splits
+func ApplyRules(rules []Rule, t *Transaction, overwrite bool) []RuleID {
+	original := *t
+	var applied []RuleID
+	var category, merchant, notes bool
+	for _, rule := range rules {
+		if !rule.Matches(original) {
+			continue
+		}
+		changed := false
+		if rule.CategoryID != "" && !category {
+			category = true
+			if len(t.Splits) == 0 && (overwrite || t.CategoryID == "") && t.CategoryID != rule.CategoryID {
+				t.CategoryID, changed = rule.CategoryID, true
+			}
+		}
+		if rule.MerchantID != "" && !merchant {
+			merchant = true
+			if (overwrite || t.MerchantID == "") && t.MerchantID != rule.MerchantID {
+				t.MerchantID, changed = rule.MerchantID, true
+			}
+		}
+		if rule.Notes != "" && !notes {
+			notes = true
+			if (overwrite || t.Notes == "") && t.Notes != rule.Notes {
+				t.Notes, changed = rule.Notes, true
+			}
+		}
+		if changed {
+			applied = append(applied, rule.ID)
+		}
+	}
+	return applied
+}
+
+// RuleChange is what applying rules does to an existing transaction
+type RuleChange struct {
+	TransactionID TransactionID `json:"transaction_id"`
+	Name          string        `json:"name"`
+	Date          time.Time     `json:"date"`
+	Rules         []RuleID      `json:"rules"`
+	// RuleActions are the category, merchant and notes the transaction ends up with
+	RuleActions
+	Previous RuleActions `json:"previous"`
+}
+
+// RuleChanges returns the changes applying the rules makes to the transactions, transactions the rules leave
+// as they are are left out
+func RuleChanges(rules []Rule, transactions []Transaction, overwrite bool) []RuleChange {
+	changes := []RuleChange{}
+	for _, transaction := range transactions {
+		previous := RuleActions{
+			CategoryID: transaction.CategoryID,
+			MerchantID: transaction.MerchantID,
+			Notes:      transaction.Notes,
+		}
+		applied := ApplyRules(rules, &transaction, overwrite)
+		if len(applied) == 0 {
+			continue
+		}
+		changes = append(changes, RuleChange{
+			TransactionID: transaction.ID,
+			Name:          transaction.Name,
+			Date:          transaction.Date,
+			Rules:         applied,
+			RuleActions: RuleActions{
+				CategoryID: transaction.CategoryID,
+				MerchantID: transaction.MerchantID,
+				Notes:      transaction.Notes,
+			},
+			Previous: previous,
+		})
+	}
+	return changes
+}
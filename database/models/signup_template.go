@@ -0,0 +1,110 @@
+package models
+
+import (
+	"FiberFinanceAPI/utils"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is the locale of the template used when there is none for the locale of a new user
+const DefaultLocale = "en"
+
+// maxTemplateDepth keeps template category trees as shallow as users build them by hand
+const maxTemplateDepth = 3
+
+// ErrInvalidTemplate is returned when the categories of a signup template cannot be created for a user
+var ErrInvalidTemplate = errors.New("invalid signup template")
+
+// SignupTemplateID is our identifier for our signup templates
+type SignupTemplateID string
+
+// TemplateCategory is a category a signup template creates, with the subcategories created under it
+type TemplateCategory struct {
+	Name       string             `json:"name"`
+	Categories TemplateCategories `json:"categories,omitempty"`
+}
+
+// TemplateCategories is the category tree of a signup template
+type TemplateCategories []TemplateCategory
+
+// Validate checks every category has a name that is unique among its siblings, as they are for a user
+func (c TemplateCategories) Validate() error {
+	return c.validate(1)
+}
+
+func (c TemplateCategories) validate(depth int) error {
+	if len(c) > 0 && depth > maxTemplateDepth {
+		return fmt.Errorf("%w: categories cannot be nested more than %d deep", ErrInvalidTemplate, maxTemplateDepth)
+	}
+	names := map[string]bool{}
+	for _, category := range c {
+		name := strings.TrimSpace(category.Name)
+		if name == "" {
+			return fmt.Errorf("%w: category name is empty", ErrInvalidTemplate)
+		}
+		if names[name] {
+			return fmt.Errorf("%w: category %q is listed twice under the same parent", ErrInvalidTemplate, name)
+		}
+		names[name] = true
+		if err := category.Categories.validate(depth + 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, categories are saved as JSON
+func (c TemplateCategories) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan implements sql.Scanner
+func (c *TemplateCategories) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	}
+	return fmt.Errorf("cannot scan %T into TemplateCategories", src)
+}
+
+// SignupTemplate is what a user signing up with Locale starts with, a category tree and an account in the
+// base currency of the user. Currency is the base currency of users who do not give one, empty when the locale
+// has no currency of its own
+type SignupTemplate struct {
+	ID          SignupTemplateID   `json:"id"`
+	Locale      string             `json:"locale"`
+	Categories  TemplateCategories `json:"categories"`
+	AccountName string             `json:"account_name"`
+	AccountType AccountType        `json:"account_type"`
+	Currency    utils.CurrencyCode `json:"currency"`
+	CreatedAt   time.Time          `json:"created_at"`
+	DeletedAt   time.Time          `json:"-"`
+}
+
+// LocaleFallbacks returns the locales whose template is used for a user signing up with locale, most specific
+// first and lower cased, e.g. en-ke, en for en_KE and sw-ke, sw, en for sw-KE
+func LocaleFallbacks(locale string) []string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	var locales []string
+	for locale != "" {
+		locales = append(locales, locale)
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	if len(locales) == 0 || locales[len(locales)-1] != DefaultLocale {
+		locales = append(locales, DefaultLocale)
+	}
+	return locales
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SplitID is our identifier for our transaction splits
+type SplitID string
+
+// TransactionSplit is the part of a Transaction that belongs to one category
+type TransactionSplit struct {
+	ID            SplitID       `json:"id"`
+	TransactionID TransactionID `json:"transaction_id"`
+	CategoryID    CategoryID    `json:"category_id"`
+	Amount        int64         `json:"amount"`
+	Notes         string        `json:"notes"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
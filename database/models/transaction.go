@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"FiberFinanceAPI/utils"
+	"encoding/json"
+	"time"
+)
 
 // TransactionID is our identifier for our transactions
 type TransactionID string
@@ -11,18 +15,83 @@ type TransactionType string
 const (
 	Income  TransactionType = "income"
 	Expense TransactionType = "expense"
+	// TransferOut and TransferIn are the two legs of a Transfer, they are not income or expense
+	TransferOut TransactionType = "transfer_out"
+	TransferIn  TransactionType = "transfer_in"
+)
+
+// SignedAmount returns the effect an amount of this type has on an account balance
+func (t TransactionType) SignedAmount(amount int64) int64 {
+	switch t {
+	case Expense, TransferOut:
+		return -amount
+	}
+	return amount
+}
+
+// TransactionStatus is how far a transaction is through being confirmed against the account statement
+type TransactionStatus string
+
+const (
+	// Pending transactions are recorded but not yet seen on the account statement
+	Pending TransactionStatus = "pending"
+	// Cleared transactions are seen on the account statement
+	Cleared TransactionStatus = "cleared"
+	// Reconciled transactions are in a statement balance the account was reconciled with, they cannot be
+	// changed or deleted until they are unlocked
+	Reconciled TransactionStatus = "reconciled"
 )
 
 type Transaction struct {
-	ID              TransactionID   `json:"id"`
-	UserID          UserID          `json:"user_id"`
-	AccountID       AccountID       `json:"account_id"`
-	CategoryID      CategoryID      `json:"category_id"`
-	Name            string          `json:"name"`
-	TransactionType TransactionType `json:"transaction_type"`
-	Amount          int64           `json:"amount"`
-	Notes           string          `json:"notes"`
-	Date            time.Time       `json:"date"`
-	CreatedAt       time.Time       `json:"created_at"`
-	DeletedAt       time.Time       `json:"-"`
+	ID              TransactionID      `json:"id"`
+	UserID          UserID             `json:"user_id"`
+	AccountID       AccountID          `json:"account_id"`
+	CategoryID      CategoryID         `json:"category_id"`
+	Name            string             `json:"name"`
+	TransactionType TransactionType    `json:"transaction_type"`
+	Amount          int64              `json:"amount"`
+	Notes           string             `json:"notes"`
+	Date            time.Time          `json:"date"`
+	CreatedAt       time.Time          `json:"created_at"`
+	DeletedAt       time.Time          `json:"-"`
+	TransferID      TransferID         `json:"transfer_id,omitempty"`
+	MerchantID      MerchantID         `json:"merchant_id,omitempty"`
+	Splits          []TransactionSplit `json:"splits,omitempty"`
+	// PossibleDuplicates are the transactions a transaction just created is likely a duplicate of
+	PossibleDuplicates []TransactionID `json:"possible_duplicates,omitempty"`
+	// Currency is the currency of the account the transaction was made on
+	Currency utils.CurrencyCode `json:"currency"`
+	Status   TransactionStatus  `json:"status"`
 }
+
+// MarshalJSON writes the amount as Money so it is read in the minor units of the account currency
+func (t Transaction) MarshalJSON() ([]byte, error) {
+	type transaction Transaction
+	return json.Marshal(struct {
+		transaction
+		Amount utils.Money `json:"amount"`
+	}{transaction(t), utils.NewMoney(t.Amount, t.Currency)})
+}
+
+// UnmarshalJSON reads a transaction written by MarshalJSON or with a bare amount in minor units
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction
+	aux := struct {
+		*transaction
+		Amount utils.Money `json:"amount"`
+	}{transaction: (*transaction)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.Amount = aux.Amount.Amount
+	return nil
+}
+
+// TransactionSort is what transaction searches order their results by, the transaction ID breaks ties so pages
+// of a search do not overlap
+type TransactionSort string
+
+const (
+	SortByDate   TransactionSort = "date"
+	SortByAmount TransactionSort = "amount"
+)
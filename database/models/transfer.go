@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransferID is our identifier for our transfers
+type TransferID string
+
+// Scan implements sql.Scanner, transactions that are not part of a transfer have a NULL transfer_id
+func (t *TransferID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = ""
+	case string:
+		*t = TransferID(v)
+	case []byte:
+		*t = TransferID(v)
+	default:
+		return fmt.Errorf("cannot scan %T into TransferID", src)
+	}
+	return nil
+}
+
+// Transfer moves an amount from one account to another, it is recorded as a transfer_out transaction on
+// FromAccountID and a transfer_in transaction on ToAccountID
+type Transfer struct {
+	ID            TransferID    `json:"id"`
+	UserID        UserID        `json:"user_id"`
+	FromAccountID AccountID     `json:"from_account_id"`
+	ToAccountID   AccountID     `json:"to_account_id"`
+	Amount        int64         `json:"amount"`
+	Notes         string        `json:"notes"`
+	Date          time.Time     `json:"date"`
+	CreatedAt     time.Time     `json:"created_at"`
+	DeletedAt     time.Time     `json:"-"`
+	Transactions  []Transaction `json:"transactions,omitempty"`
+}
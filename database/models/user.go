@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"FiberFinanceAPI/utils"
+	"time"
+)
 
 // UserID is identifier for our User
 type UserID string
@@ -13,4 +16,6 @@ type User struct {
 	PasswordChangedAt time.Time `json:"-"`
 	CreatedAt         time.Time `json:"created_at" db:"created_at"`
 	DeletedAt         time.Time `json:"-"`
+	// BaseCurrency is the currency totals across accounts in different currencies are converted to
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
 }
@@ -15,12 +15,28 @@ WHERE account_id = $1
 AND deleted_at = '0001-01-01 00:00:00Z'
 LIMIT 1;
 
+--name: GetAccountForUpdate :one
+SELECT * FROM accounts
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE;
+
+--name: AddAccountBalance :one
+UPDATE accounts SET balance = balance + $2
+WHERE account_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
 --name: ListAccounts :many
 SELECT * FROM accounts
 WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY account_id
-LIMIT $2
-OFFSET $3
+AND ($4::text = '' OR (created_at, account_id) > ($3::timestamptz, NULLIF($4::text, '')::uuid))
+ORDER BY created_at, account_id
+LIMIT $2;
+
+--name: CountAccounts :one
+SELECT COUNT(*) FROM accounts
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z';
 
 --name: DeleteAccount :one
 UPDATE accounts SET deleted_at = now()
@@ -0,0 +1,27 @@
+--name: RestoreAccount :one
+INSERT INTO accounts(user_id, account_name, account_type, balance, currency)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ON CONSTRAINT owner_accounts_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *, (xmax = 0) AS created;
+
+--name: RestoreCategory :one
+INSERT INTO categories(parent_id, user_id, name)
+VALUES ($1, $2, $3)
+ON CONFLICT ON CONSTRAINT user_category_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *, (xmax = 0) AS created;
+
+--name: RestoreMerchant :one
+INSERT INTO merchant(user_id, name)
+VALUES($1, $2)
+ON CONFLICT ON CONSTRAINT user_merchant_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *, (xmax = 0) AS created;
+
+--name: RestoreBudget :execrows
+INSERT INTO budgets (user_id, category_id, month, amount, rollover)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (category_id, month) WHERE deleted_at = '0001-01-01 00:00:00Z' DO NOTHING;
+
+--name: RestoreImportMapping :execrows
+INSERT INTO import_mappings (user_id, name, mapping)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, name) WHERE deleted_at = '0001-01-01 00:00:00Z' DO NOTHING;
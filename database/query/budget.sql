@@ -0,0 +1,51 @@
+--name: CreateBudget :one
+INSERT INTO budgets (user_id, category_id, month, amount, rollover)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+--name: UpdateBudget :one
+UPDATE budgets SET amount = $3,
+rollover = $4
+WHERE budget_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: ListBudgetHistory :many
+WITH RECURSIVE tree AS (
+    SELECT c.category_id AS budget_category_id, c.category_id
+    FROM categories c
+    WHERE c.category_id IN (SELECT category_id FROM budgets WHERE user_id = $1)
+    UNION
+    SELECT tree.budget_category_id, c.category_id
+    FROM categories c
+    JOIN tree ON c.parent_id = tree.category_id::text
+    WHERE c.deleted_at = '0001-01-01 00:00:00Z'
+)
+SELECT b.budget_id, b.category_id, c.name, b.month, b.rollover, b.amount,
+       COALESCE((SELECT SUM(CASE WHEN d.currency = $3 THEN d.amount
+                                 ELSE convert_amount(d.amount, d.currency, $3, d.day) END)
+                 FROM (SELECT t.currency, a.date::date AS day, SUM(a.amount)::bigint AS amount
+                       FROM transaction_category_amounts a
+                       JOIN transactions t ON t.transaction_id = a.transaction_id
+                       JOIN tree ON tree.category_id = a.category_id
+                       WHERE tree.budget_category_id = b.category_id
+                         AND a.user_id = b.user_id
+                         AND a.transaction_type = 'expense'
+                         AND a.deleted_at = '0001-01-01 00:00:00Z'
+                         AND a.date >= b.month
+                         AND a.date < b.month + INTERVAL '1 month'
+                       GROUP BY t.currency, day) d), 0)::bigint AS spent
+FROM budgets b
+JOIN categories c ON c.category_id = b.category_id
+WHERE b.user_id = $1
+AND b.deleted_at = '0001-01-01 00:00:00Z'
+AND b.month <= $2
+ORDER BY b.category_id, b.month;
+
+--name: DeleteBudget :one
+UPDATE budgets SET deleted_at = now()
+WHERE budget_id = $1
+  AND user_id = $2
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at;
@@ -23,8 +23,7 @@ VALUES ($1, $2, $3)
 RETURNING *;
 
 --name: UpdateCategory :one
-UPDATE categories SET name = $3
-AND parent_id = $2
+UPDATE categories SET name = $2
 WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
 RETURNING *;
 
@@ -36,12 +35,118 @@ LIMIT 1;
 --name: ListCategories :many
 SELECT * FROM categories
 WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY category_id
-LIMIT $2
-OFFSET $3;
+AND ($4::text = '' OR (created_at, category_id) > ($3::timestamptz, NULLIF($4::text, '')::uuid))
+ORDER BY created_at, category_id
+LIMIT $2;
+
+--name: CountCategories :one
+SELECT COUNT(*) FROM categories
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z';
 
 --name: DeleteCategory :one
 UPDATE categories SET deleted_at = now()
 WHERE category_id = $1
 AND deleted_at = '0001-01-01 00:00:00Z'
-RETURNING deleted_at;
\ No newline at end of file
+RETURNING deleted_at;
+--name: MoveCategory :one
+UPDATE categories SET parent_id = $2
+WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: ListAllCategories :many
+SELECT * FROM categories
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY name, category_id;
+
+--name: LockUserCategories :exec
+SELECT category_id FROM categories
+WHERE user_id = $1
+FOR UPDATE;
+
+--name: ReparentCategories :execrows
+UPDATE categories SET parent_id = $2
+WHERE parent_id = $1::text
+AND deleted_at = '0001-01-01 00:00:00Z';
+
+--name: ReassignCategory :one
+WITH moved_transactions AS (
+    UPDATE transactions SET category_id = $2
+    WHERE category_id = $1 AND ($3 OR status <> 'reconciled')
+    RETURNING deleted_at
+), moved_splits AS (
+    UPDATE transaction_splits s SET category_id = $2
+    FROM transactions t
+    WHERE s.category_id = $1 AND t.transaction_id = s.transaction_id AND ($3 OR t.status <> 'reconciled')
+    RETURNING s.split_id
+), moved_postings AS (
+    SELECT p.transaction_id, p.user_id, p.ledger_account, p.account_id, SUM(p.amount) AS amount
+    FROM postings p
+    JOIN transactions t ON t.transaction_id = p.transaction_id
+    WHERE p.category_id = $1 AND ($3 OR t.status <> 'reconciled')
+    GROUP BY p.transaction_id, p.user_id, p.ledger_account, p.account_id
+    HAVING SUM(p.amount) <> 0
+), reposted AS (
+    INSERT INTO postings (transaction_id, user_id, ledger_account, account_id, category_id, amount)
+    SELECT transaction_id, user_id, ledger_account, account_id, $1::uuid, -amount FROM moved_postings
+    UNION ALL
+    SELECT transaction_id, user_id, ledger_account, account_id, $2::uuid, amount FROM moved_postings
+), moved_recurring AS (
+    UPDATE recurring_transactions SET category_id = $2 WHERE category_id = $1 RETURNING recurring_id
+)
+SELECT (SELECT COUNT(*) FROM moved_transactions WHERE deleted_at = '0001-01-01 00:00:00Z'),
+(SELECT COUNT(*) FROM moved_splits), (SELECT COUNT(*) FROM moved_recurring),
+(SELECT COUNT(*) FROM transactions t
+ WHERE t.status = 'reconciled' AND NOT $3
+ AND t.deleted_at = '0001-01-01 00:00:00Z'
+ AND (t.category_id = $1 OR EXISTS (
+     SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.transaction_id AND s.category_id = $1
+ )));
+
+--name: MergeCategoryBudgets :execrows
+WITH moved AS (
+    UPDATE budgets b SET category_id = $2
+    WHERE b.category_id = $1
+    AND b.deleted_at = '0001-01-01 00:00:00Z'
+    AND NOT EXISTS (
+        SELECT 1 FROM budgets t
+        WHERE t.category_id = $2 AND t.month = b.month AND t.deleted_at = '0001-01-01 00:00:00Z'
+    )
+    RETURNING b.budget_id
+), added AS (
+    UPDATE budgets t SET amount = t.amount + b.amount
+    FROM budgets b
+    WHERE b.category_id = $1
+    AND b.deleted_at = '0001-01-01 00:00:00Z'
+    AND t.category_id = $2
+    AND t.month = b.month
+    AND t.deleted_at = '0001-01-01 00:00:00Z'
+    RETURNING b.budget_id
+), merged AS (
+    UPDATE budgets SET deleted_at = now()
+    WHERE budget_id IN (SELECT budget_id FROM added)
+    RETURNING budget_id
+)
+SELECT budget_id FROM moved
+UNION ALL
+SELECT budget_id FROM merged;
+
+--name: CountCategoryUsage :one
+SELECT (SELECT COUNT(*) FROM transactions WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z')
++ (SELECT COUNT(*) FROM transaction_splits s
+   JOIN transactions t ON t.transaction_id = s.transaction_id
+   WHERE s.category_id = $1 AND t.deleted_at = '0001-01-01 00:00:00Z')
++ (SELECT COUNT(*) FROM recurring_transactions WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z')
++ (SELECT COUNT(*) FROM budgets WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z');
+
+--name: ListCategorizedTransactions :many
+SELECT t.name, COALESCE(m.name, ''), t.category_id
+FROM transactions t
+JOIN categories c ON c.category_id = t.category_id
+LEFT JOIN merchant m ON m.merchant_id = t.merchant_id
+WHERE t.user_id = $1
+AND t.transfer_id IS NULL
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND c.deleted_at = '0001-01-01 00:00:00Z'
+AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.transaction_id)
+ORDER BY t.date DESC
+LIMIT $2;
@@ -0,0 +1,44 @@
+--name: ListDuplicateCandidates :many
+SELECT * FROM transactions
+WHERE account_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND transfer_id IS NULL
+  AND date >= $2
+  AND date <= $3
+ORDER BY date, transaction_id;
+
+--name: ListDuplicatePairs :many
+SELECT a.*, b.*
+FROM transactions a
+JOIN transactions b ON b.account_id = a.account_id
+    AND b.transaction_type = a.transaction_type
+    AND b.amount = a.amount
+    AND (b.created_at, b.transaction_id) > (a.created_at, a.transaction_id)
+    AND b.date BETWEEN a.date - make_interval(secs => $4) AND a.date + make_interval(secs => $4)
+    AND b.deleted_at = '0001-01-01 00:00:00Z'
+    AND b.transfer_id IS NULL
+WHERE a.user_id = $1
+  AND a.deleted_at = '0001-01-01 00:00:00Z'
+  AND a.transfer_id IS NULL
+  AND ($2::timestamptz = '0001-01-01 00:00:00Z' OR b.date >= $2)
+  AND ($3::timestamptz = '0001-01-01 00:00:00Z' OR b.date < $3)
+  AND NOT EXISTS (SELECT 1 FROM dismissed_duplicates d
+                  WHERE d.transaction_id = LEAST(a.transaction_id, b.transaction_id)
+                    AND d.duplicate_id = GREATEST(a.transaction_id, b.transaction_id))
+ORDER BY b.date DESC, b.transaction_id;
+
+--name: DismissDuplicate :exec
+INSERT INTO dismissed_duplicates (user_id, transaction_id, duplicate_id)
+VALUES ($1, LEAST($2::uuid, $3::uuid), GREATEST($2::uuid, $3::uuid))
+ON CONFLICT DO NOTHING;
+
+--name: MoveImportedTransactions :exec
+UPDATE imported_transactions SET transaction_id = $2
+WHERE transaction_id = $1;
+
+--name: FillTransactionDetails :one
+UPDATE transactions SET notes = CASE WHEN notes = '' THEN $2 ELSE notes END,
+                        merchant_id = COALESCE(merchant_id, NULLIF($3, '')::uuid)
+WHERE transaction_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
@@ -0,0 +1,15 @@
+--name: UpsertExchangeRates :execrows
+INSERT INTO exchange_rates (base, quote, date, rate)
+SELECT * FROM unnest($1::varchar[], $2::varchar[], $3::date[], $4::numeric[])
+ON CONFLICT (base, quote, date) DO UPDATE SET rate = EXCLUDED.rate;
+
+--name: ConvertAmount :one
+SELECT exchange_rate($2, $3, $4), convert_amount($1, $2, $3, $4);
+
+--name: ListConvertedBalances :many
+SELECT account_id, account_name, account_type, balance, currency,
+exchange_rate(currency, $2, $3), convert_amount(balance, currency, $2, $3)
+FROM accounts
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY account_name, account_id;
@@ -0,0 +1,45 @@
+--name: ExportAccounts :many
+SELECT * FROM accounts
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, account_id;
+
+--name: ExportCategories :many
+SELECT * FROM categories
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, category_id;
+
+--name: ExportMerchants :many
+SELECT * FROM merchant
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, merchant_id;
+
+--name: ExportTransactions :many
+SELECT t.* FROM transactions t
+JOIN accounts a ON a.account_id = t.account_id
+WHERE t.user_id = $1
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+AND (t.date, t.transaction_id) > ($2, $3::uuid)
+ORDER BY t.date, t.transaction_id
+LIMIT $4;
+
+--name: ExportTransfers :many
+SELECT * FROM transfers
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY date, transfer_id;
+
+--name: ExportBudgets :many
+SELECT * FROM budgets
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY month, budget_id;
+
+--name: ExportRecurring :many
+SELECT * FROM recurring_transactions
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, recurring_id;
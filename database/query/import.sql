@@ -0,0 +1,42 @@
+--name: CreateImportMapping :one
+INSERT INTO import_mappings (user_id, name, mapping)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+--name: GetImportMappingByID :one
+SELECT * FROM import_mappings
+WHERE mapping_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1;
+
+--name: ListImportMappings :many
+SELECT * FROM import_mappings
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY name;
+
+--name: DeleteImportMapping :one
+UPDATE import_mappings SET deleted_at = now()
+WHERE mapping_id = $1
+  AND user_id = $2
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at;
+
+--name: CreateImportedTransaction :exec
+INSERT INTO imported_transactions (account_id, external_id, transaction_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_id, external_id) DO UPDATE SET transaction_id = EXCLUDED.transaction_id;
+
+--name: ListImportedExternalIDs :many
+SELECT i.external_id FROM imported_transactions i
+JOIN transactions t ON t.transaction_id = i.transaction_id
+WHERE i.account_id = $1
+AND i.external_id = ANY($2::varchar[])
+AND t.deleted_at = '0001-01-01 00:00:00Z';
+
+--name: GetAccountAmountAfter :one
+SELECT COALESCE(SUM(CASE WHEN transaction_type IN ('expense', 'transfer_out') THEN -amount ELSE amount END), 0)::bigint
+FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $2;
@@ -15,12 +15,46 @@ LIMIT 1;
 --name: ListMerchants :one
 SELECT * FROM merchant
 WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY merchant_id
-LIMIT $2
-OFFSET $3;
+AND ($4::text = '' OR (created_at, merchant_id) > ($3::timestamptz, NULLIF($4::text, '')::uuid))
+ORDER BY created_at, merchant_id
+LIMIT $2;
+
+--name: CountMerchants :one
+SELECT COUNT(*) FROM merchant
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z';
 
 --name: DeleteMerchant :one
 UPDATE merchant SET deleted_at = now()
 WHERE merchant_id = $1
 AND deleted_at = '0001-01-01 00:00:00Z'
-RETURNING deleted_at;
\ No newline at end of file
+RETURNING deleted_at;
+
+--name: ListMerchantSpend :many
+WITH daily AS (
+    SELECT t.merchant_id, t.currency, t.date::date AS day, COUNT(*) AS transaction_count,
+    COALESCE(SUM(t.amount) FILTER (WHERE t.transaction_type = 'expense'), 0)::BIGINT AS spent,
+    COALESCE(SUM(t.amount) FILTER (WHERE t.transaction_type = 'income'), 0)::BIGINT AS refunded
+    FROM transactions t
+    JOIN merchant m ON m.merchant_id = t.merchant_id
+    WHERE m.user_id = $1
+    AND t.deleted_at = '0001-01-01 00:00:00Z'
+    AND t.date > $2
+    AND t.date < $3
+    GROUP BY t.merchant_id, t.currency, day
+), converted AS (
+    SELECT d.merchant_id, d.transaction_count, convert_amount(d.spent, d.currency, $4, d.day) AS spent,
+    convert_amount(d.refunded, d.currency, $4, d.day) AS refunded
+    FROM daily d
+)
+SELECT m.merchant_id, m.name, SUM(c.transaction_count)::BIGINT, COALESCE(SUM(c.spent), 0)::BIGINT AS spent,
+COALESCE(SUM(c.refunded), 0)::BIGINT, COUNT(*) FILTER (WHERE c.spent IS NULL)
+FROM converted c
+JOIN merchant m ON m.merchant_id = c.merchant_id
+GROUP BY m.merchant_id
+ORDER BY spent DESC, m.name;
+
+--name: GetOrCreateMerchant :one
+INSERT INTO merchant(user_id, name)
+VALUES($1, $2)
+ON CONFLICT ON CONSTRAINT user_merchant_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
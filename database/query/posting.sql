@@ -0,0 +1,46 @@
+--name: CreatePosting :one
+INSERT INTO postings (transaction_id, user_id, ledger_account, account_id, category_id, amount)
+VALUES ($1, $2, $3, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, $6)
+RETURNING *;
+
+--name: ListPostingsByTransactionID :many
+SELECT * FROM postings
+WHERE transaction_id = $1
+ORDER BY created_at, amount DESC;
+
+--name: ReversePostingsByTransactionID :exec
+INSERT INTO postings (transaction_id, user_id, ledger_account, account_id, category_id, amount)
+SELECT transaction_id, user_id, ledger_account, account_id, category_id, -SUM(amount)
+FROM postings
+WHERE transaction_id = $1
+GROUP BY transaction_id, user_id, ledger_account, account_id, category_id
+HAVING SUM(amount) <> 0;
+
+--name: TrialBalance :many
+WITH daily AS (
+    SELECT p.ledger_account, p.account_id, p.category_id, t.currency, t.date::date AS day, SUM(p.amount)::BIGINT AS total
+    FROM postings p
+    JOIN transactions t ON t.transaction_id = p.transaction_id
+    WHERE p.user_id = $1
+    GROUP BY p.ledger_account, p.account_id, p.category_id, t.currency, day
+), converted AS (
+    SELECT d.*, convert_amount(d.total, d.currency, $2, d.day) AS converted_total FROM daily d
+)
+SELECT d.ledger_account, COALESCE(d.account_id::text, ''), d.category_id, COALESCE(a.account_name, c.name, '') AS name,
+d.currency, SUM(d.total)::BIGINT AS total, COALESCE(SUM(d.converted_total), 0)::BIGINT AS converted_total,
+COUNT(*) FILTER (WHERE d.converted_total IS NULL) AS missing_rates
+FROM converted d
+LEFT JOIN accounts a ON a.account_id = d.account_id
+LEFT JOIN categories c ON c.category_id = d.category_id
+GROUP BY d.ledger_account, d.account_id, d.category_id, d.currency, a.account_name, c.name
+ORDER BY d.ledger_account, name, d.currency;
+
+--name: ListAccountImbalances :many
+SELECT a.account_id, a.account_name, a.balance, COALESCE(SUM(p.amount), 0) AS posted_balance
+FROM accounts a
+LEFT JOIN postings p ON p.account_id = a.account_id
+WHERE a.user_id = $1
+  AND a.deleted_at = '0001-01-01 00:00:00Z'
+GROUP BY a.account_id
+HAVING a.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY a.account_name;
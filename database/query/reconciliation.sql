@@ -0,0 +1,74 @@
+--name: CreateReconciliation :one
+INSERT INTO reconciliations (user_id, account_id, statement_date, statement_balance, currency)
+SELECT $1, account_id, $3, $4, currency FROM accounts
+WHERE account_id = $2
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: GetReconciliationByID :one
+SELECT * FROM reconciliations
+WHERE reconciliation_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1;
+
+--name: GetReconciliationForUpdate :one
+SELECT * FROM reconciliations
+WHERE reconciliation_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE;
+
+--name: ListReconciliations :many
+SELECT * FROM reconciliations
+WHERE account_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND ($4::text = '' OR (statement_date, reconciliation_id) < ($3::date, NULLIF($4::text, '')::uuid))
+ORDER BY statement_date DESC, reconciliation_id DESC
+LIMIT $2;
+
+--name: CountReconciliations :one
+SELECT COUNT(*) FROM reconciliations
+WHERE account_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z';
+
+--name: CompleteReconciliation :one
+UPDATE reconciliations SET status = 'completed',
+opening_balance = $2,
+transaction_count = $3,
+completed_at = now()
+WHERE reconciliation_id = $1
+  AND status = 'open'
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: DeleteReconciliation :one
+UPDATE reconciliations SET deleted_at = now()
+WHERE reconciliation_id = $1
+  AND status = 'open'
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at;
+
+--name: AddReconciliationTransaction :exec
+INSERT INTO reconciliation_transactions (reconciliation_id, transaction_id)
+VALUES ($1, $2);
+
+--name: ListUnreconciledTransactions :many
+SELECT * FROM transactions
+WHERE account_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND status <> 'reconciled'
+  AND date::date <= $2::date
+ORDER BY date, transaction_id;
+
+--name: ListReconciliationTransactions :many
+SELECT t.* FROM transactions t
+JOIN reconciliation_transactions rt ON rt.transaction_id = t.transaction_id
+WHERE rt.reconciliation_id = $1
+ORDER BY t.date, t.transaction_id;
+
+--name: GetUnreconciledAmount :one
+SELECT COALESCE(SUM(CASE WHEN transaction_type IN ('expense', 'transfer_out') THEN -amount ELSE amount END), 0)::bigint
+FROM transactions
+WHERE account_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND status <> 'reconciled';
@@ -0,0 +1,67 @@
+--name: CreateRecurring :one
+INSERT INTO recurring_transactions (user_id, account_id, category_id, merchant_id, name, transaction_type, amount,
+notes, rule, start_date, end_date, skip_dates, next_run)
+VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11, COALESCE($12::date[], '{}'), $13)
+RETURNING *;
+
+--name: UpdateRecurring :one
+UPDATE recurring_transactions SET account_id = $3,
+category_id = $4,
+merchant_id = NULLIF($5, '')::uuid,
+name = $6,
+transaction_type = $7,
+amount = $8,
+notes = $9,
+rule = $10,
+start_date = $11,
+end_date = $12,
+skip_dates = COALESCE($13::date[], '{}'),
+next_run = $14
+WHERE recurring_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: GetRecurringByID :one
+SELECT * FROM recurring_transactions
+WHERE recurring_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1;
+
+--name: GetRecurringForUpdate :one
+SELECT * FROM recurring_transactions
+WHERE recurring_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE;
+
+--name: ListRecurring :many
+SELECT * FROM recurring_transactions
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, recurring_id
+LIMIT  $2
+OFFSET $3;
+
+--name: ListDueRecurring :many
+SELECT r.recurring_id FROM recurring_transactions r
+JOIN accounts a ON a.account_id = r.account_id
+JOIN categories c ON c.category_id = r.category_id
+WHERE r.deleted_at = '0001-01-01 00:00:00Z'
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+AND c.deleted_at = '0001-01-01 00:00:00Z'
+AND r.next_run > '0001-01-01 00:00:00Z'
+AND r.next_run <= $1
+ORDER BY r.next_run
+LIMIT $2;
+
+--name: SetRecurringNextRun :exec
+UPDATE recurring_transactions SET next_run = $2
+WHERE recurring_id = $1;
+
+--name: DeleteRecurring :one
+UPDATE recurring_transactions SET deleted_at = now()
+WHERE recurring_id = $1
+  AND user_id = $2
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at;
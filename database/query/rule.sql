@@ -0,0 +1,82 @@
+--name: CreateRule :one
+INSERT INTO rules (user_id, name, position, conditions, category_id, merchant_id, notes)
+VALUES ($1, $2, COALESCE((SELECT MAX(position) + 1 FROM rules WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'), 1),
+        $3, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, $6)
+RETURNING *;
+
+--name: UpdateRule :one
+UPDATE rules SET name = $2, conditions = $3, category_id = NULLIF($4, '')::uuid, merchant_id = NULLIF($5, '')::uuid, notes = $6
+WHERE rule_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: GetRuleByID :one
+SELECT * FROM rules
+WHERE rule_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1;
+
+--name: ListRules :many
+SELECT * FROM rules
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY position, created_at;
+
+--name: SetRulePosition :execrows
+UPDATE rules SET position = $3
+WHERE rule_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z';
+
+--name: DeleteRule :one
+UPDATE rules SET deleted_at = now()
+WHERE rule_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at;
+
+--name: ReassignRuleCategory :execrows
+UPDATE rules SET category_id = NULLIF($2, '')::uuid
+WHERE category_id = $1;
+
+--name: ReassignRuleMerchant :execrows
+UPDATE rules SET merchant_id = NULLIF($2, '')::uuid
+WHERE merchant_id = $1;
+
+--name: ListRuleTransactions :many
+SELECT t.transaction_id, t.user_id, t.account_id, t.category_id, t.name, t.transaction_type, t.amount, t.notes, t.date,
+       t.created_at, t.deleted_at, t.transfer_id, t.merchant_id, t.currency, t.status
+FROM transactions t
+JOIN accounts a ON a.account_id = t.account_id
+WHERE t.user_id = $1
+AND t.transfer_id IS NULL
+AND t.status <> 'reconciled'
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+AND t.date >= $2
+AND ($3::timestamptz = '0001-01-01 00:00:00Z' OR t.date < $3)
+ORDER BY t.date, t.transaction_id;
+
+--name: ApplyRuleChange :exec
+WITH changed AS (
+    UPDATE transactions SET category_id = COALESCE(NULLIF($2, '')::uuid, category_id),
+        merchant_id = COALESCE(NULLIF($3, '')::uuid, merchant_id), notes = $4
+    WHERE transaction_id = $1
+    AND status <> 'reconciled'
+    AND deleted_at = '0001-01-01 00:00:00Z'
+    RETURNING transaction_id, category_id
+), moved AS (
+    SELECT p.transaction_id, p.user_id, p.ledger_account, p.category_id, c.category_id AS new_category_id,
+    SUM(p.amount) AS amount
+    FROM postings p
+    JOIN changed c ON c.transaction_id = p.transaction_id
+    WHERE p.category_id IS NOT NULL
+    AND p.category_id <> c.category_id
+    AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = c.transaction_id)
+    GROUP BY p.transaction_id, p.user_id, p.ledger_account, p.category_id, c.category_id
+    HAVING SUM(p.amount) <> 0
+)
+INSERT INTO postings (transaction_id, user_id, ledger_account, category_id, amount)
+SELECT transaction_id, user_id, ledger_account, category_id, -amount FROM moved
+UNION ALL
+SELECT transaction_id, user_id, ledger_account, new_category_id, amount FROM moved;
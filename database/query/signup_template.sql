@@ -0,0 +1,34 @@
+--name: CreateSignupTemplate :one
+INSERT INTO signup_templates (locale, categories, account_name, account_type, currency)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+--name: UpdateSignupTemplate :one
+UPDATE signup_templates SET locale = $2, categories = $3, account_name = $4, account_type = $5, currency = $6
+WHERE template_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: GetSignupTemplateByID :one
+SELECT * FROM signup_templates
+WHERE template_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1;
+
+--name: GetSignupTemplateForLocale :one
+SELECT * FROM signup_templates
+WHERE lower(locale) = ANY($1::varchar[])
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY array_position($1::varchar[], lower(locale)::varchar)
+LIMIT 1;
+
+--name: ListSignupTemplates :many
+SELECT * FROM signup_templates
+WHERE deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY locale;
+
+--name: DeleteSignupTemplate :one
+UPDATE signup_templates SET deleted_at = now()
+WHERE template_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at;
@@ -0,0 +1,18 @@
+--name: CreateSplit :one
+INSERT INTO transaction_splits (transaction_id, category_id, amount, notes)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+--name: ListSplitsByTransactionID :many
+SELECT * FROM transaction_splits
+WHERE transaction_id = $1
+ORDER BY amount DESC, split_id;
+
+--name: ListSplitsByTransactionIDs :many
+SELECT * FROM transaction_splits
+WHERE transaction_id = ANY($1::uuid[])
+ORDER BY transaction_id, amount DESC, split_id;
+
+--name: DeleteSplitsByTransactionID :exec
+DELETE FROM transaction_splits
+WHERE transaction_id = $1;
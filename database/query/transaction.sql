@@ -1,17 +1,21 @@
 --name: CreateTransaction :one
-INSERT INTO transactions (user_id, account_id, category_id, name, transaction_type, amount, notes,date)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO transactions (user_id, account_id, category_id, name, transaction_type, amount, notes, date, transfer_id, merchant_id, status)
+VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid, NULLIF($10, '')::uuid, COALESCE(NULLIF($11, ''), 'pending'))
 RETURNING *;
 
 --name: UpdateTransaction :one
-UPDATE transactions SET account_id = $2,
-category_id = $3,
-name = $4,
-transaction_type = $5,
-amount = $6,
-notes = $7,
-date = $8
+UPDATE transactions SET account_id = $3,
+category_id = $4,
+name = $5,
+transaction_type = $6,
+amount = $7,
+notes = $8,
+date = $9,
+merchant_id = NULLIF($10, '')::uuid,
+status = CASE WHEN status = 'reconciled' THEN 'cleared' ELSE status END
 WHERE transaction_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
 RETURNING *;
 
 --name: GetTransactionByID :one
@@ -20,40 +24,191 @@ WHERE transaction_id = $1
 AND deleted_at = '0001-01-01 00:00:00Z'
 LIMIT 1;
 
+--name: GetTransactionForUpdate :one
+SELECT * FROM transactions
+WHERE transaction_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE;
+
 --name: ListTransactionsByUserID :many
 SELECT * FROM transactions
 WHERE user_id = $1
     AND deleted_at = '0001-01-01 00:00:00Z'
-    AND date > $4
-    AND date < $5
-ORDER BY transaction_id
-LIMIT  $2
-OFFSET $3;
+    AND date > $3
+    AND date < $4
+    AND ($6::text = '' OR (date, transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transaction_id DESC
+LIMIT  $2;
+
+--name: CountTransactionsByUserID :one
+SELECT COUNT(*) FROM transactions
+WHERE user_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND date > $2
+  AND date < $3;
 
 --name: ListTransactionsByAccountID :many
 SELECT * FROM transactions
 WHERE account_id = $1
   AND deleted_at = '0001-01-01 00:00:00Z'
-  AND date > $4
-  AND date < $5
-ORDER BY transaction_id
-LIMIT  $2
-OFFSET $3;
+  AND date > $3
+  AND date < $4
+  AND ($6::text = '' OR (date, transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transaction_id DESC
+LIMIT  $2;
 
---name: ListTransactionsByCategoryID :many
-SELECT * FROM transactions
-WHERE category_id = $1
+--name: CountTransactionsByAccountID :one
+SELECT COUNT(*) FROM transactions
+WHERE account_id = $1
   AND deleted_at = '0001-01-01 00:00:00Z'
-  AND date > $4
-  AND date < $5
-ORDER BY transaction_id
-LIMIT  $2
-OFFSET $3;
+  AND date > $2
+  AND date < $3;
+
+--name: ListTransactionsByCategoryID :many
+SELECT t.transaction_id, t.user_id, t.account_id, t.category_id, t.name, t.transaction_type, SUM(ca.amount) AS amount,
+       t.notes, t.date, t.created_at, t.deleted_at, t.transfer_id, t.merchant_id, t.currency, t.status
+FROM transactions t
+JOIN transaction_category_amounts ca ON ca.transaction_id = t.transaction_id
+WHERE ca.category_id = $1
+  AND t.deleted_at = '0001-01-01 00:00:00Z'
+  AND t.date > $3
+  AND t.date < $4
+  AND ($6::text = '' OR (t.date, t.transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+GROUP BY t.transaction_id
+ORDER BY t.date DESC, t.transaction_id DESC
+LIMIT  $2;
+
+--name: CountTransactionsByCategoryID :one
+SELECT COUNT(DISTINCT t.transaction_id) FROM transactions t
+JOIN transaction_category_amounts ca ON ca.transaction_id = t.transaction_id
+WHERE ca.category_id = $1
+  AND t.deleted_at = '0001-01-01 00:00:00Z'
+  AND t.date > $2
+  AND t.date < $3;
 
 
 --name: DeleteTransaction :one
 UPDATE transactions SET deleted_at = now()
 WHERE transaction_id = $1
   AND deleted_at = '0001-01-01 00:00:00Z'
-RETURNING deleted_at
+RETURNING deleted_at;
+
+--name: ListTransactionsByTransferID :many
+SELECT * FROM transactions
+WHERE transfer_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY transaction_type;
+
+--name: DeleteTransactionsByTransferID :many
+UPDATE transactions SET deleted_at = now()
+WHERE transfer_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: ListTransactionsByMerchantID :many
+SELECT * FROM transactions
+WHERE merchant_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND date > $3
+  AND date < $4
+  AND ($6::text = '' OR (date, transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transaction_id DESC
+LIMIT  $2;
+
+--name: CountMerchantTransactions :one
+SELECT COUNT(*) FROM transactions
+WHERE merchant_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND date > $2
+  AND date < $3;
+
+--name: CountTransactionsByMerchantID :one
+SELECT COUNT(*) FROM transactions
+WHERE merchant_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z';
 
+--name: ReassignMerchant :execrows
+UPDATE transactions SET merchant_id = $2
+WHERE merchant_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z';
+
+--name: SearchTransactions :many
+WITH RECURSIVE tree AS (
+    SELECT c.category_id FROM categories c
+    WHERE c.category_id = NULLIF($3::text, '')::uuid AND c.user_id = $1
+    UNION
+    SELECT c.category_id FROM categories c
+    JOIN tree ON c.parent_id = tree.category_id::text
+    WHERE c.deleted_at = '0001-01-01 00:00:00Z'
+)
+SELECT t.transaction_id, t.user_id, t.account_id, t.category_id, t.name, t.transaction_type, t.amount, t.notes,
+       t.date, t.created_at, t.deleted_at, t.transfer_id, t.merchant_id, t.currency, t.status
+FROM transactions t
+WHERE t.user_id = $1
+  AND t.deleted_at = '0001-01-01 00:00:00Z'
+  AND ($2::text = '' OR t.account_id = NULLIF($2::text, '')::uuid)
+  AND ($3::text = '' OR EXISTS (SELECT 1 FROM transaction_category_amounts ca
+                                JOIN tree ON tree.category_id = ca.category_id
+                                WHERE ca.transaction_id = t.transaction_id))
+  AND ($4::text = '' OR t.merchant_id = NULLIF($4::text, '')::uuid)
+  AND ($5::text = '' OR t.transaction_type::text = $5)
+  AND ($6::bigint IS NULL OR t.amount >= $6)
+  AND ($7::bigint IS NULL OR t.amount <= $7)
+  AND ($8::timestamptz = '0001-01-01 00:00:00Z' OR t.date >= $8)
+  AND ($9::timestamptz = '0001-01-01 00:00:00Z' OR t.date < $9)
+  AND ($10::text = '' OR to_tsvector('simple', t.name || ' ' || t.notes) @@ to_tsquery('simple', $10))
+  AND ($14::text = '' OR CASE
+      WHEN $11::text = 'amount' AND $12::boolean
+          THEN (t.amount, t.date, t.transaction_id) < ($16::bigint, $15::timestamptz, NULLIF($14::text, '')::uuid)
+      WHEN $11::text = 'amount'
+          THEN (t.amount, t.date, t.transaction_id) > ($16::bigint, $15::timestamptz, NULLIF($14::text, '')::uuid)
+      WHEN $12::boolean
+          THEN (t.date, t.transaction_id) < ($15::timestamptz, NULLIF($14::text, '')::uuid)
+      ELSE (t.date, t.transaction_id) > ($15::timestamptz, NULLIF($14::text, '')::uuid)
+  END)
+ORDER BY CASE WHEN $11::text = 'amount' AND NOT $12::boolean THEN t.amount END,
+         CASE WHEN $11::text = 'amount' AND $12::boolean THEN t.amount END DESC,
+         CASE WHEN NOT $12::boolean THEN t.date END,
+         CASE WHEN $12::boolean THEN t.date END DESC,
+         CASE WHEN NOT $12::boolean THEN t.transaction_id END,
+         CASE WHEN $12::boolean THEN t.transaction_id END DESC
+LIMIT $13;
+
+--name: CountSearchTransactions :one
+WITH RECURSIVE tree AS (
+    SELECT c.category_id FROM categories c
+    WHERE c.category_id = NULLIF($3::text, '')::uuid AND c.user_id = $1
+    UNION
+    SELECT c.category_id FROM categories c
+    JOIN tree ON c.parent_id = tree.category_id::text
+    WHERE c.deleted_at = '0001-01-01 00:00:00Z'
+)
+SELECT COUNT(*)
+FROM transactions t
+WHERE t.user_id = $1
+  AND t.deleted_at = '0001-01-01 00:00:00Z'
+  AND ($2::text = '' OR t.account_id = NULLIF($2::text, '')::uuid)
+  AND ($3::text = '' OR EXISTS (SELECT 1 FROM transaction_category_amounts ca
+                                JOIN tree ON tree.category_id = ca.category_id
+                                WHERE ca.transaction_id = t.transaction_id))
+  AND ($4::text = '' OR t.merchant_id = NULLIF($4::text, '')::uuid)
+  AND ($5::text = '' OR t.transaction_type::text = $5)
+  AND ($6::bigint IS NULL OR t.amount >= $6)
+  AND ($7::bigint IS NULL OR t.amount <= $7)
+  AND ($8::timestamptz = '0001-01-01 00:00:00Z' OR t.date >= $8)
+  AND ($9::timestamptz = '0001-01-01 00:00:00Z' OR t.date < $9)
+  AND ($10::text = '' OR to_tsvector('simple', t.name || ' ' || t.notes) @@ to_tsquery('simple', $10));
+
+--name: UpdateTransactionStatus :one
+UPDATE transactions SET status = $2
+WHERE transaction_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: GetPendingAmount :one
+SELECT COALESCE(SUM(CASE WHEN transaction_type IN ('expense', 'transfer_out') THEN -amount ELSE amount END), 0)::bigint
+FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND status = 'pending';
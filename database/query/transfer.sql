@@ -0,0 +1,51 @@
+--name: CreateTransfer :one
+INSERT INTO transfers (user_id, from_account_id, to_account_id, amount, notes, date)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+--name: UpdateTransfer :one
+UPDATE transfers SET from_account_id = $3,
+to_account_id = $4,
+amount = $5,
+notes = $6,
+date = $7
+WHERE transfer_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: GetTransferByID :one
+SELECT * FROM transfers
+WHERE transfer_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1;
+
+--name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE transfer_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE;
+
+--name: ListTransfers :many
+SELECT * FROM transfers
+WHERE user_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND date > $3
+  AND date < $4
+  AND ($6::text = '' OR (date, transfer_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transfer_id DESC
+LIMIT  $2;
+
+--name: CountTransfers :one
+SELECT COUNT(*) FROM transfers
+WHERE user_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+  AND date > $2
+  AND date < $3;
+
+--name: DeleteTransfer :one
+UPDATE transfers SET deleted_at = now()
+WHERE transfer_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at;
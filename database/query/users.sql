@@ -1,6 +1,6 @@
 --name: CreateUser :one
-INSERT INTO users (email, password_hash)
-VALUES ($1, $2)
+INSERT INTO users (email, password_hash, base_currency)
+VALUES ($1, $2, $3)
 RETURNING *;
 
 --name: GetUserByEmail :one
@@ -13,9 +13,13 @@ LIMIT 1;
 --name: ListUsers :many
 SELECT * FROM users
 WHERE deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY user_id
-LIMIT $1
-OFFSET $2;
+AND ($3::text = '' OR (created_at, user_id) > ($2::timestamptz, NULLIF($3::text, '')::uuid))
+ORDER BY created_at, user_id
+LIMIT $1;
+
+--name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at = '0001-01-01 00:00:00Z';
 
 --name: DeleteUser :one
 UPDATE users SET deleted_at = now(),
@@ -31,3 +35,15 @@ password_hash = $2
 WHERE user_id = $1
 AND deleted_at = '0001-01-01 00:00:00Z'
 RETURNING password_changed_at;
+
+--name: UpdateBaseCurrency :one
+UPDATE users SET base_currency = $2
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING *;
+
+--name: SetDefaultBaseCurrency :exec
+UPDATE users SET base_currency = $2
+WHERE user_id = $1
+AND base_currency = ''
+AND deleted_at = '0001-01-01 00:00:00Z';
@@ -93,24 +93,81 @@ func (q *Queries) GetAccountByID(ctx context.Context, id model.AccountID) (model
 	return account, err
 }
 
+const getAccountForUpdate = `--name: GetAccountForUpdate :one
+SELECT * FROM accounts
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE`
+
+// GetAccountForUpdate returns the account and locks its row until the surrounding database transaction ends
+func (q *Queries) GetAccountForUpdate(ctx context.Context, id model.AccountID) (model.Account, error) {
+	q.logs.WithField("func", "database/sqlc/accounts.go -> GetAccountForUpdate()").Debug()
+
+	row := q.db.QueryRowContext(ctx, getAccountForUpdate, id)
+	var account model.Account
+	err := row.Scan(
+		&account.AccountID,
+		&account.UserID,
+		&account.Name,
+		&account.Type,
+		&account.Balance,
+		&account.Currency,
+		&account.CreatedAt,
+		&account.DeletedAt,
+	)
+	return account, err
+}
+
+const addAccountBalance = `--name: AddAccountBalance :one
+UPDATE accounts SET balance = balance + $2
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING account_id, user_id, account_name, account_type, balance, currency, created_at, deleted_at`
+
+type AddAccountBalanceParams struct {
+	AccountID model.AccountID `json:"account_id"`
+	Amount    int64           `json:"amount"`
+}
+
+// AddAccountBalance adds amount to the account balance, a negative amount is subtracted
+func (q *Queries) AddAccountBalance(ctx context.Context, args AddAccountBalanceParams) (model.Account, error) {
+	q.logs.WithField("func", "database/sqlc/accounts.go -> AddAccountBalance()").Debug()
+	row := q.db.QueryRowContext(ctx, addAccountBalance, args.AccountID, args.Amount)
+	var account model.Account
+	err := row.Scan(
+		&account.AccountID,
+		&account.UserID,
+		&account.Name,
+		&account.Type,
+		&account.Balance,
+		&account.Currency,
+		&account.CreatedAt,
+		&account.DeletedAt,
+	)
+	return account, err
+}
+
 const listAccounts = `--name: ListAccounts :many
 SELECT * FROM accounts
 WHERE user_id = $1 
 AND deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY account_id
+AND ($4::text = '' OR (created_at, account_id) > ($3::timestamptz, NULLIF($4::text, '')::uuid))
+ORDER BY created_at, account_id
 LIMIT $2
-OFFSET $3
 `
 
+// ListAccountParams lists the accounts of a user in the order they were opened, Cursor is the last account of
+// the previous page
 type ListAccountParams struct {
 	UserID model.UserID `json:"user_id"`
 	Limit  int32        `json:"limit"`
-	Offset int32        `json:"offset"`
+	Cursor model.Cursor `json:"cursor"`
 }
 
 func (q *Queries) ListAccounts(ctx context.Context, args ListAccountParams) ([]model.Account, error) {
 	q.logs.WithField("func", "database/sqlc/accounts.go -> ListAccounts()").Debug()
-	rows, err := q.db.QueryContext(ctx, listAccounts, args.UserID, args.Limit, args.Offset)
+	rows, err := q.db.QueryContext(ctx, listAccounts, args.UserID, args.Limit, args.Cursor.Date, args.Cursor.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +196,20 @@ func (q *Queries) ListAccounts(ctx context.Context, args ListAccountParams) ([]m
 	return accounts, err
 }
 
+const countAccounts = `--name: CountAccounts :one
+SELECT COUNT(*) FROM accounts
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'`
+
+// CountAccounts counts the accounts ListAccounts pages through
+func (q *Queries) CountAccounts(ctx context.Context, userID model.UserID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/accounts.go -> CountAccounts()").Debug()
+	row := q.db.QueryRowContext(ctx, countAccounts, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteAccount = `--name: DeleteAccount :one
 UPDATE accounts SET deleted_at = now()
 WHERE account_id = $1 
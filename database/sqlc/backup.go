@@ -0,0 +1,126 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+)
+
+const restoreAccount = `--name: RestoreAccount :one
+INSERT INTO accounts(user_id, account_name, account_type, balance, currency)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ON CONSTRAINT owner_accounts_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING account_id, user_id, account_name, account_type, balance, currency, created_at, deleted_at, (xmax = 0) AS created`
+
+type RestoreAccountRow struct {
+	Account model.Account `json:"account"`
+	// Created is false when the user already had the account, a deleted one is restored
+	Created bool `json:"created"`
+}
+
+// RestoreAccount returns the account of the user with the name, type and currency, creating it when there is none
+func (q *Queries) RestoreAccount(ctx context.Context, args CreateAccountParams) (RestoreAccountRow, error) {
+	q.logs.WithField("func", "database/sqlc/backup.go -> RestoreAccount()").Debug()
+	row := q.db.QueryRowContext(ctx, restoreAccount, args.UserID, args.AccountName, args.AccountType, args.Balance, args.Currency)
+	var r RestoreAccountRow
+	err := row.Scan(
+		&r.Account.AccountID,
+		&r.Account.UserID,
+		&r.Account.Name,
+		&r.Account.Type,
+		&r.Account.Balance,
+		&r.Account.Currency,
+		&r.Account.CreatedAt,
+		&r.Account.DeletedAt,
+		&r.Created,
+	)
+	return r, err
+}
+
+const restoreCategory = `--name: RestoreCategory :one
+INSERT INTO categories(parent_id, user_id, name)
+VALUES ($1, $2, $3)
+ON CONFLICT ON CONSTRAINT user_category_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING category_id, parent_id, user_id, name, created_at, deleted_at, (xmax = 0) AS created`
+
+type RestoreCategoryRow struct {
+	Category model.Category `json:"category"`
+	// Created is false when the user already had the category, a deleted one is restored
+	Created bool `json:"created"`
+}
+
+// RestoreCategory returns the category of the user with the parent and name, creating it when there is none
+func (q *Queries) RestoreCategory(ctx context.Context, args CreateCategoryParams) (RestoreCategoryRow, error) {
+	q.logs.WithField("func", "database/sqlc/backup.go -> RestoreCategory()").Debug()
+	row := q.db.QueryRowContext(ctx, restoreCategory, args.ParentID, args.UserID, args.Name)
+	var r RestoreCategoryRow
+	err := row.Scan(
+		&r.Category.ID,
+		&r.Category.ParentID,
+		&r.Category.UserID,
+		&r.Category.Name,
+		&r.Category.CreatedAt,
+		&r.Category.DeletedAt,
+		&r.Created,
+	)
+	return r, err
+}
+
+const restoreMerchant = `--name: RestoreMerchant :one
+INSERT INTO merchant(user_id, name)
+VALUES($1, $2)
+ON CONFLICT ON CONSTRAINT user_merchant_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING merchant_id, user_id, name, created_at, deleted_at, (xmax = 0) AS created`
+
+type RestoreMerchantRow struct {
+	Merchant model.Merchant `json:"merchant"`
+	// Created is false when the user already had the merchant, a deleted one is restored
+	Created bool `json:"created"`
+}
+
+// RestoreMerchant is GetOrCreateMerchant telling whether the merchant was created
+func (q *Queries) RestoreMerchant(ctx context.Context, args CreateMerchantParams) (RestoreMerchantRow, error) {
+	q.logs.WithField("func", "database/sqlc/backup.go -> RestoreMerchant()").Debug()
+	row := q.db.QueryRowContext(ctx, restoreMerchant, args.UserID, args.Name)
+	var r RestoreMerchantRow
+	err := row.Scan(
+		&r.Merchant.ID,
+		&r.Merchant.UserID,
+		&r.Merchant.Name,
+		&r.Merchant.CreatedAt,
+		&r.Merchant.DeletedAt,
+		&r.Created,
+	)
+	return r, err
+}
+
+const restoreBudget = `--name: RestoreBudget :execrows
+INSERT INTO budgets (user_id, category_id, month, amount, rollover)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (category_id, month) WHERE deleted_at = '0001-01-01 00:00:00Z' DO NOTHING`
+
+// RestoreBudget creates the budget unless the category already has one for the month, it returns the number
+// of budgets created
+func (q *Queries) RestoreBudget(ctx context.Context, args CreateBudgetParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/backup.go -> RestoreBudget()").Debug()
+	result, err := q.db.ExecContext(ctx, restoreBudget, args.UserID, args.CategoryID, args.Month, args.Amount, args.Rollover)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreImportMapping = `--name: RestoreImportMapping :execrows
+INSERT INTO import_mappings (user_id, name, mapping)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, name) WHERE deleted_at = '0001-01-01 00:00:00Z' DO NOTHING`
+
+// RestoreImportMapping creates the mapping unless the user already has one by its name, it returns the number
+// of mappings created
+func (q *Queries) RestoreImportMapping(ctx context.Context, args CreateImportMappingParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/backup.go -> RestoreImportMapping()").Debug()
+	result, err := q.db.ExecContext(ctx, restoreImportMapping, args.UserID, args.Name, args.Mapping)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,386 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// RestoreAdjustmentName is the name of the transaction that brings a restored account to its backed up balance
+const RestoreAdjustmentName = "Backup balance adjustment"
+
+// RestoreBackupParams is the content of a backup restored for UserID, the identifiers in it are those of the
+// instance and user it was taken from
+type RestoreBackupParams struct {
+	UserID         model.UserID                 `json:"user_id"`
+	Accounts       []model.Account              `json:"accounts"`
+	Categories     []model.Category             `json:"categories"`
+	Merchants      []model.Merchant             `json:"merchants"`
+	Transfers      []model.Transfer             `json:"transfers"`
+	Transactions   []model.Transaction          `json:"transactions"`
+	Budgets        []model.Budget               `json:"budgets"`
+	Recurring      []model.RecurringTransaction `json:"recurring"`
+	ImportMappings []model.ImportMapping        `json:"import_mappings"`
+}
+
+// restoreIDs maps the identifiers of a backup to those of the user it is restored for
+type restoreIDs struct {
+	accounts   map[model.AccountID]model.AccountID
+	categories map[model.CategoryID]model.CategoryID
+	merchants  map[model.MerchantID]model.MerchantID
+}
+
+// RestoreBackupTx restores a backup for the user, either all of it or none. Accounts, categories and merchants
+// are matched to the user's own by identifier and then by name, and created when there is no match. Transfers,
+// transactions and recurring transactions the user still has under the same identifier are left alone, so a
+// backup restored over the user it was taken from only brings back what was deleted since
+func (r SQLRepo) RestoreBackupTx(ctx context.Context, args RestoreBackupParams) (model.RestoreResult, error) {
+	r.logs.WithField("func", "database/sqlc/backup_tx.go -> RestoreBackupTx()").Debug()
+	var result model.RestoreResult
+	err := r.execTx(ctx, func(q *Queries) error {
+		result = model.RestoreResult{Adjustments: []model.Transaction{}}
+		ids := restoreIDs{
+			accounts:   make(map[model.AccountID]model.AccountID),
+			categories: make(map[model.CategoryID]model.CategoryID),
+			merchants:  make(map[model.MerchantID]model.MerchantID),
+		}
+		created, err := q.restoreAccounts(ctx, args, ids, &result)
+		if err != nil {
+			return err
+		}
+		if err = q.restoreCategories(ctx, args, ids, &result); err != nil {
+			return err
+		}
+		if err = q.restoreMerchants(ctx, args, ids, &result); err != nil {
+			return err
+		}
+		if err = q.restoreTransfers(ctx, args, ids, &result); err != nil {
+			return err
+		}
+		if err = q.restoreTransactions(ctx, args, ids, &result); err != nil {
+			return err
+		}
+		if err = q.restoreBalances(ctx, args.UserID, created, &result); err != nil {
+			return err
+		}
+		if err = q.restoreBudgets(ctx, args, ids, &result); err != nil {
+			return err
+		}
+		if err = q.restoreRecurring(ctx, args, ids, &result); err != nil {
+			return err
+		}
+		for _, mapping := range args.ImportMappings {
+			n, err := q.RestoreImportMapping(ctx, CreateImportMappingParams{
+				UserID:  args.UserID,
+				Name:    mapping.Name,
+				Mapping: mapping.Mapping,
+			})
+			if err != nil {
+				return err
+			}
+			countRestored(&result.ImportMappings, n > 0)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// restoreAccounts maps the accounts of the backup, it returns the balance the backup gives each account it created
+func (q *Queries) restoreAccounts(ctx context.Context, args RestoreBackupParams, ids restoreIDs, result *model.RestoreResult) (map[model.AccountID]int64, error) {
+	created := make(map[model.AccountID]int64)
+	for _, account := range args.Accounts {
+		existing, err := q.GetAccountByID(ctx, account.AccountID)
+		if err == nil && existing.UserID == args.UserID {
+			ids.accounts[account.AccountID] = existing.AccountID
+			countRestored(&result.Accounts, false)
+			continue
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		row, err := q.RestoreAccount(ctx, CreateAccountParams{
+			UserID:      args.UserID,
+			AccountName: account.Name,
+			AccountType: account.Type,
+			Currency:    account.Currency,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ids.accounts[account.AccountID] = row.Account.AccountID
+		if row.Created {
+			created[row.Account.AccountID] = account.Balance
+		}
+		countRestored(&result.Accounts, row.Created)
+	}
+	return created, nil
+}
+
+// restoreCategories maps the categories of the backup, parents before their children. A category whose parent
+// is not in the backup is restored at the top
+func (q *Queries) restoreCategories(ctx context.Context, args RestoreBackupParams, ids restoreIDs, result *model.RestoreResult) error {
+	inBackup := make(map[model.CategoryID]bool, len(args.Categories))
+	for _, category := range args.Categories {
+		inBackup[category.ID] = true
+	}
+	pending := args.Categories
+	for len(pending) > 0 {
+		var waiting []model.Category
+		for _, category := range pending {
+			parentID := model.CategoryID("")
+			if inBackup[category.ParentID] {
+				var ok bool
+				if parentID, ok = ids.categories[category.ParentID]; !ok {
+					waiting = append(waiting, category)
+					continue
+				}
+			}
+			existing, err := q.GetCategoryByID(ctx, category.ID)
+			if err == nil && existing.UserID == args.UserID {
+				ids.categories[category.ID] = existing.ID
+				countRestored(&result.Categories, false)
+				continue
+			}
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			row, err := q.RestoreCategory(ctx, CreateCategoryParams{
+				ParentID: parentID,
+				UserID:   args.UserID,
+				Name:     category.Name,
+			})
+			if err != nil {
+				return err
+			}
+			ids.categories[category.ID] = row.Category.ID
+			countRestored(&result.Categories, row.Created)
+		}
+		if len(waiting) == len(pending) {
+			// the parents left form a cycle, break it by restoring them at the top
+			inBackup = map[model.CategoryID]bool{}
+		}
+		pending = waiting
+	}
+	return nil
+}
+
+// restoreMerchants maps the merchants of the backup
+func (q *Queries) restoreMerchants(ctx context.Context, args RestoreBackupParams, ids restoreIDs, result *model.RestoreResult) error {
+	for _, merchant := range args.Merchants {
+		existing, err := q.GetMerchantByID(ctx, merchant.ID)
+		if err == nil && existing.UserID == args.UserID {
+			ids.merchants[merchant.ID] = existing.ID
+			countRestored(&result.Merchants, false)
+			continue
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		row, err := q.RestoreMerchant(ctx, CreateMerchantParams{UserID: args.UserID, Name: merchant.Name})
+		if err != nil {
+			return err
+		}
+		ids.merchants[merchant.ID] = row.Merchant.ID
+		countRestored(&result.Merchants, row.Created)
+	}
+	return nil
+}
+
+// restoreTransfers creates the transfers of the backup the user does not have, with both their legs
+func (q *Queries) restoreTransfers(ctx context.Context, args RestoreBackupParams, ids restoreIDs, result *model.RestoreResult) error {
+	for _, transfer := range args.Transfers {
+		from, okFrom := ids.accounts[transfer.FromAccountID]
+		to, okTo := ids.accounts[transfer.ToAccountID]
+		if !okFrom || !okTo {
+			result.Transfers.Skipped++
+			continue
+		}
+		existing, err := q.GetTransferByID(ctx, transfer.ID)
+		if err == nil && existing.UserID == args.UserID {
+			countRestored(&result.Transfers, false)
+			continue
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		accounts, err := q.lockTransferAccounts(ctx, args.UserID, from, to)
+		if err != nil {
+			return err
+		}
+		restored, err := q.CreateTransfer(ctx, CreateTransferParams{
+			UserID:        args.UserID,
+			FromAccountID: from,
+			ToAccountID:   to,
+			Amount:        transfer.Amount,
+			Notes:         transfer.Notes,
+			Date:          transfer.Date,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = q.createTransferLegs(ctx, restored, accounts); err != nil {
+			return err
+		}
+		countRestored(&result.Transfers, true)
+	}
+	return nil
+}
+
+// restoreTransactions creates the transactions of the backup the user does not have. Transfer legs come
+// back with their transfer, and categories or merchants the backup does not hold are left out
+func (q *Queries) restoreTransactions(ctx context.Context, args RestoreBackupParams, ids restoreIDs, result *model.RestoreResult) error {
+	for _, transaction := range args.Transactions {
+		if transaction.TransferID != "" {
+			continue
+		}
+		accountID, ok := ids.accounts[transaction.AccountID]
+		if !ok {
+			result.Transactions.Skipped++
+			continue
+		}
+		existing, err := q.GetTransactionByID(ctx, transaction.ID)
+		if err == nil && existing.UserID == args.UserID {
+			countRestored(&result.Transactions, false)
+			continue
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		restore := CreateTransactionParams{
+			UserID:          args.UserID,
+			AccountID:       accountID,
+			CategoryID:      ids.categories[transaction.CategoryID],
+			Name:            transaction.Name,
+			TransactionType: transaction.TransactionType,
+			Amount:          transaction.Amount,
+			Notes:           transaction.Notes,
+			Date:            transaction.Date,
+			MerchantID:      ids.merchants[transaction.MerchantID],
+			Status:          transaction.Status,
+		}
+		for _, split := range transaction.Splits {
+			categoryID, ok := ids.categories[split.CategoryID]
+			if !ok {
+				// a split cannot lose its category, so the transaction keeps just its own
+				restore.Splits = nil
+				break
+			}
+			restore.Splits = append(restore.Splits, CreateSplitParams{
+				CategoryID: categoryID,
+				Amount:     split.Amount,
+				Notes:      split.Notes,
+			})
+		}
+		if _, err = q.createTransaction(ctx, restore); err != nil {
+			return err
+		}
+		countRestored(&result.Transactions, true)
+	}
+	return nil
+}
+
+// restoreBalances brings the accounts the restore created to the balance the backup gives them, which their
+// transactions only fall short of when a balance was once set by hand
+func (q *Queries) restoreBalances(ctx context.Context, userID model.UserID, balances map[model.AccountID]int64, result *model.RestoreResult) error {
+	for accountID, balance := range balances {
+		account, err := q.GetAccountByID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if account.Balance == balance {
+			continue
+		}
+		adjustment := CreateTransactionParams{
+			UserID:          userID,
+			AccountID:       accountID,
+			Name:            RestoreAdjustmentName,
+			TransactionType: model.Income,
+			Amount:          balance - account.Balance,
+			Date:            time.Now(),
+		}
+		if adjustment.Amount < 0 {
+			adjustment.TransactionType, adjustment.Amount = model.Expense, -adjustment.Amount
+		}
+		transaction, err := q.createTransaction(ctx, adjustment)
+		if err != nil {
+			return err
+		}
+		result.Adjustments = append(result.Adjustments, transaction)
+	}
+	return nil
+}
+
+// restoreBudgets creates the budgets of the backup for the months their category has none
+func (q *Queries) restoreBudgets(ctx context.Context, args RestoreBackupParams, ids restoreIDs, result *model.RestoreResult) error {
+	for _, budget := range args.Budgets {
+		categoryID, ok := ids.categories[budget.CategoryID]
+		if !ok {
+			result.Budgets.Skipped++
+			continue
+		}
+		n, err := q.RestoreBudget(ctx, CreateBudgetParams{
+			UserID:     args.UserID,
+			CategoryID: categoryID,
+			Month:      budget.Month,
+			Amount:     budget.Amount,
+			Rollover:   budget.Rollover,
+		})
+		if err != nil {
+			return err
+		}
+		countRestored(&result.Budgets, n > 0)
+	}
+	return nil
+}
+
+// restoreRecurring creates the recurring transactions of the backup the user does not have. They keep their
+// next run, so whatever fell due after the backup was taken is posted by the scheduler
+func (q *Queries) restoreRecurring(ctx context.Context, args RestoreBackupParams, ids restoreIDs, result *model.RestoreResult) error {
+	for _, recurring := range args.Recurring {
+		accountID, okAccount := ids.accounts[recurring.AccountID]
+		categoryID, okCategory := ids.categories[recurring.CategoryID]
+		if !okAccount || !okCategory {
+			result.Recurring.Skipped++
+			continue
+		}
+		existing, err := q.GetRecurringByID(ctx, recurring.ID)
+		if err == nil && existing.UserID == args.UserID {
+			countRestored(&result.Recurring, false)
+			continue
+		}
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		_, err = q.CreateRecurring(ctx, CreateRecurringParams{
+			UserID:          args.UserID,
+			AccountID:       accountID,
+			CategoryID:      categoryID,
+			MerchantID:      ids.merchants[recurring.MerchantID],
+			Name:            recurring.Name,
+			TransactionType: recurring.TransactionType,
+			Amount:          recurring.Amount,
+			Notes:           recurring.Notes,
+			Rule:            recurring.Rule,
+			StartDate:       recurring.StartDate,
+			EndDate:         recurring.EndDate,
+			SkipDates:       recurring.SkipDates,
+			NextRun:         recurring.NextRun,
+		})
+		if err != nil {
+			return err
+		}
+		countRestored(&result.Recurring, true)
+	}
+	return nil
+}
+
+// countRestored adds a record to the created or existing count
+func countRestored(c *model.RestoreCount, created bool) {
+	if created {
+		c.Created++
+		return
+	}
+	c.Existing++
+}
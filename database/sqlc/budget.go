@@ -0,0 +1,161 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+	"time"
+)
+
+const createBudget = `--name: CreateBudget :one
+INSERT INTO budgets (user_id, category_id, month, amount, rollover)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING budget_id, user_id, category_id, month, amount, rollover, created_at, deleted_at`
+
+type CreateBudgetParams struct {
+	UserID     model.UserID     `json:"user_id"`
+	CategoryID model.CategoryID `json:"category_id"`
+	Month      time.Time        `json:"month"`
+	Amount     int64            `json:"amount"`
+	Rollover   bool             `json:"rollover"`
+}
+
+func (q *Queries) CreateBudget(ctx context.Context, args CreateBudgetParams) (model.Budget, error) {
+	q.logs.WithField("func", "database/sqlc/budget.go -> CreateBudget()").Debug()
+	row := q.db.QueryRowContext(ctx, createBudget, args.UserID, args.CategoryID, args.Month, args.Amount, args.Rollover)
+	var budget model.Budget
+	err := row.Scan(
+		&budget.ID,
+		&budget.UserID,
+		&budget.CategoryID,
+		&budget.Month,
+		&budget.Amount,
+		&budget.Rollover,
+		&budget.CreatedAt,
+		&budget.DeletedAt,
+	)
+	return budget, err
+}
+
+const updateBudget = `--name: UpdateBudget :one
+UPDATE budgets SET amount = $3,
+rollover = $4
+WHERE budget_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING budget_id, user_id, category_id, month, amount, rollover, created_at, deleted_at`
+
+type UpdateBudgetParams struct {
+	BudgetID model.BudgetID `json:"budget_id"`
+	UserID   model.UserID   `json:"user_id"`
+	Amount   int64          `json:"amount"`
+	Rollover bool           `json:"rollover"`
+}
+
+func (q *Queries) UpdateBudget(ctx context.Context, args UpdateBudgetParams) (model.Budget, error) {
+	q.logs.WithField("func", "database/sqlc/budget.go -> UpdateBudget()").Debug()
+	row := q.db.QueryRowContext(ctx, updateBudget, args.BudgetID, args.UserID, args.Amount, args.Rollover)
+	var budget model.Budget
+	err := row.Scan(
+		&budget.ID,
+		&budget.UserID,
+		&budget.CategoryID,
+		&budget.Month,
+		&budget.Amount,
+		&budget.Rollover,
+		&budget.CreatedAt,
+		&budget.DeletedAt,
+	)
+	return budget, err
+}
+
+const listBudgetHistory = `--name: ListBudgetHistory :many
+WITH RECURSIVE tree AS (
+    SELECT c.category_id AS budget_category_id, c.category_id
+    FROM categories c
+    WHERE c.category_id IN (SELECT category_id FROM budgets WHERE user_id = $1)
+    UNION
+    SELECT tree.budget_category_id, c.category_id
+    FROM categories c
+    JOIN tree ON c.parent_id = tree.category_id::text
+    WHERE c.deleted_at = '0001-01-01 00:00:00Z'
+)
+SELECT b.budget_id, b.category_id, c.name, b.month, b.rollover, b.amount,
+       COALESCE((SELECT SUM(CASE WHEN d.currency = $3 THEN d.amount
+                                 ELSE convert_amount(d.amount, d.currency, $3, d.day) END)
+                 FROM (SELECT t.currency, a.date::date AS day, SUM(a.amount)::bigint AS amount
+                       FROM transaction_category_amounts a
+                       JOIN transactions t ON t.transaction_id = a.transaction_id
+                       JOIN tree ON tree.category_id = a.category_id
+                       WHERE tree.budget_category_id = b.category_id
+                         AND a.user_id = b.user_id
+                         AND a.transaction_type = 'expense'
+                         AND a.deleted_at = '0001-01-01 00:00:00Z'
+                         AND a.date >= b.month
+                         AND a.date < b.month + INTERVAL '1 month'
+                       GROUP BY t.currency, day) d), 0)::bigint AS spent
+FROM budgets b
+JOIN categories c ON c.category_id = b.category_id
+WHERE b.user_id = $1
+AND b.deleted_at = '0001-01-01 00:00:00Z'
+AND b.month <= $2
+ORDER BY b.category_id, b.month`
+
+type ListBudgetHistoryParams struct {
+	UserID model.UserID `json:"user_id"`
+	Month  time.Time    `json:"month"`
+	// BaseCurrency is the currency spending is converted to, at the rate of the day of each transaction. Amounts no
+	// rate is known for are left out
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
+}
+
+// ListBudgetHistory returns what was budgeted and spent for every budget of the user up to and including month,
+// ordered by category and month so model.CarryOver can work out the amounts carried over
+func (q *Queries) ListBudgetHistory(ctx context.Context, args ListBudgetHistoryParams) ([]model.BudgetProgress, error) {
+	q.logs.WithField("func", "database/sqlc/budget.go -> ListBudgetHistory()").Debug()
+	rows, err := q.db.QueryContext(ctx, listBudgetHistory, args.UserID, args.Month, args.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var history []model.BudgetProgress
+	for rows.Next() {
+		var progress model.BudgetProgress
+		err = rows.Scan(
+			&progress.BudgetID,
+			&progress.CategoryID,
+			&progress.CategoryName,
+			&progress.Month,
+			&progress.Rollover,
+			&progress.Budgeted,
+			&progress.Spent,
+		)
+		history = append(history, progress)
+	}
+	return history, err
+}
+
+const deleteBudget = `--name: DeleteBudget :one
+UPDATE budgets SET deleted_at = now()
+WHERE budget_id = $1
+  AND user_id = $2
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at`
+
+type DeleteBudgetParams struct {
+	BudgetID model.BudgetID `json:"budget_id"`
+	UserID   model.UserID   `json:"user_id"`
+}
+
+func (q *Queries) DeleteBudget(ctx context.Context, args DeleteBudgetParams) (time.Time, error) {
+	q.logs.WithField("func", "database/sqlc/budget.go -> DeleteBudget()").Debug()
+	row := q.db.QueryRowContext(ctx, deleteBudget, args.BudgetID, args.UserID)
+	var budget model.Budget
+	err := row.Scan(
+		&budget.DeletedAt,
+	)
+	return budget.DeletedAt, err
+}
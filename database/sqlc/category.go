@@ -34,20 +34,19 @@ func (q *Queries) CreateCategory(ctx context.Context, args CreateCategoryParams)
 }
 
 const updateCategory = `--name: UpdateCategory :one
-UPDATE categories SET name = $3
-AND parent_id = $2
+UPDATE categories SET name = $2
 WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
 RETURNING category_id, parent_id, user_id, name, created_at, deleted_at`
 
+// UpdateCategoryParams renames a category, it is moved to another parent with MoveCategoryTx
 type UpdateCategoryParams struct {
 	CategoryID model.CategoryID `json:"category_id"`
-	ParentID   model.CategoryID `json:"parent_id"`
 	Name       string           `json:"name"`
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, args UpdateCategoryParams) (model.Category, error) {
 	q.logs.WithField("func", "database/sqlc/category.go -> UpdateCategory()").Debug()
-	row := q.db.QueryRowContext(ctx, updateCategory, args.CategoryID, args.ParentID, args.Name)
+	row := q.db.QueryRowContext(ctx, updateCategory, args.CategoryID, args.Name)
 	var category model.Category
 	err := row.Scan(
 		&category.ID,
@@ -83,19 +82,19 @@ func (q *Queries) GetCategoryByID(ctx context.Context, id model.CategoryID) (mod
 const listCategory = `--name: ListCategories :many
 SELECT * FROM categories
 WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY category_id
-LIMIT $2 
-OFFSET $3`
+AND ($4::text = '' OR (created_at, category_id) > ($3::timestamptz, NULLIF($4::text, '')::uuid))
+ORDER BY created_at, category_id
+LIMIT $2`
 
 type ListCategoryParams struct {
 	UserID model.UserID `json:"category_id"`
 	Limit  int32        `json:"limit"`
-	Offset int32        `json:"offset"`
+	Cursor model.Cursor `json:"cursor"`
 }
 
 func (q *Queries) ListCategories(ctx context.Context, args ListCategoryParams) ([]model.Category, error) {
 	q.logs.WithField("func", "database/sqlc/category.go -> ListCategories()").Debug()
-	rows, err := q.db.QueryContext(ctx, listCategory, args.UserID, args.Limit, args.Offset)
+	rows, err := q.db.QueryContext(ctx, listCategory, args.UserID, args.Limit, args.Cursor.Date, args.Cursor.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +118,19 @@ func (q *Queries) ListCategories(ctx context.Context, args ListCategoryParams) (
 	return categories, err
 }
 
+const countCategories = `--name: CountCategories :one
+SELECT COUNT(*) FROM categories
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'`
+
+// CountCategories counts the categories ListCategories pages through
+func (q *Queries) CountCategories(ctx context.Context, userID model.UserID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> CountCategories()").Debug()
+	row := q.db.QueryRowContext(ctx, countCategories, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteCategory = `--name: DeleteUser :exec
 UPDATE categories SET deleted_at = now()
 WHERE category_id = $1
@@ -135,3 +147,251 @@ func (q *Queries) DeleteCategory(ctx context.Context, id model.CategoryID) (time
 	)
 	return category.DeletedAt, err
 }
+
+const moveCategory = `--name: MoveCategory :one
+UPDATE categories SET parent_id = $2
+WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING category_id, parent_id, user_id, name, created_at, deleted_at`
+
+type MoveCategoryParams struct {
+	CategoryID model.CategoryID `json:"category_id"`
+	UserID     model.UserID     `json:"user_id"`
+	// ParentID is the category it is moved under, empty moves it to the top level
+	ParentID model.CategoryID `json:"parent_id"`
+}
+
+func (q *Queries) MoveCategory(ctx context.Context, args MoveCategoryParams) (model.Category, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> MoveCategory()").Debug()
+	row := q.db.QueryRowContext(ctx, moveCategory, args.CategoryID, args.ParentID)
+	var category model.Category
+	err := row.Scan(
+		&category.ID,
+		&category.ParentID,
+		&category.UserID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.DeletedAt,
+	)
+	return category, err
+}
+
+const listAllCategories = `--name: ListAllCategories :many
+SELECT * FROM categories
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY name, category_id`
+
+// ListAllCategories returns every live category of the user, for building the category tree
+func (q *Queries) ListAllCategories(ctx context.Context, userID model.UserID) ([]model.Category, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> ListAllCategories()").Debug()
+	rows, err := q.db.QueryContext(ctx, listAllCategories, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var categories []model.Category
+	for rows.Next() {
+		var category model.Category
+		err = rows.Scan(
+			&category.ID,
+			&category.ParentID,
+			&category.UserID,
+			&category.Name,
+			&category.CreatedAt,
+			&category.DeletedAt,
+		)
+		categories = append(categories, category)
+	}
+	return categories, err
+}
+
+const lockUserCategories = `--name: LockUserCategories :exec
+SELECT category_id FROM categories
+WHERE user_id = $1
+FOR UPDATE`
+
+// LockUserCategories locks every category of the user so two changes to the tree cannot together create a cycle
+func (q *Queries) LockUserCategories(ctx context.Context, userID model.UserID) error {
+	q.logs.WithField("func", "database/sqlc/category.go -> LockUserCategories()").Debug()
+	_, err := q.db.ExecContext(ctx, lockUserCategories, userID)
+	return err
+}
+
+const reparentCategories = `--name: ReparentCategories :execrows
+UPDATE categories SET parent_id = $2
+WHERE parent_id = $1::text
+AND deleted_at = '0001-01-01 00:00:00Z'`
+
+type ReparentCategoriesParams struct {
+	FromParentID model.CategoryID `json:"from_parent_id"`
+	ToParentID   model.CategoryID `json:"to_parent_id"`
+}
+
+// ReparentCategories moves the subcategories of one category under another
+func (q *Queries) ReparentCategories(ctx context.Context, args ReparentCategoriesParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> ReparentCategories()").Debug()
+	result, err := q.db.ExecContext(ctx, reparentCategories, args.FromParentID, args.ToParentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reassignCategory = `--name: ReassignCategory :one
+WITH moved_transactions AS (
+    UPDATE transactions SET category_id = $2
+    WHERE category_id = $1 AND ($3 OR status <> 'reconciled')
+    RETURNING deleted_at
+), moved_splits AS (
+    UPDATE transaction_splits s SET category_id = $2
+    FROM transactions t
+    WHERE s.category_id = $1 AND t.transaction_id = s.transaction_id AND ($3 OR t.status <> 'reconciled')
+    RETURNING s.split_id
+), moved_postings AS (
+    SELECT p.transaction_id, p.user_id, p.ledger_account, p.account_id, SUM(p.amount) AS amount
+    FROM postings p
+    JOIN transactions t ON t.transaction_id = p.transaction_id
+    WHERE p.category_id = $1 AND ($3 OR t.status <> 'reconciled')
+    GROUP BY p.transaction_id, p.user_id, p.ledger_account, p.account_id
+    HAVING SUM(p.amount) <> 0
+), reposted AS (
+    INSERT INTO postings (transaction_id, user_id, ledger_account, account_id, category_id, amount)
+    SELECT transaction_id, user_id, ledger_account, account_id, $1::uuid, -amount FROM moved_postings
+    UNION ALL
+    SELECT transaction_id, user_id, ledger_account, account_id, $2::uuid, amount FROM moved_postings
+), moved_recurring AS (
+    UPDATE recurring_transactions SET category_id = $2 WHERE category_id = $1 RETURNING recurring_id
+)
+SELECT (SELECT COUNT(*) FROM moved_transactions WHERE deleted_at = '0001-01-01 00:00:00Z'),
+(SELECT COUNT(*) FROM moved_splits), (SELECT COUNT(*) FROM moved_recurring),
+(SELECT COUNT(*) FROM transactions t
+ WHERE t.status = 'reconciled' AND NOT $3
+ AND t.deleted_at = '0001-01-01 00:00:00Z'
+ AND (t.category_id = $1 OR EXISTS (
+     SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.transaction_id AND s.category_id = $1
+ )))`
+
+type ReassignCategoryParams struct {
+	FromCategoryID model.CategoryID `json:"from_category_id"`
+	ToCategoryID   model.CategoryID `json:"to_category_id"`
+	// Unlock moves reconciled transactions as well, they are left in the category otherwise
+	Unlock bool `json:"unlock"`
+}
+
+// ReassignCategory moves the transactions, splits and recurring transactions of one category to another and
+// returns how many live transactions, splits and recurring transactions were moved, and how many reconciled
+// transactions were left. The journal is not changed, what the moved transactions posted to the category is
+// reversed and posted again to the other category
+func (q *Queries) ReassignCategory(ctx context.Context, args ReassignCategoryParams) (model.CategoryMerge, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> ReassignCategory()").Debug()
+	row := q.db.QueryRowContext(ctx, reassignCategory, args.FromCategoryID, args.ToCategoryID, args.Unlock)
+	var merge model.CategoryMerge
+	err := row.Scan(
+		&merge.Transactions,
+		&merge.Splits,
+		&merge.Recurring,
+		&merge.Reconciled,
+	)
+	return merge, err
+}
+
+const mergeCategoryBudgets = `--name: MergeCategoryBudgets :execrows
+WITH moved AS (
+    UPDATE budgets b SET category_id = $2
+    WHERE b.category_id = $1
+    AND b.deleted_at = '0001-01-01 00:00:00Z'
+    AND NOT EXISTS (
+        SELECT 1 FROM budgets t
+        WHERE t.category_id = $2 AND t.month = b.month AND t.deleted_at = '0001-01-01 00:00:00Z'
+    )
+    RETURNING b.budget_id
+), added AS (
+    UPDATE budgets t SET amount = t.amount + b.amount
+    FROM budgets b
+    WHERE b.category_id = $1
+    AND b.deleted_at = '0001-01-01 00:00:00Z'
+    AND t.category_id = $2
+    AND t.month = b.month
+    AND t.deleted_at = '0001-01-01 00:00:00Z'
+    RETURNING b.budget_id
+), merged AS (
+    UPDATE budgets SET deleted_at = now()
+    WHERE budget_id IN (SELECT budget_id FROM added)
+    RETURNING budget_id
+)
+SELECT budget_id FROM moved
+UNION ALL
+SELECT budget_id FROM merged`
+
+// MergeCategoryBudgets moves the budgets of one category to another, where both have a budget for a month the
+// amounts are added together, and returns how many budgets were merged
+func (q *Queries) MergeCategoryBudgets(ctx context.Context, args ReassignCategoryParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> MergeCategoryBudgets()").Debug()
+	result, err := q.db.ExecContext(ctx, mergeCategoryBudgets, args.FromCategoryID, args.ToCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countCategoryUsage = `--name: CountCategoryUsage :one
+SELECT (SELECT COUNT(*) FROM transactions WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z')
++ (SELECT COUNT(*) FROM transaction_splits s
+   JOIN transactions t ON t.transaction_id = s.transaction_id
+   WHERE s.category_id = $1 AND t.deleted_at = '0001-01-01 00:00:00Z')
++ (SELECT COUNT(*) FROM recurring_transactions WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z')
++ (SELECT COUNT(*) FROM budgets WHERE category_id = $1 AND deleted_at = '0001-01-01 00:00:00Z')`
+
+// CountCategoryUsage counts the live transactions, splits, recurring transactions and budgets of a category
+func (q *Queries) CountCategoryUsage(ctx context.Context, id model.CategoryID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> CountCategoryUsage()").Debug()
+	row := q.db.QueryRowContext(ctx, countCategoryUsage, id)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listCategorizedTransactions = `--name: ListCategorizedTransactions :many
+SELECT t.name, COALESCE(m.name, ''), t.category_id
+FROM transactions t
+JOIN categories c ON c.category_id = t.category_id
+LEFT JOIN merchant m ON m.merchant_id = t.merchant_id
+WHERE t.user_id = $1
+AND t.transfer_id IS NULL
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND c.deleted_at = '0001-01-01 00:00:00Z'
+AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.transaction_id)
+ORDER BY t.date DESC
+LIMIT $2`
+
+type ListCategorizedTransactionsParams struct {
+	UserID model.UserID `json:"user_id"`
+	Limit  int32        `json:"limit"`
+}
+
+// ListCategorizedTransactions returns the latest transactions of the user filed under a single live category,
+// split transactions and transfers are left out
+func (q *Queries) ListCategorizedTransactions(ctx context.Context, args ListCategorizedTransactionsParams) ([]model.CategorizedTransaction, error) {
+	q.logs.WithField("func", "database/sqlc/category.go -> ListCategorizedTransactions()").Debug()
+	rows, err := q.db.QueryContext(ctx, listCategorizedTransactions, args.UserID, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.CategorizedTransaction
+	for rows.Next() {
+		var transaction model.CategorizedTransaction
+		err = rows.Scan(
+			&transaction.Name,
+			&transaction.Merchant,
+			&transaction.CategoryID,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
@@ -0,0 +1,230 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrCategoryNotFound is returned when a category is moved under, or merged into, a category that does not
+	// exist or belongs to another user
+	ErrCategoryNotFound = errors.New("category not found or deleted")
+	// ErrCategoryCycle is returned when a category would end up under itself
+	ErrCategoryCycle = errors.New("a category cannot be moved under or merged into itself or one of its subcategories")
+	// ErrCategoryInUse is returned when a category that still has transactions is deleted without reassigning them
+	ErrCategoryInUse = errors.New("category has transactions, reassign them to another category before deleting it")
+)
+
+// MoveCategoryTx moves a category, with its subcategories, under another parent or to the top level
+func (r SQLRepo) MoveCategoryTx(ctx context.Context, args MoveCategoryParams) (model.Category, error) {
+	r.logs.WithField("func", "database/sqlc/category_tx.go -> MoveCategoryTx()").Debug()
+	var category model.Category
+	err := r.execTx(ctx, func(q *Queries) error {
+		if err := q.LockUserCategories(ctx, args.UserID); err != nil {
+			return err
+		}
+		if _, err := q.getOwnedCategory(ctx, args.UserID, args.CategoryID); err != nil {
+			return err
+		}
+		if args.ParentID != "" {
+			parent, err := q.getOwnedCategory(ctx, args.UserID, args.ParentID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return ErrCategoryNotFound
+				}
+				return err
+			}
+			if err = q.checkNotUnder(ctx, args.CategoryID, parent); err != nil {
+				return err
+			}
+		}
+		var err error
+		category, err = q.MoveCategory(ctx, args)
+		return err
+	})
+	return category, err
+}
+
+type MergeCategoriesParams struct {
+	CategoryID model.CategoryID `json:"category_id"`
+	UserID     model.UserID     `json:"user_id"`
+	// TargetID is the category everything of the merged category is moved to
+	TargetID model.CategoryID `json:"target_id"`
+	// Unlock moves reconciled transactions as well, they stay under the merged category otherwise
+	Unlock bool `json:"unlock"`
+}
+
+// MergeCategoriesTx moves the transactions, splits, recurring transactions, budgets and subcategories of a
+// category to the target category and deletes it
+func (r SQLRepo) MergeCategoriesTx(ctx context.Context, args MergeCategoriesParams) (model.CategoryMerge, error) {
+	r.logs.WithField("func", "database/sqlc/category_tx.go -> MergeCategoriesTx()").Debug()
+	var merge model.CategoryMerge
+	err := r.execTx(ctx, func(q *Queries) error {
+		if err := q.LockUserCategories(ctx, args.UserID); err != nil {
+			return err
+		}
+		if _, err := q.getOwnedCategory(ctx, args.UserID, args.CategoryID); err != nil {
+			return err
+		}
+		// the subcategories are moved under the target, which must not be one of them
+		var err error
+		merge, err = q.mergeCategory(ctx, args.UserID, args.CategoryID, args.TargetID, args.Unlock)
+		if err != nil {
+			return err
+		}
+		if err = q.checkNotUnder(ctx, args.CategoryID, merge.Category); err != nil {
+			return err
+		}
+		merge.Subcategories, err = q.ReparentCategories(ctx, ReparentCategoriesParams{
+			FromParentID: args.CategoryID,
+			ToParentID:   args.TargetID,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.DeleteCategory(ctx, args.CategoryID)
+		return err
+	})
+	return merge, err
+}
+
+type DeleteCategoryParams struct {
+	CategoryID model.CategoryID `json:"category_id"`
+	UserID     model.UserID     `json:"user_id"`
+	// ReassignTo is the category the transactions of the deleted category are moved to
+	ReassignTo model.CategoryID `json:"reassign_to"`
+	// Unlock lets reconciled transactions be moved to ReassignTo
+	Unlock bool `json:"unlock"`
+}
+
+// DeleteCategoryTx deletes a category, its transactions are first moved to ReassignTo when it is given and its
+// subcategories are moved up to its parent
+func (r SQLRepo) DeleteCategoryTx(ctx context.Context, args DeleteCategoryParams) (time.Time, error) {
+	r.logs.WithField("func", "database/sqlc/category_tx.go -> DeleteCategoryTx()").Debug()
+	var deletedAt time.Time
+	err := r.execTx(ctx, func(q *Queries) error {
+		if err := q.LockUserCategories(ctx, args.UserID); err != nil {
+			return err
+		}
+		category, err := q.getOwnedCategory(ctx, args.UserID, args.CategoryID)
+		if err != nil {
+			return err
+		}
+		if args.ReassignTo != "" {
+			merge, err := q.mergeCategory(ctx, args.UserID, args.CategoryID, args.ReassignTo, args.Unlock)
+			if err != nil {
+				return err
+			}
+			if merge.Reconciled > 0 {
+				return ErrTransactionReconciled
+			}
+		}
+		count, err := q.CountCategoryUsage(ctx, args.CategoryID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrCategoryInUse
+		}
+		// rules that filed transactions under the category stop doing so
+		if _, err = q.ReassignRuleCategory(ctx, ReassignCategoryParams{FromCategoryID: args.CategoryID}); err != nil {
+			return err
+		}
+		_, err = q.ReparentCategories(ctx, ReparentCategoriesParams{
+			FromParentID: args.CategoryID,
+			ToParentID:   category.ParentID,
+		})
+		if err != nil {
+			return err
+		}
+		deletedAt, err = q.DeleteCategory(ctx, args.CategoryID)
+		return err
+	})
+	return deletedAt, err
+}
+
+// getOwnedCategory returns a live category of the user, a category of another user is reported as not found
+func (q *Queries) getOwnedCategory(ctx context.Context, userID model.UserID, id model.CategoryID) (model.Category, error) {
+	category, err := q.GetCategoryByID(ctx, id)
+	if err != nil {
+		return model.Category{}, err
+	}
+	if category.UserID != userID {
+		return model.Category{}, sql.ErrNoRows
+	}
+	return category, nil
+}
+
+// checkCategories checks the categories given, such as those of a transaction and its splits, exist and belong
+// to the user
+func (q *Queries) checkCategories(ctx context.Context, userID model.UserID, ids ...model.CategoryID) error {
+	checked := map[model.CategoryID]bool{"": true}
+	for _, id := range ids {
+		if checked[id] {
+			continue
+		}
+		if _, err := q.getOwnedCategory(ctx, userID, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCategoryNotFound
+			}
+			return err
+		}
+		checked[id] = true
+	}
+	return nil
+}
+
+// checkNotUnder walks up from category to the top level and fails when it passes the category with the given id
+func (q *Queries) checkNotUnder(ctx context.Context, id model.CategoryID, category model.Category) error {
+	seen := map[model.CategoryID]bool{}
+	for {
+		if category.ID == id {
+			return ErrCategoryCycle
+		}
+		// the tree may already hold a cycle from before cycles were checked, it has no top level to reach
+		if category.IsTopLevel() || seen[category.ID] {
+			return nil
+		}
+		seen[category.ID] = true
+		parent, err := q.GetCategoryByID(ctx, category.ParentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		category = parent
+	}
+}
+
+// mergeCategory moves everything filed under a category but its subcategories, rules included, to the target
+// category of the same user, reconciled transactions only when they are unlocked. It must run inside a database
+// transaction holding the lock on the categories of the user
+func (q *Queries) mergeCategory(ctx context.Context, userID model.UserID, id, targetID model.CategoryID, unlock bool) (model.CategoryMerge, error) {
+	if targetID == id {
+		return model.CategoryMerge{}, ErrCategoryCycle
+	}
+	target, err := q.getOwnedCategory(ctx, userID, targetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.CategoryMerge{}, ErrCategoryNotFound
+		}
+		return model.CategoryMerge{}, err
+	}
+	args := ReassignCategoryParams{FromCategoryID: id, ToCategoryID: targetID, Unlock: unlock}
+	merge, err := q.ReassignCategory(ctx, args)
+	if err != nil {
+		return model.CategoryMerge{}, err
+	}
+	if merge.Budgets, err = q.MergeCategoryBudgets(ctx, args); err != nil {
+		return model.CategoryMerge{}, err
+	}
+	if _, err = q.ReassignRuleCategory(ctx, args); err != nil {
+		return model.CategoryMerge{}, err
+	}
+	merge.Category = target
+	return merge, nil
+}
@@ -27,6 +27,7 @@ func NewQuery(db DbTx, log *utils.StandardLogger) *Queries {
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db: tx,
+		db:   tx,
+		logs: q.logs,
 	}
 }
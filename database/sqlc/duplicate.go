@@ -0,0 +1,215 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"time"
+)
+
+const listDuplicateCandidates = `--name: ListDuplicateCandidates :many
+SELECT transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at,
+       deleted_at, transfer_id, merchant_id, currency, status
+FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND transfer_id IS NULL
+AND date >= $2
+AND date <= $3
+ORDER BY date, transaction_id`
+
+type ListDuplicateCandidatesParams struct {
+	AccountID model.AccountID `json:"account_id"`
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+}
+
+// ListDuplicateCandidates returns the transactions of the account dated from From to To inclusive that new
+// transactions can be duplicates of, transfer legs are left out
+func (q *Queries) ListDuplicateCandidates(ctx context.Context, args ListDuplicateCandidatesParams) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/duplicate.go -> ListDuplicateCandidates()").Debug()
+	rows, err := q.db.QueryContext(ctx, listDuplicateCandidates, args.AccountID, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const listDuplicatePairs = `--name: ListDuplicatePairs :many
+SELECT a.transaction_id, a.user_id, a.account_id, a.category_id, a.name, a.transaction_type, a.amount, a.notes,
+       a.date, a.created_at, a.deleted_at, a.transfer_id, a.merchant_id, a.currency, a.status,
+       b.transaction_id, b.user_id, b.account_id, b.category_id, b.name, b.transaction_type, b.amount, b.notes,
+       b.date, b.created_at, b.deleted_at, b.transfer_id, b.merchant_id, b.currency, b.status
+FROM transactions a
+JOIN transactions b ON b.account_id = a.account_id
+    AND b.transaction_type = a.transaction_type
+    AND b.amount = a.amount
+    AND (b.created_at, b.transaction_id) > (a.created_at, a.transaction_id)
+    AND b.date BETWEEN a.date - make_interval(secs => $4) AND a.date + make_interval(secs => $4)
+    AND b.deleted_at = '0001-01-01 00:00:00Z'
+    AND b.transfer_id IS NULL
+WHERE a.user_id = $1
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+AND a.transfer_id IS NULL
+AND ($2::timestamptz = '0001-01-01 00:00:00Z' OR b.date >= $2)
+AND ($3::timestamptz = '0001-01-01 00:00:00Z' OR b.date < $3)
+AND NOT EXISTS (SELECT 1 FROM dismissed_duplicates d
+                WHERE d.transaction_id = LEAST(a.transaction_id, b.transaction_id)
+                AND d.duplicate_id = GREATEST(a.transaction_id, b.transaction_id))
+ORDER BY b.date DESC, b.transaction_id`
+
+type ListDuplicatePairsParams struct {
+	UserID model.UserID `json:"user_id"`
+	// From and To bound the date of the duplicates, a zero bound is left out
+	From   time.Time     `json:"from"`
+	To     time.Time     `json:"to"`
+	Window time.Duration `json:"window"`
+}
+
+// ListDuplicatePairs returns the pairs of transactions of the user on the same account with the same type and
+// amount whose dates are within Window of each other, pairs the user dismissed are left out. Similarity is not
+// set, the pairs are candidates whose names are still to be compared. The duplicate of each pair is the
+// transaction created last, latest first
+func (q *Queries) ListDuplicatePairs(ctx context.Context, args ListDuplicatePairsParams) ([]model.DuplicatePair, error) {
+	q.logs.WithField("func", "database/sqlc/duplicate.go -> ListDuplicatePairs()").Debug()
+	rows, err := q.db.QueryContext(ctx, listDuplicatePairs, args.UserID, args.From, args.To, args.Window.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var pairs []model.DuplicatePair
+	for rows.Next() {
+		var pair model.DuplicatePair
+		err = rows.Scan(
+			&pair.Transaction.ID,
+			&pair.Transaction.UserID,
+			&pair.Transaction.AccountID,
+			&pair.Transaction.CategoryID,
+			&pair.Transaction.Name,
+			&pair.Transaction.TransactionType,
+			&pair.Transaction.Amount,
+			&pair.Transaction.Notes,
+			&pair.Transaction.Date,
+			&pair.Transaction.CreatedAt,
+			&pair.Transaction.DeletedAt,
+			&pair.Transaction.TransferID,
+			&pair.Transaction.MerchantID,
+			&pair.Transaction.Currency,
+			&pair.Transaction.Status,
+			&pair.Duplicate.ID,
+			&pair.Duplicate.UserID,
+			&pair.Duplicate.AccountID,
+			&pair.Duplicate.CategoryID,
+			&pair.Duplicate.Name,
+			&pair.Duplicate.TransactionType,
+			&pair.Duplicate.Amount,
+			&pair.Duplicate.Notes,
+			&pair.Duplicate.Date,
+			&pair.Duplicate.CreatedAt,
+			&pair.Duplicate.DeletedAt,
+			&pair.Duplicate.TransferID,
+			&pair.Duplicate.MerchantID,
+			&pair.Duplicate.Currency,
+			&pair.Duplicate.Status,
+		)
+		pairs = append(pairs, pair)
+	}
+	return pairs, err
+}
+
+const dismissDuplicate = `--name: DismissDuplicate :exec
+INSERT INTO dismissed_duplicates (user_id, transaction_id, duplicate_id)
+VALUES ($1, LEAST($2::uuid, $3::uuid), GREATEST($2::uuid, $3::uuid))
+ON CONFLICT DO NOTHING`
+
+type DismissDuplicateParams struct {
+	UserID        model.UserID        `json:"user_id"`
+	TransactionID model.TransactionID `json:"transaction_id"`
+	DuplicateID   model.TransactionID `json:"duplicate_id"`
+}
+
+// DismissDuplicate remembers the user keeps both transactions of a pair, dismissing a pair twice is not an error
+func (q *Queries) DismissDuplicate(ctx context.Context, args DismissDuplicateParams) error {
+	q.logs.WithField("func", "database/sqlc/duplicate.go -> DismissDuplicate()").Debug()
+	_, err := q.db.ExecContext(ctx, dismissDuplicate, args.UserID, args.TransactionID, args.DuplicateID)
+	return err
+}
+
+const moveImportedTransactions = `--name: MoveImportedTransactions :exec
+UPDATE imported_transactions SET transaction_id = $2
+WHERE transaction_id = $1`
+
+// MoveImportedTransactions hands the statement entries imported as one transaction over to another, so importing
+// the statement again does not bring back a merged duplicate
+func (q *Queries) MoveImportedTransactions(ctx context.Context, from, to model.TransactionID) error {
+	q.logs.WithField("func", "database/sqlc/duplicate.go -> MoveImportedTransactions()").Debug()
+	_, err := q.db.ExecContext(ctx, moveImportedTransactions, from, to)
+	return err
+}
+
+const fillTransactionDetails = `--name: FillTransactionDetails :one
+UPDATE transactions SET notes = CASE WHEN notes = '' THEN $2 ELSE notes END,
+merchant_id = COALESCE(merchant_id, NULLIF($3, '')::uuid)
+WHERE transaction_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at, deleted_at, transfer_id, merchant_id, currency, status`
+
+type FillTransactionDetailsParams struct {
+	TransactionID model.TransactionID `json:"transaction_id"`
+	Notes         string              `json:"notes"`
+	MerchantID    model.MerchantID    `json:"merchant_id"`
+}
+
+// FillTransactionDetails gives a transaction the notes and merchant it does not have yet, neither changes its
+// effect on the account balance
+func (q *Queries) FillTransactionDetails(ctx context.Context, args FillTransactionDetailsParams) (model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/duplicate.go -> FillTransactionDetails()").Debug()
+	row := q.db.QueryRowContext(ctx, fillTransactionDetails, args.TransactionID, args.Notes, args.MerchantID)
+	var transaction model.Transaction
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.UserID,
+		&transaction.AccountID,
+		&transaction.CategoryID,
+		&transaction.Name,
+		&transaction.TransactionType,
+		&transaction.Amount,
+		&transaction.Notes,
+		&transaction.Date,
+		&transaction.CreatedAt,
+		&transaction.DeletedAt,
+		&transaction.TransferID,
+		&transaction.MerchantID,
+		&transaction.Currency,
+		&transaction.Status,
+	)
+	return transaction, err
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"errors"
+)
+
+// ErrNotDuplicate is returned when two transactions merged as duplicates could not be the same transaction
+var ErrNotDuplicate = errors.New("transactions are not on the same account with the same type and amount")
+
+type MergeDuplicateParams struct {
+	UserID model.UserID `json:"user_id"`
+	// TransactionID is the transaction kept, DuplicateID the one deleted
+	TransactionID model.TransactionID `json:"transaction_id"`
+	DuplicateID   model.TransactionID `json:"duplicate_id"`
+}
+
+// MergeDuplicateTx deletes the duplicate of a transaction and reverses its effect on the account. The kept
+// transaction is given the notes and merchant of the duplicate it does not have, and the statement entries the
+// duplicate was imported from so they are not imported again. A reconciled duplicate is not deleted
+func (r SQLRepo) MergeDuplicateTx(ctx context.Context, args MergeDuplicateParams) (model.Transaction, error) {
+	r.logs.WithField("func", "database/sqlc/duplicate_tx.go -> MergeDuplicateTx()").Debug()
+	var transaction model.Transaction
+	err := r.execTx(ctx, func(q *Queries) error {
+		if args.TransactionID == args.DuplicateID {
+			return ErrNotDuplicate
+		}
+		kept, err := q.getOwnedTransactionForUpdate(ctx, args.TransactionID, args.UserID)
+		if err != nil {
+			return err
+		}
+		duplicate, err := q.getOwnedTransactionForUpdate(ctx, args.DuplicateID, args.UserID)
+		if err != nil {
+			return err
+		}
+		if kept.AccountID != duplicate.AccountID || kept.TransactionType != duplicate.TransactionType ||
+			kept.Amount != duplicate.Amount {
+			return ErrNotDuplicate
+		}
+		if err = checkUnlocked(duplicate, false); err != nil {
+			return err
+		}
+		if _, err = q.lockOwnedAccounts(ctx, args.UserID, kept.AccountID); err != nil {
+			return err
+		}
+		if _, err = q.DeleteTransaction(ctx, duplicate.ID); err != nil {
+			return err
+		}
+		if err = q.reverseTransaction(ctx, duplicate); err != nil {
+			return err
+		}
+		if err = q.MoveImportedTransactions(ctx, duplicate.ID, kept.ID); err != nil {
+			return err
+		}
+		transaction, err = q.FillTransactionDetails(ctx, FillTransactionDetailsParams{
+			TransactionID: kept.ID,
+			Notes:         duplicate.Notes,
+			MerchantID:    duplicate.MerchantID,
+		})
+		if err != nil {
+			return err
+		}
+		// a transaction entered by hand is cleared once its duplicate came in from the statement
+		if transaction.Status == model.Pending && duplicate.Status != model.Pending {
+			transaction, err = q.UpdateTransactionStatus(ctx, UpdateTransactionStatusParams{
+				TransactionID: kept.ID,
+				Status:        model.Cleared,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		transaction.Splits, err = q.ListSplitsByTransactionID(ctx, kept.ID)
+		return err
+	})
+	return transaction, err
+}
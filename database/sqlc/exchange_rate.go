@@ -0,0 +1,100 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+	"github.com/lib/pq"
+	"time"
+)
+
+const upsertExchangeRates = `--name: UpsertExchangeRates :execrows
+INSERT INTO exchange_rates (base, quote, date, rate)
+SELECT * FROM unnest($1::varchar[], $2::varchar[], $3::date[], $4::numeric[])
+ON CONFLICT (base, quote, date) DO UPDATE SET rate = EXCLUDED.rate`
+
+// UpsertExchangeRates stores the rates, replacing those already stored for the same pair and date
+func (q *Queries) UpsertExchangeRates(ctx context.Context, rates []model.ExchangeRate) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/exchange_rate.go -> UpsertExchangeRates()").Debug()
+	bases := make([]string, 0, len(rates))
+	quotes := make([]string, 0, len(rates))
+	dates := make([]string, 0, len(rates))
+	values := make([]float64, 0, len(rates))
+	for _, rate := range rates {
+		bases = append(bases, string(rate.Base))
+		quotes = append(quotes, string(rate.Quote))
+		dates = append(dates, rate.Date.Format("2006-01-02"))
+		values = append(values, rate.Rate)
+	}
+	result, err := q.db.ExecContext(ctx, upsertExchangeRates, pq.Array(bases), pq.Array(quotes), pq.Array(dates),
+		pq.Array(values))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const convertAmount = `--name: ConvertAmount :one
+SELECT exchange_rate($2, $3, $4), convert_amount($1, $2, $3, $4)`
+
+type ConvertAmountParams struct {
+	Amount int64              `json:"amount"`
+	From   utils.CurrencyCode `json:"from"`
+	To     utils.CurrencyCode `json:"to"`
+	// Date is the day whose rate is used, the latest one before it when there is none for the day itself
+	Date time.Time `json:"date"`
+}
+
+// ConvertAmount converts an amount in minor units from one currency to another at the rate of a date
+func (q *Queries) ConvertAmount(ctx context.Context, args ConvertAmountParams) (model.Conversion, error) {
+	q.logs.WithField("func", "database/sqlc/exchange_rate.go -> ConvertAmount()").Debug()
+	row := q.db.QueryRowContext(ctx, convertAmount, args.Amount, args.From, args.To, args.Date)
+	conversion := model.Conversion{Currency: args.To}
+	err := row.Scan(
+		&conversion.Rate,
+		&conversion.Amount,
+	)
+	return conversion, err
+}
+
+const listConvertedBalances = `--name: ListConvertedBalances :many
+SELECT account_id, account_name, account_type, balance, currency,
+exchange_rate(currency, $2, $3), convert_amount(balance, currency, $2, $3)
+FROM accounts
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY account_name, account_id`
+
+type ListConvertedBalancesParams struct {
+	UserID       model.UserID       `json:"user_id"`
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
+	Date         time.Time          `json:"date"`
+}
+
+// ListConvertedBalances returns the balance of every account of the user converted to a currency at the rate of a date
+func (q *Queries) ListConvertedBalances(ctx context.Context, args ListConvertedBalancesParams) ([]model.ConvertedBalance, error) {
+	q.logs.WithField("func", "database/sqlc/exchange_rate.go -> ListConvertedBalances()").Debug()
+	rows, err := q.db.QueryContext(ctx, listConvertedBalances, args.UserID, args.BaseCurrency, args.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var balances []model.ConvertedBalance
+	for rows.Next() {
+		var balance model.ConvertedBalance
+		err = rows.Scan(
+			&balance.AccountID,
+			&balance.Name,
+			&balance.Type,
+			&balance.Balance,
+			&balance.Currency,
+			&balance.Rate,
+			&balance.ConvertedBalance,
+		)
+		balances = append(balances, balance)
+	}
+	return balances, err
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+)
+
+// exchangeRateBatch is the number of rates stored per statement, a full ECB history holds some 200000
+const exchangeRateBatch = 5000
+
+// ImportExchangeRatesTx stores a file of rates in batches, either all of them or none
+func (r SQLRepo) ImportExchangeRatesTx(ctx context.Context, rates []model.ExchangeRate) (int64, error) {
+	r.logs.WithField("func", "database/sqlc/exchange_rate_tx.go -> ImportExchangeRatesTx()").Debug()
+	var stored int64
+	err := r.execTx(ctx, func(q *Queries) error {
+		for start := 0; start < len(rates); start += exchangeRateBatch {
+			end := start + exchangeRateBatch
+			if end > len(rates) {
+				end = len(rates)
+			}
+			n, err := q.UpsertExchangeRates(ctx, rates[start:end])
+			if err != nil {
+				return err
+			}
+			stored += n
+		}
+		return nil
+	})
+	return stored, err
+}
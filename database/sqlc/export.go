@@ -0,0 +1,281 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"github.com/lib/pq"
+	"time"
+)
+
+const exportAccounts = `--name: ExportAccounts :many
+SELECT * FROM accounts
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, account_id`
+
+// ExportAccounts returns every account of the user
+func (q *Queries) ExportAccounts(ctx context.Context, userID model.UserID) ([]model.Account, error) {
+	q.logs.WithField("func", "database/sqlc/export.go -> ExportAccounts()").Debug()
+	rows, err := q.db.QueryContext(ctx, exportAccounts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var accounts []model.Account
+	for rows.Next() {
+		var account model.Account
+		err = rows.Scan(
+			&account.AccountID,
+			&account.UserID,
+			&account.Name,
+			&account.Type,
+			&account.Balance,
+			&account.Currency,
+			&account.CreatedAt,
+			&account.DeletedAt,
+		)
+		accounts = append(accounts, account)
+	}
+	return accounts, err
+}
+
+const exportCategories = `--name: ExportCategories :many
+SELECT * FROM categories
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, category_id`
+
+// ExportCategories returns every category of the user
+func (q *Queries) ExportCategories(ctx context.Context, userID model.UserID) ([]model.Category, error) {
+	q.logs.WithField("func", "database/sqlc/export.go -> ExportCategories()").Debug()
+	rows, err := q.db.QueryContext(ctx, exportCategories, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var categories []model.Category
+	for rows.Next() {
+		var category model.Category
+		err = rows.Scan(
+			&category.ID,
+			&category.ParentID,
+			&category.UserID,
+			&category.Name,
+			&category.CreatedAt,
+			&category.DeletedAt,
+		)
+		categories = append(categories, category)
+	}
+	return categories, err
+}
+
+const exportMerchants = `--name: ExportMerchants :many
+SELECT * FROM merchant
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, merchant_id`
+
+// ExportMerchants returns every merchant of the user
+func (q *Queries) ExportMerchants(ctx context.Context, userID model.UserID) ([]model.Merchant, error) {
+	q.logs.WithField("func", "database/sqlc/export.go -> ExportMerchants()").Debug()
+	rows, err := q.db.QueryContext(ctx, exportMerchants, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var merchants []model.Merchant
+	for rows.Next() {
+		var merchant model.Merchant
+		err = rows.Scan(
+			&merchant.ID,
+			&merchant.UserID,
+			&merchant.Name,
+			&merchant.CreatedAt,
+			&merchant.DeletedAt,
+		)
+		merchants = append(merchants, merchant)
+	}
+	return merchants, err
+}
+
+const exportTransactions = `--name: ExportTransactions :many
+SELECT t.* FROM transactions t
+JOIN accounts a ON a.account_id = t.account_id
+WHERE t.user_id = $1
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+AND (t.date, t.transaction_id) > ($2, $3::uuid)
+ORDER BY t.date, t.transaction_id
+LIMIT $4`
+
+// ExportTransactionsParams pages through the transactions of a user, each page starts after the date and ID
+// of the last transaction of the page before, the zero values start at the first transaction
+type ExportTransactionsParams struct {
+	UserID    model.UserID        `json:"user_id"`
+	AfterDate time.Time           `json:"after_date"`
+	AfterID   model.TransactionID `json:"after_id"`
+	Limit     int32               `json:"limit"`
+}
+
+// ExportTransactions returns a page of the transactions of the user on accounts that were not deleted, oldest first
+func (q *Queries) ExportTransactions(ctx context.Context, args ExportTransactionsParams) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/export.go -> ExportTransactions()").Debug()
+	afterID := args.AfterID
+	if afterID == "" {
+		afterID = "00000000-0000-0000-0000-000000000000"
+	}
+	rows, err := q.db.QueryContext(ctx, exportTransactions, args.UserID, args.AfterDate, afterID, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const exportTransfers = `--name: ExportTransfers :many
+SELECT * FROM transfers
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY date, transfer_id`
+
+// ExportTransfers returns every transfer of the user
+func (q *Queries) ExportTransfers(ctx context.Context, userID model.UserID) ([]model.Transfer, error) {
+	q.logs.WithField("func", "database/sqlc/export.go -> ExportTransfers()").Debug()
+	rows, err := q.db.QueryContext(ctx, exportTransfers, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transfers []model.Transfer
+	for rows.Next() {
+		var transfer model.Transfer
+		err = rows.Scan(
+			&transfer.ID,
+			&transfer.UserID,
+			&transfer.FromAccountID,
+			&transfer.ToAccountID,
+			&transfer.Amount,
+			&transfer.Notes,
+			&transfer.Date,
+			&transfer.CreatedAt,
+			&transfer.DeletedAt,
+		)
+		transfers = append(transfers, transfer)
+	}
+	return transfers, err
+}
+
+const exportBudgets = `--name: ExportBudgets :many
+SELECT * FROM budgets
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY month, budget_id`
+
+// ExportBudgets returns every budget of the user
+func (q *Queries) ExportBudgets(ctx context.Context, userID model.UserID) ([]model.Budget, error) {
+	q.logs.WithField("func", "database/sqlc/export.go -> ExportBudgets()").Debug()
+	rows, err := q.db.QueryContext(ctx, exportBudgets, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var budgets []model.Budget
+	for rows.Next() {
+		var budget model.Budget
+		err = rows.Scan(
+			&budget.ID,
+			&budget.UserID,
+			&budget.CategoryID,
+			&budget.Month,
+			&budget.Amount,
+			&budget.Rollover,
+			&budget.CreatedAt,
+			&budget.DeletedAt,
+		)
+		budgets = append(budgets, budget)
+	}
+	return budgets, err
+}
+
+const exportRecurring = `--name: ExportRecurring :many
+SELECT * FROM recurring_transactions
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, recurring_id`
+
+// ExportRecurring returns every recurring transaction of the user
+func (q *Queries) ExportRecurring(ctx context.Context, userID model.UserID) ([]model.RecurringTransaction, error) {
+	q.logs.WithField("func", "database/sqlc/export.go -> ExportRecurring()").Debug()
+	rows, err := q.db.QueryContext(ctx, exportRecurring, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var recurring []model.RecurringTransaction
+	for rows.Next() {
+		var template model.RecurringTransaction
+		err = rows.Scan(
+			&template.ID,
+			&template.UserID,
+			&template.AccountID,
+			&template.CategoryID,
+			&template.MerchantID,
+			&template.Name,
+			&template.TransactionType,
+			&template.Amount,
+			&template.Notes,
+			&template.Rule,
+			&template.StartDate,
+			&template.EndDate,
+			pq.Array(&template.SkipDates),
+			&template.NextRun,
+			&template.CreatedAt,
+			&template.DeletedAt,
+		)
+		recurring = append(recurring, template)
+	}
+	return recurring, err
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/importer"
+	"context"
+	"github.com/lib/pq"
+	"time"
+)
+
+const createImportMapping = `--name: CreateImportMapping :one
+INSERT INTO import_mappings (user_id, name, mapping)
+VALUES ($1, $2, $3)
+RETURNING mapping_id, user_id, name, mapping, created_at, deleted_at`
+
+type CreateImportMappingParams struct {
+	UserID  model.UserID        `json:"user_id"`
+	Name    string              `json:"name"`
+	Mapping importer.CSVMapping `json:"mapping"`
+}
+
+func (q *Queries) CreateImportMapping(ctx context.Context, args CreateImportMappingParams) (model.ImportMapping, error) {
+	q.logs.WithField("func", "database/sqlc/import.go -> CreateImportMapping()").Debug()
+	row := q.db.QueryRowContext(ctx, createImportMapping, args.UserID, args.Name, args.Mapping)
+	var mapping model.ImportMapping
+	err := row.Scan(
+		&mapping.ID,
+		&mapping.UserID,
+		&mapping.Name,
+		&mapping.Mapping,
+		&mapping.CreatedAt,
+		&mapping.DeletedAt,
+	)
+	return mapping, err
+}
+
+const getImportMapping = `--name: GetImportMappingByID :one
+SELECT * FROM import_mappings
+WHERE mapping_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1`
+
+func (q *Queries) GetImportMappingByID(ctx context.Context, id model.ImportMappingID) (model.ImportMapping, error) {
+	q.logs.WithField("func", "database/sqlc/import.go -> GetImportMappingByID()").Debug()
+	row := q.db.QueryRowContext(ctx, getImportMapping, id)
+	var mapping model.ImportMapping
+	err := row.Scan(
+		&mapping.ID,
+		&mapping.UserID,
+		&mapping.Name,
+		&mapping.Mapping,
+		&mapping.CreatedAt,
+		&mapping.DeletedAt,
+	)
+	return mapping, err
+}
+
+const listImportMappings = `--name: ListImportMappings :many
+SELECT * FROM import_mappings
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY name`
+
+func (q *Queries) ListImportMappings(ctx context.Context, userID model.UserID) ([]model.ImportMapping, error) {
+	q.logs.WithField("func", "database/sqlc/import.go -> ListImportMappings()").Debug()
+	rows, err := q.db.QueryContext(ctx, listImportMappings, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var mappings []model.ImportMapping
+	for rows.Next() {
+		var mapping model.ImportMapping
+		err = rows.Scan(
+			&mapping.ID,
+			&mapping.UserID,
+			&mapping.Name,
+			&mapping.Mapping,
+			&mapping.CreatedAt,
+			&mapping.DeletedAt,
+		)
+		mappings = append(mappings, mapping)
+	}
+	return mappings, err
+}
+
+const deleteImportMapping = `--name: DeleteImportMapping :one
+UPDATE import_mappings SET deleted_at = now()
+WHERE mapping_id = $1
+  AND user_id = $2
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at`
+
+type DeleteImportMappingParams struct {
+	MappingID model.ImportMappingID `json:"mapping_id"`
+	UserID    model.UserID          `json:"user_id"`
+}
+
+func (q *Queries) DeleteImportMapping(ctx context.Context, args DeleteImportMappingParams) (time.Time, error) {
+	q.logs.WithField("func", "database/sqlc/import.go -> DeleteImportMapping()").Debug()
+	row := q.db.QueryRowContext(ctx, deleteImportMapping, args.MappingID, args.UserID)
+	var mapping model.ImportMapping
+	err := row.Scan(
+		&mapping.DeletedAt,
+	)
+	return mapping.DeletedAt, err
+}
+
+const createImportedTransaction = `--name: CreateImportedTransaction :exec
+INSERT INTO imported_transactions (account_id, external_id, transaction_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (account_id, external_id) DO UPDATE SET transaction_id = EXCLUDED.transaction_id`
+
+type CreateImportedTransactionParams struct {
+	AccountID     model.AccountID     `json:"account_id"`
+	ExternalID    string              `json:"external_id"`
+	TransactionID model.TransactionID `json:"transaction_id"`
+}
+
+// CreateImportedTransaction records the external identifier of an imported transaction, an identifier whose
+// transaction was deleted is given to the new one
+func (q *Queries) CreateImportedTransaction(ctx context.Context, args CreateImportedTransactionParams) error {
+	q.logs.WithField("func", "database/sqlc/import.go -> CreateImportedTransaction()").Debug()
+	_, err := q.db.ExecContext(ctx, createImportedTransaction, args.AccountID, args.ExternalID, args.TransactionID)
+	return err
+}
+
+const listImportedExternalIDs = `--name: ListImportedExternalIDs :many
+SELECT i.external_id FROM imported_transactions i
+JOIN transactions t ON t.transaction_id = i.transaction_id
+WHERE i.account_id = $1
+AND i.external_id = ANY($2::varchar[])
+AND t.deleted_at = '0001-01-01 00:00:00Z'`
+
+type ListImportedExternalIDsParams struct {
+	AccountID   model.AccountID `json:"account_id"`
+	ExternalIDs []string        `json:"external_ids"`
+}
+
+// ListImportedExternalIDs returns which of the external identifiers were already imported into the account
+func (q *Queries) ListImportedExternalIDs(ctx context.Context, args ListImportedExternalIDsParams) ([]string, error) {
+	q.logs.WithField("func", "database/sqlc/import.go -> ListImportedExternalIDs()").Debug()
+	rows, err := q.db.QueryContext(ctx, listImportedExternalIDs, args.AccountID, pq.Array(args.ExternalIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var ids []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(
+			&id,
+		)
+		ids = append(ids, id)
+	}
+	return ids, err
+}
+
+const getAccountAmountAfter = `--name: GetAccountAmountAfter :one
+SELECT COALESCE(SUM(CASE WHEN transaction_type IN ('expense', 'transfer_out') THEN -amount ELSE amount END), 0)::bigint
+FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $2`
+
+type GetAccountAmountAfterParams struct {
+	AccountID model.AccountID `json:"account_id"`
+	Date      time.Time       `json:"date"`
+}
+
+// GetAccountAmountAfter returns the effect the transactions of an account dated after date have on its balance,
+// the balance of the account as of date is its balance without it
+func (q *Queries) GetAccountAmountAfter(ctx context.Context, args GetAccountAmountAfterParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/import.go -> GetAccountAmountAfter()").Debug()
+	row := q.db.QueryRowContext(ctx, getAccountAmountAfter, args.AccountID, args.Date)
+	var amount int64
+	err := row.Scan(&amount)
+	return amount, err
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"time"
+)
+
+// AdjustmentName is the name of the transaction that brings an account to the balance of an imported statement
+const AdjustmentName = "Statement balance adjustment"
+
+type ImportTransactionParams struct {
+	Transaction CreateTransactionParams `json:"transaction"`
+	// ExternalID is the identifier the bank gave the entry, entries without one are never taken as duplicates
+	ExternalID string `json:"external_id"`
+	// MerchantName is the merchant the transaction is linked to, it is created when the user has none by that name
+	MerchantName string `json:"merchant_name"`
+}
+
+type ImportTransactionsParams struct {
+	UserID       model.UserID              `json:"user_id"`
+	AccountID    model.AccountID           `json:"account_id"`
+	Transactions []ImportTransactionParams `json:"transactions"`
+	// Balance is the balance the statement gives the account as of BalanceDate, when it is set any difference
+	// from the balance of the account on that date left after the import is made up by a transaction in
+	// AdjustmentCategoryID dated BalanceDate. A zero BalanceDate is now
+	Balance              *int64           `json:"balance"`
+	BalanceDate          time.Time        `json:"balance_date"`
+	AdjustmentCategoryID model.CategoryID `json:"adjustment_category_id"`
+}
+
+// ImportTransactionsTx creates the transactions of an imported statement on an account of the user, either
+// all of them are created or none are. Entries already imported into the account are skipped
+func (r SQLRepo) ImportTransactionsTx(ctx context.Context, args ImportTransactionsParams) (model.ImportResult, error) {
+	r.logs.WithField("func", "database/sqlc/import_tx.go -> ImportTransactionsTx()").Debug()
+	result := model.ImportResult{Duplicates: []string{}}
+	err := r.execTx(ctx, func(q *Queries) error {
+		if _, err := q.lockOwnedAccounts(ctx, args.UserID, args.AccountID); err != nil {
+			return err
+		}
+		imported, err := q.importedExternalIDs(ctx, args.AccountID, args.Transactions)
+		if err != nil {
+			return err
+		}
+		merchants := map[string]model.MerchantID{}
+		for _, arg := range args.Transactions {
+			if arg.ExternalID != "" && imported[arg.ExternalID] {
+				result.Duplicates = append(result.Duplicates, arg.ExternalID)
+				continue
+			}
+			arg.Transaction.UserID = args.UserID
+			arg.Transaction.AccountID = args.AccountID
+			if arg.MerchantName != "" && arg.Transaction.MerchantID == "" {
+				if _, ok := merchants[arg.MerchantName]; !ok {
+					merchant, err := q.GetOrCreateMerchant(ctx, CreateMerchantParams{
+						UserID: args.UserID,
+						Name:   arg.MerchantName,
+					})
+					if err != nil {
+						return err
+					}
+					merchants[arg.MerchantName] = merchant.ID
+				}
+				arg.Transaction.MerchantID = merchants[arg.MerchantName]
+			}
+			transaction, err := q.createTransaction(ctx, arg.Transaction)
+			if err != nil {
+				return err
+			}
+			if arg.ExternalID != "" {
+				imported[arg.ExternalID] = true
+				err = q.CreateImportedTransaction(ctx, CreateImportedTransactionParams{
+					AccountID:     args.AccountID,
+					ExternalID:    arg.ExternalID,
+					TransactionID: transaction.ID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			result.Transactions = append(result.Transactions, transaction)
+		}
+		account, err := q.GetAccountByID(ctx, args.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Balance = account.Balance
+		if args.Balance == nil {
+			return nil
+		}
+		date, balance := args.BalanceDate, account.Balance
+		if date.IsZero() {
+			date = time.Now()
+		} else {
+			after, err := q.GetAccountAmountAfter(ctx, GetAccountAmountAfterParams{
+				AccountID: args.AccountID,
+				Date:      date,
+			})
+			if err != nil {
+				return err
+			}
+			balance -= after
+		}
+		if *args.Balance == balance {
+			return nil
+		}
+		adjustment := CreateTransactionParams{
+			UserID:          args.UserID,
+			AccountID:       args.AccountID,
+			CategoryID:      args.AdjustmentCategoryID,
+			Name:            AdjustmentName,
+			TransactionType: model.Income,
+			Amount:          *args.Balance - balance,
+			Date:            date,
+			Status:          model.Cleared,
+		}
+		if adjustment.Amount < 0 {
+			adjustment.TransactionType, adjustment.Amount = model.Expense, -adjustment.Amount
+		}
+		transaction, err := q.createTransaction(ctx, adjustment)
+		if err != nil {
+			return err
+		}
+		result.Adjustment = &transaction
+		result.Balance += *args.Balance - balance
+		return nil
+	})
+	return result, err
+}
+
+// importedExternalIDs returns which external identifiers of the transactions were already imported into the account
+func (q *Queries) importedExternalIDs(ctx context.Context, accountID model.AccountID, args []ImportTransactionParams) (map[string]bool, error) {
+	var ids []string
+	for _, arg := range args {
+		if arg.ExternalID != "" {
+			ids = append(ids, arg.ExternalID)
+		}
+	}
+	imported := make(map[string]bool, len(ids))
+	if len(ids) == 0 {
+		return imported, nil
+	}
+	existing, err := q.ListImportedExternalIDs(ctx, ListImportedExternalIDsParams{
+		AccountID:   accountID,
+		ExternalIDs: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range existing {
+		imported[id] = true
+	}
+	return imported, nil
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
 	"context"
 	"time"
 )
@@ -30,6 +31,28 @@ func (q *Queries) CreateMerchant(ctx context.Context, args CreateMerchantParams)
 	return merchant, err
 }
 
+const getOrCreateMerchant = `--name: GetOrCreateMerchant :one
+INSERT INTO merchant(user_id, name)
+VALUES($1, $2)
+ON CONFLICT ON CONSTRAINT user_merchant_uiq DO UPDATE SET deleted_at = '0001-01-01 00:00:00Z'
+RETURNING merchant_id, user_id, name, created_at, deleted_at`
+
+// GetOrCreateMerchant returns the merchant of the user with the name, creating it when there is none.
+// A deleted merchant with the name is restored, the name can only be used once per user
+func (q *Queries) GetOrCreateMerchant(ctx context.Context, args CreateMerchantParams) (model.Merchant, error) {
+	q.logs.WithField("func", "database/sqlc/merchant.go -> GetOrCreateMerchant()").Debug()
+	row := q.db.QueryRowContext(ctx, getOrCreateMerchant, args.UserID, args.Name)
+	var merchant model.Merchant
+	err := row.Scan(
+		&merchant.ID,
+		&merchant.UserID,
+		&merchant.Name,
+		&merchant.CreatedAt,
+		&merchant.DeletedAt,
+	)
+	return merchant, err
+}
+
 const updateMerchant = `--name: UpdateMerchant :one
 UPDATE merchant SET name = $2
 WHERE merchant_id = $1 
@@ -78,19 +101,19 @@ func (q *Queries) GetMerchantByID(ctx context.Context, id model.MerchantID) (mod
 const listMerchants = `--name: ListMerchants :one
 SELECT * FROM merchant
 WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY merchant_id
-LIMIT $2
-OFFSET $3`
+AND ($4::text = '' OR (created_at, merchant_id) > ($3::timestamptz, NULLIF($4::text, '')::uuid))
+ORDER BY created_at, merchant_id
+LIMIT $2`
 
 type ListMerchantParams struct {
 	UserID model.UserID `json:"user_id"`
 	Limit  int32        `json:"limit"`
-	Offset int32        `json:"offset"`
+	Cursor model.Cursor `json:"cursor"`
 }
 
 func (q *Queries) ListMerchants(ctx context.Context, args ListMerchantParams) ([]model.Merchant, error) {
 	q.logs.WithField("func", "database/sqlc/merchant.go -> ListMerchants()").Debug()
-	rows, err := q.db.QueryContext(ctx, listMerchants, args.UserID, args.Limit, args.Offset)
+	rows, err := q.db.QueryContext(ctx, listMerchants, args.UserID, args.Limit, args.Cursor.Date, args.Cursor.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +136,19 @@ func (q *Queries) ListMerchants(ctx context.Context, args ListMerchantParams) ([
 	return merchants, err
 }
 
+const countMerchants = `--name: CountMerchants :one
+SELECT COUNT(*) FROM merchant
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'`
+
+// CountMerchants counts the merchants ListMerchants pages through
+func (q *Queries) CountMerchants(ctx context.Context, userID model.UserID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/merchant.go -> CountMerchants()").Debug()
+	row := q.db.QueryRowContext(ctx, countMerchants, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteMerchant = `--name: DeleteMerchant :one
 UPDATE merchant SET deleted_at = now()
 WHERE merchant_id = $1
@@ -128,3 +164,63 @@ func (q *Queries) DeleteMerchant(ctx context.Context, id model.MerchantID) (time
 	)
 	return merchant.DeletedAt, err
 }
+
+const listMerchantSpend = `--name: ListMerchantSpend :many
+WITH daily AS (
+    SELECT t.merchant_id, t.currency, t.date::date AS day, COUNT(*) AS transaction_count,
+    COALESCE(SUM(t.amount) FILTER (WHERE t.transaction_type = 'expense'), 0)::BIGINT AS spent,
+    COALESCE(SUM(t.amount) FILTER (WHERE t.transaction_type = 'income'), 0)::BIGINT AS refunded
+    FROM transactions t
+    JOIN merchant m ON m.merchant_id = t.merchant_id
+    WHERE m.user_id = $1
+    AND t.deleted_at = '0001-01-01 00:00:00Z'
+    AND t.date > $2
+    AND t.date < $3
+    GROUP BY t.merchant_id, t.currency, day
+), converted AS (
+    SELECT d.merchant_id, d.transaction_count, convert_amount(d.spent, d.currency, $4, d.day) AS spent,
+    convert_amount(d.refunded, d.currency, $4, d.day) AS refunded
+    FROM daily d
+)
+SELECT m.merchant_id, m.name, SUM(c.transaction_count)::BIGINT, COALESCE(SUM(c.spent), 0)::BIGINT AS spent,
+COALESCE(SUM(c.refunded), 0)::BIGINT, COUNT(*) FILTER (WHERE c.spent IS NULL)
+FROM converted c
+JOIN merchant m ON m.merchant_id = c.merchant_id
+GROUP BY m.merchant_id
+ORDER BY spent DESC, m.name`
+
+type ListMerchantSpendParams struct {
+	UserID model.UserID `json:"user_id"`
+	From   time.Time    `json:"from"`
+	To     time.Time    `json:"to"`
+	// BaseCurrency is the currency the totals are converted to, at the rate of the day of each transaction
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
+}
+
+// ListMerchantSpend totals the transactions of every merchant of the user over a period, the merchants the most
+// was spent at first
+func (q *Queries) ListMerchantSpend(ctx context.Context, args ListMerchantSpendParams) ([]model.MerchantSpend, error) {
+	q.logs.WithField("func", "database/sqlc/merchant.go -> ListMerchantSpend()").Debug()
+	rows, err := q.db.QueryContext(ctx, listMerchantSpend, args.UserID, args.From, args.To, args.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var spends []model.MerchantSpend
+	for rows.Next() {
+		var spend model.MerchantSpend
+		err = rows.Scan(
+			&spend.MerchantID,
+			&spend.Name,
+			&spend.TransactionCount,
+			&spend.Spent,
+			&spend.Refunded,
+			&spend.MissingRates,
+		)
+		spends = append(spends, spend)
+	}
+	return spends, err
+}
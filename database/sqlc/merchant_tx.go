@@ -0,0 +1,82 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrMerchantNotFound is returned when a transaction refers to a merchant that does not exist or belongs to another user
+	ErrMerchantNotFound = errors.New("merchant not found or deleted")
+	// ErrMerchantInUse is returned when a merchant that still has transactions is deleted without reassigning them
+	ErrMerchantInUse = errors.New("merchant has transactions, reassign them to another merchant before deleting it")
+)
+
+type DeleteMerchantParams struct {
+	MerchantID model.MerchantID `json:"merchant_id"`
+	UserID     model.UserID     `json:"user_id"`
+	// ReassignTo is the merchant the transactions of the deleted merchant are moved to
+	ReassignTo model.MerchantID `json:"reassign_to"`
+}
+
+// DeleteMerchantTx deletes a merchant, its transactions are first moved to ReassignTo when it is given
+func (r SQLRepo) DeleteMerchantTx(ctx context.Context, args DeleteMerchantParams) (time.Time, error) {
+	r.logs.WithField("func", "database/sqlc/merchant_tx.go -> DeleteMerchantTx()").Debug()
+	var deletedAt time.Time
+	err := r.execTx(ctx, func(q *Queries) error {
+		merchant, err := q.GetMerchantByID(ctx, args.MerchantID)
+		if err != nil {
+			return err
+		}
+		if merchant.UserID != args.UserID {
+			return sql.ErrNoRows
+		}
+		if args.ReassignTo != "" {
+			if args.ReassignTo == args.MerchantID {
+				return ErrMerchantNotFound
+			}
+			if err = q.checkMerchant(ctx, args.UserID, args.ReassignTo); err != nil {
+				return err
+			}
+			if _, err = q.ReassignMerchant(ctx, ReassignMerchantParams{FromMerchantID: args.MerchantID, ToMerchantID: args.ReassignTo}); err != nil {
+				return err
+			}
+		}
+		count, err := q.CountTransactionsByMerchantID(ctx, args.MerchantID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrMerchantInUse
+		}
+		// rules move to the merchant the transactions went to, or stop giving transactions a merchant
+		_, err = q.ReassignRuleMerchant(ctx, ReassignMerchantParams{FromMerchantID: args.MerchantID, ToMerchantID: args.ReassignTo})
+		if err != nil {
+			return err
+		}
+		deletedAt, err = q.DeleteMerchant(ctx, args.MerchantID)
+		return err
+	})
+	return deletedAt, err
+}
+
+// checkMerchant checks the merchant, if one is given, exists and belongs to the user
+func (q *Queries) checkMerchant(ctx context.Context, userID model.UserID, id model.MerchantID) error {
+	if id == "" {
+		return nil
+	}
+	merchant, err := q.GetMerchantByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMerchantNotFound
+		}
+		return err
+	}
+	if merchant.UserID != userID {
+		return ErrMerchantNotFound
+	}
+	return nil
+}
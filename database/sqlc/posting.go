@@ -0,0 +1,190 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+)
+
+const createPosting = `--name: CreatePosting :one
+INSERT INTO postings (transaction_id, user_id, ledger_account, account_id, category_id, amount)
+VALUES ($1, $2, $3, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, $6)
+RETURNING posting_id, transaction_id, user_id, ledger_account, COALESCE(account_id::text, ''), category_id, amount, created_at`
+
+type CreatePostingParams struct {
+	TransactionID model.TransactionID `json:"transaction_id"`
+	UserID        model.UserID        `json:"user_id"`
+	LedgerAccount model.LedgerAccount `json:"ledger_account"`
+	AccountID     model.AccountID     `json:"account_id"`
+	CategoryID    model.CategoryID    `json:"category_id"`
+	Amount        int64               `json:"amount"`
+}
+
+func (q *Queries) CreatePosting(ctx context.Context, args CreatePostingParams) (model.Posting, error) {
+	q.logs.WithField("func", "database/sqlc/posting.go -> CreatePosting()").Debug()
+	row := q.db.QueryRowContext(ctx, createPosting, args.TransactionID, args.UserID, args.LedgerAccount, args.AccountID,
+		args.CategoryID, args.Amount)
+	var posting model.Posting
+	err := row.Scan(
+		&posting.ID,
+		&posting.TransactionID,
+		&posting.UserID,
+		&posting.LedgerAccount,
+		&posting.AccountID,
+		&posting.CategoryID,
+		&posting.Amount,
+		&posting.CreatedAt,
+	)
+	return posting, err
+}
+
+const listPostingsByTransactionID = `--name: ListPostingsByTransactionID :many
+SELECT posting_id, transaction_id, user_id, ledger_account, COALESCE(account_id::text, ''), category_id, amount, created_at 
+FROM postings
+WHERE transaction_id = $1
+ORDER BY created_at, amount DESC`
+
+func (q *Queries) ListPostingsByTransactionID(ctx context.Context, id model.TransactionID) ([]model.Posting, error) {
+	q.logs.WithField("func", "database/sqlc/posting.go -> ListPostingsByTransactionID()").Debug()
+	rows, err := q.db.QueryContext(ctx, listPostingsByTransactionID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var postings []model.Posting
+	for rows.Next() {
+		var posting model.Posting
+		err = rows.Scan(
+			&posting.ID,
+			&posting.TransactionID,
+			&posting.UserID,
+			&posting.LedgerAccount,
+			&posting.AccountID,
+			&posting.CategoryID,
+			&posting.Amount,
+			&posting.CreatedAt,
+		)
+		postings = append(postings, posting)
+	}
+	return postings, err
+}
+
+const reversePostingsByTransactionID = `--name: ReversePostingsByTransactionID :exec
+INSERT INTO postings (transaction_id, user_id, ledger_account, account_id, category_id, amount)
+SELECT transaction_id, user_id, ledger_account, account_id, category_id, -SUM(amount)
+FROM postings
+WHERE transaction_id = $1
+GROUP BY transaction_id, user_id, ledger_account, account_id, category_id
+HAVING SUM(amount) <> 0`
+
+// ReversePostingsByTransactionID posts the opposite of what is left on every ledger account the transaction
+// posted to, postings are never changed or removed so the journal keeps the history of the transaction
+func (q *Queries) ReversePostingsByTransactionID(ctx context.Context, id model.TransactionID) error {
+	q.logs.WithField("func", "database/sqlc/posting.go -> ReversePostingsByTransactionID()").Debug()
+	_, err := q.db.ExecContext(ctx, reversePostingsByTransactionID, id)
+	return err
+}
+
+const trialBalance = `--name: TrialBalance :many
+WITH daily AS (
+    SELECT p.ledger_account, p.account_id, p.category_id, t.currency, t.date::date AS day, SUM(p.amount)::BIGINT AS total
+    FROM postings p
+    JOIN transactions t ON t.transaction_id = p.transaction_id
+    WHERE p.user_id = $1
+    GROUP BY p.ledger_account, p.account_id, p.category_id, t.currency, day
+), converted AS (
+    SELECT d.*, convert_amount(d.total, d.currency, $2, d.day) AS converted_total FROM daily d
+)
+SELECT d.ledger_account, COALESCE(d.account_id::text, ''), d.category_id, COALESCE(a.account_name, c.name, '') AS name,
+d.currency, SUM(d.total)::BIGINT AS total, COALESCE(SUM(d.converted_total), 0)::BIGINT AS converted_total,
+COUNT(*) FILTER (WHERE d.converted_total IS NULL) AS missing_rates
+FROM converted d
+LEFT JOIN accounts a ON a.account_id = d.account_id
+LEFT JOIN categories c ON c.category_id = d.category_id
+GROUP BY d.ledger_account, d.account_id, d.category_id, d.currency, a.account_name, c.name
+ORDER BY d.ledger_account, name, d.currency`
+
+type TrialBalanceParams struct {
+	UserID model.UserID `json:"user_id"`
+	// BaseCurrency is the currency the totals are also converted to, at the rate of the day of each transaction
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
+}
+
+// TrialBalance returns the total posted to every ledger account of the user in every currency it was posted in,
+// split into debit and credit
+func (q *Queries) TrialBalance(ctx context.Context, args TrialBalanceParams) ([]model.TrialBalanceLine, error) {
+	q.logs.WithField("func", "database/sqlc/posting.go -> TrialBalance()").Debug()
+	rows, err := q.db.QueryContext(ctx, trialBalance, args.UserID, args.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var lines []model.TrialBalanceLine
+	for rows.Next() {
+		var line model.TrialBalanceLine
+		var total, converted int64
+		err = rows.Scan(
+			&line.LedgerAccount,
+			&line.AccountID,
+			&line.CategoryID,
+			&line.Name,
+			&line.Currency,
+			&total,
+			&converted,
+			&line.MissingRates,
+		)
+		if total > 0 {
+			line.Debit = total
+		} else {
+			line.Credit = -total
+		}
+		if converted > 0 {
+			line.ConvertedDebit = converted
+		} else {
+			line.ConvertedCredit = -converted
+		}
+		lines = append(lines, line)
+	}
+	return lines, err
+}
+
+const listAccountImbalances = `--name: ListAccountImbalances :many
+SELECT a.account_id, a.account_name, a.balance, COALESCE(SUM(p.amount), 0) AS posted_balance
+FROM accounts a
+LEFT JOIN postings p ON p.account_id = a.account_id
+WHERE a.user_id = $1
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+GROUP BY a.account_id
+HAVING a.balance <> COALESCE(SUM(p.amount), 0)
+ORDER BY a.account_name`
+
+// ListAccountImbalances returns the accounts of the user whose balance does not match their postings
+func (q *Queries) ListAccountImbalances(ctx context.Context, userID model.UserID) ([]model.AccountImbalance, error) {
+	q.logs.WithField("func", "database/sqlc/posting.go -> ListAccountImbalances()").Debug()
+	rows, err := q.db.QueryContext(ctx, listAccountImbalances, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var imbalances []model.AccountImbalance
+	for rows.Next() {
+		var imbalance model.AccountImbalance
+		err = rows.Scan(
+			&imbalance.AccountID,
+			&imbalance.Name,
+			&imbalance.Balance,
+			&imbalance.PostedBalance,
+		)
+		imbalances = append(imbalances, imbalance)
+	}
+	return imbalances, err
+}
@@ -11,7 +11,10 @@ type userQuery interface {
 	GetUserByID(ctx context.Context, id model.UserID) (model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (model.User, error)
 	UpdatePassword(ctx context.Context, args UpdatePasswordParams) (time.Time, error)
+	UpdateBaseCurrency(ctx context.Context, args UpdateBaseCurrencyParams) (model.User, error)
+	SetDefaultBaseCurrency(ctx context.Context, args UpdateBaseCurrencyParams) error
 	ListUsers(ctx context.Context, args ListUserParams) ([]model.User, error)
+	CountUsers(ctx context.Context) (int64, error)
 	DeleteUser(ctx context.Context, id model.UserID) (time.Time, error)
 }
 
@@ -33,33 +36,214 @@ type accountQuery interface {
 	CreateAccount(ctx context.Context, args CreateAccountParams) (model.Account, error)
 	UpdateAccount(ctx context.Context, args UpdateAccountParams) (model.Account, error)
 	GetAccountByID(ctx context.Context, id model.AccountID) (model.Account, error)
+	GetAccountForUpdate(ctx context.Context, id model.AccountID) (model.Account, error)
+	AddAccountBalance(ctx context.Context, args AddAccountBalanceParams) (model.Account, error)
 	ListAccounts(ctx context.Context, args ListAccountParams) ([]model.Account, error)
+	CountAccounts(ctx context.Context, userID model.UserID) (int64, error)
 	DeleteAccount(ctx context.Context, id model.AccountID) (time.Time, error)
 }
 type categoryQuery interface {
 	CreateCategory(ctx context.Context, args CreateCategoryParams) (model.Category, error)
 	UpdateCategory(ctx context.Context, args UpdateCategoryParams) (model.Category, error)
+	MoveCategory(ctx context.Context, args MoveCategoryParams) (model.Category, error)
 	GetCategoryByID(ctx context.Context, id model.CategoryID) (model.Category, error)
 	ListCategories(ctx context.Context, args ListCategoryParams) ([]model.Category, error)
+	CountCategories(ctx context.Context, userID model.UserID) (int64, error)
 	DeleteCategory(ctx context.Context, id model.CategoryID) (time.Time, error)
+	ListAllCategories(ctx context.Context, userID model.UserID) ([]model.Category, error)
+	LockUserCategories(ctx context.Context, userID model.UserID) error
+	ReparentCategories(ctx context.Context, args ReparentCategoriesParams) (int64, error)
+	ReassignCategory(ctx context.Context, args ReassignCategoryParams) (model.CategoryMerge, error)
+	MergeCategoryBudgets(ctx context.Context, args ReassignCategoryParams) (int64, error)
+	CountCategoryUsage(ctx context.Context, id model.CategoryID) (int64, error)
+	ListCategorizedTransactions(ctx context.Context, args ListCategorizedTransactionsParams) ([]model.CategorizedTransaction, error)
 }
 
 type merchantQuery interface {
 	CreateMerchant(ctx context.Context, args CreateMerchantParams) (model.Merchant, error)
+	GetOrCreateMerchant(ctx context.Context, args CreateMerchantParams) (model.Merchant, error)
 	UpdateMerchant(ctx context.Context, args UpdateMerchantParams) (model.Merchant, error)
 	GetMerchantByID(ctx context.Context, id model.MerchantID) (model.Merchant, error)
 	ListMerchants(ctx context.Context, args ListMerchantParams) ([]model.Merchant, error)
+	CountMerchants(ctx context.Context, userID model.UserID) (int64, error)
 	DeleteMerchant(ctx context.Context, id model.MerchantID) (time.Time, error)
+	ListMerchantSpend(ctx context.Context, args ListMerchantSpendParams) ([]model.MerchantSpend, error)
 }
 
 type transactionQuery interface {
 	CreateTransaction(ctx context.Context, args CreateTransactionParams) (model.Transaction, error)
 	UpdateTransaction(ctx context.Context, args UpdateTransactionParams) (model.Transaction, error)
 	GetTransactionByID(ctx context.Context, id model.TransactionID) (model.Transaction, error)
+	GetTransactionForUpdate(ctx context.Context, id model.TransactionID) (model.Transaction, error)
 	ListTransactionsByUserID(ctx context.Context, args ListTxByUserIDParams) ([]model.Transaction, error) // we will filter with time frame
 	ListTransactionsByAccountID(ctx context.Context, args ListTxByAccountIDParams) ([]model.Transaction, error)
 	ListTransactionsByCategoryID(ctx context.Context, args ListTxByCategoryIDParams) ([]model.Transaction, error)
+	CountTransactionsByUserID(ctx context.Context, args ListTxByUserIDParams) (int64, error)
+	CountTransactionsByAccountID(ctx context.Context, args ListTxByAccountIDParams) (int64, error)
+	CountTransactionsByCategoryID(ctx context.Context, args ListTxByCategoryIDParams) (int64, error)
 	DeleteTransaction(ctx context.Context, id model.TransactionID) (time.Time, error)
+	ListTransactionsByTransferID(ctx context.Context, id model.TransferID) ([]model.Transaction, error)
+	DeleteTransactionsByTransferID(ctx context.Context, id model.TransferID) ([]model.Transaction, error)
+	ListTransactionsByMerchantID(ctx context.Context, args ListTxByMerchantIDParams) ([]model.Transaction, error)
+	CountMerchantTransactions(ctx context.Context, args ListTxByMerchantIDParams) (int64, error)
+	CountTransactionsByMerchantID(ctx context.Context, id model.MerchantID) (int64, error)
+	ReassignMerchant(ctx context.Context, args ReassignMerchantParams) (int64, error)
+	SearchTransactions(ctx context.Context, args SearchTransactionsParams) ([]model.Transaction, error)
+	CountSearchTransactions(ctx context.Context, args SearchTransactionsParams) (int64, error)
+	UpdateTransactionStatus(ctx context.Context, args UpdateTransactionStatusParams) (model.Transaction, error)
+	GetPendingAmount(ctx context.Context, id model.AccountID) (int64, error)
+}
+
+type duplicateQuery interface {
+	ListDuplicateCandidates(ctx context.Context, args ListDuplicateCandidatesParams) ([]model.Transaction, error)
+	ListDuplicatePairs(ctx context.Context, args ListDuplicatePairsParams) ([]model.DuplicatePair, error)
+	DismissDuplicate(ctx context.Context, args DismissDuplicateParams) error
+	MoveImportedTransactions(ctx context.Context, from, to model.TransactionID) error
+	FillTransactionDetails(ctx context.Context, args FillTransactionDetailsParams) (model.Transaction, error)
+}
+
+type reconciliationQuery interface {
+	CreateReconciliation(ctx context.Context, args CreateReconciliationParams) (model.Reconciliation, error)
+	GetReconciliationByID(ctx context.Context, id model.ReconciliationID) (model.Reconciliation, error)
+	GetReconciliationForUpdate(ctx context.Context, id model.ReconciliationID) (model.Reconciliation, error)
+	ListReconciliations(ctx context.Context, args ListReconciliationsParams) ([]model.Reconciliation, error)
+	CountReconciliations(ctx context.Context, id model.AccountID) (int64, error)
+	CompleteReconciliation(ctx context.Context, args CompleteReconciliationParams) (model.Reconciliation, error)
+	DeleteReconciliation(ctx context.Context, id model.ReconciliationID) (time.Time, error)
+	AddReconciliationTransaction(ctx context.Context, id model.ReconciliationID, transactionID model.TransactionID) error
+	ListUnreconciledTransactions(ctx context.Context, args ListUnreconciledTransactionsParams) ([]model.Transaction, error)
+	ListReconciliationTransactions(ctx context.Context, id model.ReconciliationID) ([]model.Transaction, error)
+	GetUnreconciledAmount(ctx context.Context, id model.AccountID) (int64, error)
+}
+
+type transferQuery interface {
+	CreateTransfer(ctx context.Context, args CreateTransferParams) (model.Transfer, error)
+	UpdateTransfer(ctx context.Context, args UpdateTransferParams) (model.Transfer, error)
+	GetTransferByID(ctx context.Context, id model.TransferID) (model.Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id model.TransferID) (model.Transfer, error)
+	ListTransfers(ctx context.Context, args ListTransfersParams) ([]model.Transfer, error)
+	CountTransfers(ctx context.Context, args ListTransfersParams) (int64, error)
+	DeleteTransfer(ctx context.Context, id model.TransferID) (time.Time, error)
+}
+
+// txQuery holds the writes that span more than one query and run within a single database transaction
+type txQuery interface {
+	CreateTransactionTx(ctx context.Context, args CreateTransactionParams) (model.Transaction, error)
+	UpdateTransactionTx(ctx context.Context, args UpdateTransactionParams) (model.Transaction, error)
+	DeleteTransactionTx(ctx context.Context, args DeleteTransactionParams) (time.Time, error)
+	CreateTransferTx(ctx context.Context, args CreateTransferParams) (model.Transfer, error)
+	UpdateTransferTx(ctx context.Context, args UpdateTransferParams) (model.Transfer, error)
+	DeleteTransferTx(ctx context.Context, args DeleteTransferParams) (time.Time, error)
+	DeleteMerchantTx(ctx context.Context, args DeleteMerchantParams) (time.Time, error)
+	CreateRecurringTx(ctx context.Context, args CreateRecurringParams) (model.RecurringTransaction, error)
+	UpdateRecurringTx(ctx context.Context, args UpdateRecurringParams) (model.RecurringTransaction, error)
+	PostRecurringTx(ctx context.Context, args PostRecurringParams) (model.Transaction, error)
+	ImportTransactionsTx(ctx context.Context, args ImportTransactionsParams) (model.ImportResult, error)
+	RestoreBackupTx(ctx context.Context, args RestoreBackupParams) (model.RestoreResult, error)
+	ImportExchangeRatesTx(ctx context.Context, rates []model.ExchangeRate) (int64, error)
+	MoveCategoryTx(ctx context.Context, args MoveCategoryParams) (model.Category, error)
+	MergeCategoriesTx(ctx context.Context, args MergeCategoriesParams) (model.CategoryMerge, error)
+	DeleteCategoryTx(ctx context.Context, args DeleteCategoryParams) (time.Time, error)
+	CreateUserTx(ctx context.Context, args SignupParams) (model.User, error)
+	ReorderRulesTx(ctx context.Context, args ReorderRulesParams) ([]model.Rule, error)
+	ApplyRulesTx(ctx context.Context, args ApplyRulesParams) ([]model.RuleChange, error)
+	MergeDuplicateTx(ctx context.Context, args MergeDuplicateParams) (model.Transaction, error)
+	SetTransactionStatusTx(ctx context.Context, args SetTransactionStatusParams) ([]model.Transaction, error)
+	CompleteReconciliationTx(ctx context.Context, args CompleteReconciliationTxParams) (model.Reconciliation, error)
+}
+
+type splitQuery interface {
+	CreateSplit(ctx context.Context, args CreateSplitParams) (model.TransactionSplit, error)
+	ListSplitsByTransactionID(ctx context.Context, id model.TransactionID) ([]model.TransactionSplit, error)
+	ListSplitsByTransactionIDs(ctx context.Context, ids []model.TransactionID) ([]model.TransactionSplit, error)
+	DeleteSplitsByTransactionID(ctx context.Context, id model.TransactionID) error
+}
+
+type postingQuery interface {
+	CreatePosting(ctx context.Context, args CreatePostingParams) (model.Posting, error)
+	ListPostingsByTransactionID(ctx context.Context, id model.TransactionID) ([]model.Posting, error)
+	ReversePostingsByTransactionID(ctx context.Context, id model.TransactionID) error
+	TrialBalance(ctx context.Context, args TrialBalanceParams) ([]model.TrialBalanceLine, error)
+	ListAccountImbalances(ctx context.Context, userID model.UserID) ([]model.AccountImbalance, error)
+}
+
+type recurringQuery interface {
+	CreateRecurring(ctx context.Context, args CreateRecurringParams) (model.RecurringTransaction, error)
+	UpdateRecurring(ctx context.Context, args UpdateRecurringParams) (model.RecurringTransaction, error)
+	GetRecurringByID(ctx context.Context, id model.RecurringID) (model.RecurringTransaction, error)
+	GetRecurringForUpdate(ctx context.Context, id model.RecurringID) (model.RecurringTransaction, error)
+	ListRecurring(ctx context.Context, args ListRecurringParams) ([]model.RecurringTransaction, error)
+	ListDueRecurring(ctx context.Context, args ListDueRecurringParams) ([]model.RecurringID, error)
+	SetRecurringNextRun(ctx context.Context, args SetRecurringNextRunParams) error
+	DeleteRecurring(ctx context.Context, args DeleteRecurringParams) (time.Time, error)
+}
+
+type budgetQuery interface {
+	CreateBudget(ctx context.Context, args CreateBudgetParams) (model.Budget, error)
+	UpdateBudget(ctx context.Context, args UpdateBudgetParams) (model.Budget, error)
+	ListBudgetHistory(ctx context.Context, args ListBudgetHistoryParams) ([]model.BudgetProgress, error)
+	DeleteBudget(ctx context.Context, args DeleteBudgetParams) (time.Time, error)
+}
+
+type importQuery interface {
+	CreateImportMapping(ctx context.Context, args CreateImportMappingParams) (model.ImportMapping, error)
+	GetImportMappingByID(ctx context.Context, id model.ImportMappingID) (model.ImportMapping, error)
+	ListImportMappings(ctx context.Context, userID model.UserID) ([]model.ImportMapping, error)
+	DeleteImportMapping(ctx context.Context, args DeleteImportMappingParams) (time.Time, error)
+	CreateImportedTransaction(ctx context.Context, args CreateImportedTransactionParams) error
+	ListImportedExternalIDs(ctx context.Context, args ListImportedExternalIDsParams) ([]string, error)
+	GetAccountAmountAfter(ctx context.Context, args GetAccountAmountAfterParams) (int64, error)
+}
+
+type exportQuery interface {
+	ExportAccounts(ctx context.Context, userID model.UserID) ([]model.Account, error)
+	ExportCategories(ctx context.Context, userID model.UserID) ([]model.Category, error)
+	ExportMerchants(ctx context.Context, userID model.UserID) ([]model.Merchant, error)
+	ExportTransactions(ctx context.Context, args ExportTransactionsParams) ([]model.Transaction, error)
+	ExportTransfers(ctx context.Context, userID model.UserID) ([]model.Transfer, error)
+	ExportBudgets(ctx context.Context, userID model.UserID) ([]model.Budget, error)
+	ExportRecurring(ctx context.Context, userID model.UserID) ([]model.RecurringTransaction, error)
+}
+
+type backupQuery interface {
+	RestoreAccount(ctx context.Context, args CreateAccountParams) (RestoreAccountRow, error)
+	RestoreCategory(ctx context.Context, args CreateCategoryParams) (RestoreCategoryRow, error)
+	RestoreMerchant(ctx context.Context, args CreateMerchantParams) (RestoreMerchantRow, error)
+	RestoreBudget(ctx context.Context, args CreateBudgetParams) (int64, error)
+	RestoreImportMapping(ctx context.Context, args CreateImportMappingParams) (int64, error)
+}
+
+type exchangeRateQuery interface {
+	UpsertExchangeRates(ctx context.Context, rates []model.ExchangeRate) (int64, error)
+	ConvertAmount(ctx context.Context, args ConvertAmountParams) (model.Conversion, error)
+	ListConvertedBalances(ctx context.Context, args ListConvertedBalancesParams) ([]model.ConvertedBalance, error)
+}
+
+type reportQuery interface {
+	SummaryReport(ctx context.Context, args SummaryReportParams) ([]model.PeriodSummary, error)
+	CategorySpendReport(ctx context.Context, args CategorySpendReportParams) ([]model.CategorySpend, error)
+}
+
+type signupTemplateQuery interface {
+	CreateSignupTemplate(ctx context.Context, args CreateSignupTemplateParams) (model.SignupTemplate, error)
+	UpdateSignupTemplate(ctx context.Context, args UpdateSignupTemplateParams) (model.SignupTemplate, error)
+	GetSignupTemplateByID(ctx context.Context, id model.SignupTemplateID) (model.SignupTemplate, error)
+	GetSignupTemplateForLocale(ctx context.Context, locales []string) (model.SignupTemplate, error)
+	ListSignupTemplates(ctx context.Context) ([]model.SignupTemplate, error)
+	DeleteSignupTemplate(ctx context.Context, id model.SignupTemplateID) (time.Time, error)
+}
+
+type ruleQuery interface {
+	CreateRule(ctx context.Context, args CreateRuleParams) (model.Rule, error)
+	UpdateRule(ctx context.Context, args UpdateRuleParams) (model.Rule, error)
+	GetRuleByID(ctx context.Context, id model.RuleID) (model.Rule, error)
+	ListRules(ctx context.Context, userID model.UserID) ([]model.Rule, error)
+	SetRulePosition(ctx context.Context, args SetRulePositionParams) (int64, error)
+	DeleteRule(ctx context.Context, args DeleteRuleParams) (time.Time, error)
+	ReassignRuleCategory(ctx context.Context, args ReassignCategoryParams) (int64, error)
+	ReassignRuleMerchant(ctx context.Context, args ReassignMerchantParams) (int64, error)
+	ListRuleTransactions(ctx context.Context, args ListRuleTransactionsParams) ([]model.Transaction, error)
+	ApplyRuleChange(ctx context.Context, change model.RuleChange) error
 }
 
 type QueryInterface interface {
@@ -71,6 +255,20 @@ type QueryInterface interface {
 	categoryQuery
 	merchantQuery
 	transactionQuery
+	transferQuery
+	splitQuery
+	postingQuery
+	recurringQuery
+	budgetQuery
+	importQuery
+	exportQuery
+	backupQuery
+	exchangeRateQuery
+	reportQuery
+	signupTemplateQuery
+	ruleQuery
+	duplicateQuery
+	reconciliationQuery
 }
 
 // we want to ensure all our methods in the interface are implemented by our Queries struct
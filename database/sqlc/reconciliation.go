@@ -0,0 +1,354 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"time"
+)
+
+const createReconciliation = `--name: CreateReconciliation :one
+INSERT INTO reconciliations (user_id, account_id, statement_date, statement_balance, currency)
+SELECT $1, account_id, $3, $4, currency FROM accounts
+WHERE account_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING reconciliation_id, user_id, account_id, statement_date, statement_balance, opening_balance, transaction_count, status, currency, created_at, completed_at, deleted_at`
+
+type CreateReconciliationParams struct {
+	UserID           model.UserID    `json:"user_id"`
+	AccountID        model.AccountID `json:"account_id"`
+	StatementDate    time.Time       `json:"statement_date"`
+	StatementBalance int64           `json:"statement_balance"`
+}
+
+// CreateReconciliation opens a reconciliation of an account in its currency, an account with an open
+// reconciliation already fails the unique index of open reconciliations
+func (q *Queries) CreateReconciliation(ctx context.Context, args CreateReconciliationParams) (model.Reconciliation, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> CreateReconciliation()").Debug()
+	row := q.db.QueryRowContext(ctx, createReconciliation, args.UserID, args.AccountID, args.StatementDate,
+		args.StatementBalance)
+	var reconciliation model.Reconciliation
+	err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.UserID,
+		&reconciliation.AccountID,
+		&reconciliation.StatementDate,
+		&reconciliation.StatementBalance,
+		&reconciliation.OpeningBalance,
+		&reconciliation.TransactionCount,
+		&reconciliation.Status,
+		&reconciliation.Currency,
+		&reconciliation.CreatedAt,
+		&reconciliation.CompletedAt,
+		&reconciliation.DeletedAt,
+	)
+	return reconciliation, err
+}
+
+const getReconciliation = `--name: GetReconciliationByID :one
+SELECT reconciliation_id, user_id, account_id, statement_date, statement_balance, opening_balance, transaction_count,
+       status, currency, created_at, completed_at, deleted_at
+FROM reconciliations
+WHERE reconciliation_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1`
+
+// GetReconciliationByID returns a reconciliation that was not cancelled
+func (q *Queries) GetReconciliationByID(ctx context.Context, id model.ReconciliationID) (model.Reconciliation, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> GetReconciliationByID()").Debug()
+	row := q.db.QueryRowContext(ctx, getReconciliation, id)
+	var reconciliation model.Reconciliation
+	err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.UserID,
+		&reconciliation.AccountID,
+		&reconciliation.StatementDate,
+		&reconciliation.StatementBalance,
+		&reconciliation.OpeningBalance,
+		&reconciliation.TransactionCount,
+		&reconciliation.Status,
+		&reconciliation.Currency,
+		&reconciliation.CreatedAt,
+		&reconciliation.CompletedAt,
+		&reconciliation.DeletedAt,
+	)
+	return reconciliation, err
+}
+
+const getReconciliationForUpdate = `--name: GetReconciliationForUpdate :one
+SELECT reconciliation_id, user_id, account_id, statement_date, statement_balance, opening_balance, transaction_count,
+       status, currency, created_at, completed_at, deleted_at
+FROM reconciliations
+WHERE reconciliation_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE`
+
+// GetReconciliationForUpdate locks a reconciliation so it is completed or cancelled only once
+func (q *Queries) GetReconciliationForUpdate(ctx context.Context, id model.ReconciliationID) (model.Reconciliation, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> GetReconciliationForUpdate()").Debug()
+	row := q.db.QueryRowContext(ctx, getReconciliationForUpdate, id)
+	var reconciliation model.Reconciliation
+	err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.UserID,
+		&reconciliation.AccountID,
+		&reconciliation.StatementDate,
+		&reconciliation.StatementBalance,
+		&reconciliation.OpeningBalance,
+		&reconciliation.TransactionCount,
+		&reconciliation.Status,
+		&reconciliation.Currency,
+		&reconciliation.CreatedAt,
+		&reconciliation.CompletedAt,
+		&reconciliation.DeletedAt,
+	)
+	return reconciliation, err
+}
+
+const listReconciliations = `--name: ListReconciliations :many
+SELECT reconciliation_id, user_id, account_id, statement_date, statement_balance, opening_balance, transaction_count,
+       status, currency, created_at, completed_at, deleted_at
+FROM reconciliations
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND ($4::text = '' OR (statement_date, reconciliation_id) < ($3::date, NULLIF($4::text, '')::uuid))
+ORDER BY statement_date DESC, reconciliation_id DESC
+LIMIT $2`
+
+// ListReconciliationsParams lists the reconciliations of an account, latest statement first. Cursor is the last
+// reconciliation of the previous page
+type ListReconciliationsParams struct {
+	AccountID model.AccountID `json:"account_id"`
+	Limit     int32           `json:"limit"`
+	Cursor    model.Cursor    `json:"cursor"`
+}
+
+// ListReconciliations returns the reconciliation history of an account along with its open reconciliation
+func (q *Queries) ListReconciliations(ctx context.Context, args ListReconciliationsParams) ([]model.Reconciliation, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> ListReconciliations()").Debug()
+	rows, err := q.db.QueryContext(ctx, listReconciliations, args.AccountID, args.Limit, args.Cursor.Date, args.Cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var reconciliations []model.Reconciliation
+	for rows.Next() {
+		var reconciliation model.Reconciliation
+		err = rows.Scan(
+			&reconciliation.ID,
+			&reconciliation.UserID,
+			&reconciliation.AccountID,
+			&reconciliation.StatementDate,
+			&reconciliation.StatementBalance,
+			&reconciliation.OpeningBalance,
+			&reconciliation.TransactionCount,
+			&reconciliation.Status,
+			&reconciliation.Currency,
+			&reconciliation.CreatedAt,
+			&reconciliation.CompletedAt,
+			&reconciliation.DeletedAt,
+		)
+		reconciliations = append(reconciliations, reconciliation)
+	}
+	return reconciliations, err
+}
+
+const countReconciliations = `--name: CountReconciliations :one
+SELECT COUNT(*) FROM reconciliations
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'`
+
+// CountReconciliations counts the reconciliations ListReconciliations pages through
+func (q *Queries) CountReconciliations(ctx context.Context, id model.AccountID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> CountReconciliations()").Debug()
+	row := q.db.QueryRowContext(ctx, countReconciliations, id)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const completeReconciliation = `--name: CompleteReconciliation :one
+UPDATE reconciliations SET status = 'completed',
+opening_balance = $2,
+transaction_count = $3,
+completed_at = now()
+WHERE reconciliation_id = $1
+AND status = 'open'
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING reconciliation_id, user_id, account_id, statement_date, statement_balance, opening_balance, transaction_count, status, currency, created_at, completed_at, deleted_at`
+
+type CompleteReconciliationParams struct {
+	ReconciliationID model.ReconciliationID `json:"reconciliation_id"`
+	OpeningBalance   int64                  `json:"opening_balance"`
+	TransactionCount int32                  `json:"transaction_count"`
+}
+
+// CompleteReconciliation closes an open reconciliation, it becomes part of the reconciliation history of its
+// account
+func (q *Queries) CompleteReconciliation(ctx context.Context, args CompleteReconciliationParams) (model.Reconciliation, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> CompleteReconciliation()").Debug()
+	row := q.db.QueryRowContext(ctx, completeReconciliation, args.ReconciliationID, args.OpeningBalance,
+		args.TransactionCount)
+	var reconciliation model.Reconciliation
+	err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.UserID,
+		&reconciliation.AccountID,
+		&reconciliation.StatementDate,
+		&reconciliation.StatementBalance,
+		&reconciliation.OpeningBalance,
+		&reconciliation.TransactionCount,
+		&reconciliation.Status,
+		&reconciliation.Currency,
+		&reconciliation.CreatedAt,
+		&reconciliation.CompletedAt,
+		&reconciliation.DeletedAt,
+	)
+	return reconciliation, err
+}
+
+const deleteReconciliation = `--name: DeleteReconciliation :one
+UPDATE reconciliations SET deleted_at = now()
+WHERE reconciliation_id = $1
+AND status = 'open'
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at`
+
+// DeleteReconciliation cancels an open reconciliation, completed ones are kept as history
+func (q *Queries) DeleteReconciliation(ctx context.Context, id model.ReconciliationID) (time.Time, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> DeleteReconciliation()").Debug()
+	row := q.db.QueryRowContext(ctx, deleteReconciliation, id)
+	var deletedAt time.Time
+	err := row.Scan(&deletedAt)
+	return deletedAt, err
+}
+
+const addReconciliationTransaction = `--name: AddReconciliationTransaction :exec
+INSERT INTO reconciliation_transactions (reconciliation_id, transaction_id)
+VALUES ($1, $2)`
+
+// AddReconciliationTransaction records a transaction was reconciled by a reconciliation
+func (q *Queries) AddReconciliationTransaction(ctx context.Context, id model.ReconciliationID, transactionID model.TransactionID) error {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> AddReconciliationTransaction()").Debug()
+	_, err := q.db.ExecContext(ctx, addReconciliationTransaction, id, transactionID)
+	return err
+}
+
+const listUnreconciledTransactions = `--name: ListUnreconciledTransactions :many
+SELECT transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at,
+       deleted_at, transfer_id, merchant_id, currency, status
+FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND status <> 'reconciled'
+AND date::date <= $2::date
+ORDER BY date, transaction_id`
+
+type ListUnreconciledTransactionsParams struct {
+	AccountID model.AccountID `json:"account_id"`
+	// StatementDate is the last day of the statement, transactions made after it in the time zone of the database
+	// are left out
+	StatementDate time.Time `json:"statement_date"`
+}
+
+// ListUnreconciledTransactions returns the transactions of an account made up to the statement date that are not
+// reconciled yet, oldest first
+func (q *Queries) ListUnreconciledTransactions(ctx context.Context, args ListUnreconciledTransactionsParams) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> ListUnreconciledTransactions()").Debug()
+	rows, err := q.db.QueryContext(ctx, listUnreconciledTransactions, args.AccountID, args.StatementDate)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const listReconciliationTransactions = `--name: ListReconciliationTransactions :many
+SELECT t.transaction_id, t.user_id, t.account_id, t.category_id, t.name, t.transaction_type, t.amount, t.notes, t.date,
+       t.created_at, t.deleted_at, t.transfer_id, t.merchant_id, t.currency, t.status
+FROM transactions t
+JOIN reconciliation_transactions rt ON rt.transaction_id = t.transaction_id
+WHERE rt.reconciliation_id = $1
+ORDER BY t.date, t.transaction_id`
+
+// ListReconciliationTransactions returns the transactions a reconciliation reconciled, those deleted or unlocked
+// since included, oldest first
+func (q *Queries) ListReconciliationTransactions(ctx context.Context, id model.ReconciliationID) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> ListReconciliationTransactions()").Debug()
+	rows, err := q.db.QueryContext(ctx, listReconciliationTransactions, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const getUnreconciledAmount = `--name: GetUnreconciledAmount :one
+SELECT COALESCE(SUM(CASE WHEN transaction_type IN ('expense', 'transfer_out') THEN -amount ELSE amount END), 0)::bigint
+FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND status <> 'reconciled'`
+
+// GetUnreconciledAmount returns the effect the transactions of an account that are not reconciled have on its
+// balance, the reconciled balance is the balance without it
+func (q *Queries) GetUnreconciledAmount(ctx context.Context, id model.AccountID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/reconciliation.go -> GetUnreconciledAmount()").Debug()
+	row := q.db.QueryRowContext(ctx, getUnreconciledAmount, id)
+	var amount int64
+	err := row.Scan(&amount)
+	return amount, err
+}
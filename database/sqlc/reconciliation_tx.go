@@ -0,0 +1,119 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrReconciliationClosed is returned when a reconciliation that was completed is completed or cancelled again
+	ErrReconciliationClosed = errors.New("reconciliation is already completed")
+	// ErrNotReconcilable is returned when a reconciliation is completed with a transaction that is not an
+	// unreconciled transaction of its account made before the end of its statement
+	ErrNotReconcilable = errors.New("transaction is not an unreconciled transaction of the account on the statement")
+	// ErrReconciliationUnbalanced is returned when the transactions a reconciliation is completed with do not make
+	// up the statement balance
+	ErrReconciliationUnbalanced = errors.New("reconciled transactions do not add up to the statement balance")
+)
+
+type CompleteReconciliationTxParams struct {
+	UserID           model.UserID           `json:"user_id"`
+	ReconciliationID model.ReconciliationID `json:"reconciliation_id"`
+	// TransactionIDs are the transactions on the statement, they are reconciled
+	TransactionIDs []model.TransactionID `json:"transaction_ids"`
+}
+
+// CompleteReconciliationTx reconciles the transactions on the statement of an open reconciliation and completes
+// it. The balance of the transactions reconciled before and the ones reconciled now has to be the statement
+// balance, otherwise nothing is reconciled
+func (r SQLRepo) CompleteReconciliationTx(ctx context.Context, args CompleteReconciliationTxParams) (model.Reconciliation, error) {
+	r.logs.WithField("func", "database/sqlc/reconciliation_tx.go -> CompleteReconciliationTx()").Debug()
+	var reconciliation model.Reconciliation
+	err := r.execTx(ctx, func(q *Queries) error {
+		open, err := q.GetReconciliationForUpdate(ctx, args.ReconciliationID)
+		if err != nil {
+			return err
+		}
+		if open.UserID != args.UserID {
+			return sql.ErrNoRows
+		}
+		if open.Status != model.ReconciliationOpen {
+			return ErrReconciliationClosed
+		}
+
+		// the transactions are locked before the account, in a consistent order, as updates and deletes do
+		ids := append([]model.TransactionID(nil), args.TransactionIDs...)
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		var transactions []model.Transaction
+		var amount int64
+		for i, id := range ids {
+			if i > 0 && id == ids[i-1] {
+				continue
+			}
+			transaction, err := q.GetTransactionForUpdate(ctx, id)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotReconcilable
+			}
+			if err != nil {
+				return err
+			}
+			if transaction.UserID != args.UserID || transaction.AccountID != open.AccountID {
+				return ErrNotReconcilable
+			}
+			amount += transaction.TransactionType.SignedAmount(transaction.Amount)
+			transactions = append(transactions, transaction)
+		}
+		// whether a transaction falls on the statement is left to the database, which knows what day it was made on
+		onStatement, err := q.ListUnreconciledTransactions(ctx, ListUnreconciledTransactionsParams{
+			AccountID:     open.AccountID,
+			StatementDate: open.StatementDate,
+		})
+		if err != nil {
+			return err
+		}
+		unreconciledIDs := make(map[model.TransactionID]bool, len(onStatement))
+		for _, transaction := range onStatement {
+			unreconciledIDs[transaction.ID] = true
+		}
+		for _, transaction := range transactions {
+			if !unreconciledIDs[transaction.ID] {
+				return ErrNotReconcilable
+			}
+		}
+		accounts, err := q.lockOwnedAccounts(ctx, args.UserID, open.AccountID)
+		if err != nil {
+			return err
+		}
+		unreconciled, err := q.GetUnreconciledAmount(ctx, open.AccountID)
+		if err != nil {
+			return err
+		}
+		opening := accounts[open.AccountID].Balance - unreconciled
+		if opening+amount != open.StatementBalance {
+			return ErrReconciliationUnbalanced
+		}
+
+		for _, transaction := range transactions {
+			_, err = q.UpdateTransactionStatus(ctx, UpdateTransactionStatusParams{
+				TransactionID: transaction.ID,
+				Status:        model.Reconciled,
+			})
+			if err != nil {
+				return err
+			}
+			if err = q.AddReconciliationTransaction(ctx, open.ID, transaction.ID); err != nil {
+				return err
+			}
+		}
+		reconciliation, err = q.CompleteReconciliation(ctx, CompleteReconciliationParams{
+			ReconciliationID: open.ID,
+			OpeningBalance:   opening,
+			TransactionCount: int32(len(transactions)),
+		})
+		return err
+	})
+	return reconciliation, err
+}
@@ -0,0 +1,313 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"github.com/lib/pq"
+	"time"
+)
+
+const createRecurring = `--name: CreateRecurring :one
+INSERT INTO recurring_transactions (user_id, account_id, category_id, merchant_id, name, transaction_type, amount,
+notes, rule, start_date, end_date, skip_dates, next_run)
+VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11, COALESCE($12::date[], '{}'), $13)
+RETURNING recurring_id, user_id, account_id, category_id, merchant_id, name, transaction_type, amount, notes, rule,
+start_date, end_date, skip_dates, next_run, created_at, deleted_at`
+
+type CreateRecurringParams struct {
+	UserID          model.UserID          `json:"user_id"`
+	AccountID       model.AccountID       `json:"account_id"`
+	CategoryID      model.CategoryID      `json:"category_id"`
+	MerchantID      model.MerchantID      `json:"merchant_id"`
+	Name            string                `json:"name"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+	Amount          int64                 `json:"amount"`
+	Notes           string                `json:"notes"`
+	Rule            string                `json:"rule"`
+	StartDate       time.Time             `json:"start_date"`
+	EndDate         time.Time             `json:"end_date"`
+	SkipDates       []string              `json:"skip_dates"`
+	NextRun         time.Time             `json:"next_run"`
+}
+
+func (q *Queries) CreateRecurring(ctx context.Context, args CreateRecurringParams) (model.RecurringTransaction, error) {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> CreateRecurring()").Debug()
+	row := q.db.QueryRowContext(ctx, createRecurring, args.UserID, args.AccountID, args.CategoryID, args.MerchantID,
+		args.Name, args.TransactionType, args.Amount, args.Notes, args.Rule, args.StartDate, args.EndDate,
+		pq.Array(args.SkipDates), args.NextRun)
+	var recurring model.RecurringTransaction
+	err := row.Scan(
+		&recurring.ID,
+		&recurring.UserID,
+		&recurring.AccountID,
+		&recurring.CategoryID,
+		&recurring.MerchantID,
+		&recurring.Name,
+		&recurring.TransactionType,
+		&recurring.Amount,
+		&recurring.Notes,
+		&recurring.Rule,
+		&recurring.StartDate,
+		&recurring.EndDate,
+		pq.Array(&recurring.SkipDates),
+		&recurring.NextRun,
+		&recurring.CreatedAt,
+		&recurring.DeletedAt,
+	)
+	return recurring, err
+}
+
+const updateRecurring = `--name: UpdateRecurring :one
+UPDATE recurring_transactions SET account_id = $3,
+category_id = $4,
+merchant_id = NULLIF($5, '')::uuid,
+name = $6,
+transaction_type = $7,
+amount = $8,
+notes = $9,
+rule = $10,
+start_date = $11,
+end_date = $12,
+skip_dates = COALESCE($13::date[], '{}'),
+next_run = $14
+WHERE recurring_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING recurring_id, user_id, account_id, category_id, merchant_id, name, transaction_type, amount, notes, rule,
+start_date, end_date, skip_dates, next_run, created_at, deleted_at`
+
+type UpdateRecurringParams struct {
+	RecurringID     model.RecurringID     `json:"recurring_id"`
+	UserID          model.UserID          `json:"user_id"`
+	AccountID       model.AccountID       `json:"account_id"`
+	CategoryID      model.CategoryID      `json:"category_id"`
+	MerchantID      model.MerchantID      `json:"merchant_id"`
+	Name            string                `json:"name"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+	Amount          int64                 `json:"amount"`
+	Notes           string                `json:"notes"`
+	Rule            string                `json:"rule"`
+	StartDate       time.Time             `json:"start_date"`
+	EndDate         time.Time             `json:"end_date"`
+	SkipDates       []string              `json:"skip_dates"`
+	NextRun         time.Time             `json:"next_run"`
+}
+
+func (q *Queries) UpdateRecurring(ctx context.Context, args UpdateRecurringParams) (model.RecurringTransaction, error) {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> UpdateRecurring()").Debug()
+	row := q.db.QueryRowContext(ctx, updateRecurring, args.RecurringID, args.UserID, args.AccountID, args.CategoryID,
+		args.MerchantID, args.Name, args.TransactionType, args.Amount, args.Notes, args.Rule, args.StartDate,
+		args.EndDate, pq.Array(args.SkipDates), args.NextRun)
+	var recurring model.RecurringTransaction
+	err := row.Scan(
+		&recurring.ID,
+		&recurring.UserID,
+		&recurring.AccountID,
+		&recurring.CategoryID,
+		&recurring.MerchantID,
+		&recurring.Name,
+		&recurring.TransactionType,
+		&recurring.Amount,
+		&recurring.Notes,
+		&recurring.Rule,
+		&recurring.StartDate,
+		&recurring.EndDate,
+		pq.Array(&recurring.SkipDates),
+		&recurring.NextRun,
+		&recurring.CreatedAt,
+		&recurring.DeletedAt,
+	)
+	return recurring, err
+}
+
+const getRecurring = `--name: GetRecurringByID :one
+SELECT * FROM recurring_transactions
+WHERE recurring_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1`
+
+func (q *Queries) GetRecurringByID(ctx context.Context, id model.RecurringID) (model.RecurringTransaction, error) {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> GetRecurringByID()").Debug()
+	row := q.db.QueryRowContext(ctx, getRecurring, id)
+	var recurring model.RecurringTransaction
+	err := row.Scan(
+		&recurring.ID,
+		&recurring.UserID,
+		&recurring.AccountID,
+		&recurring.CategoryID,
+		&recurring.MerchantID,
+		&recurring.Name,
+		&recurring.TransactionType,
+		&recurring.Amount,
+		&recurring.Notes,
+		&recurring.Rule,
+		&recurring.StartDate,
+		&recurring.EndDate,
+		pq.Array(&recurring.SkipDates),
+		&recurring.NextRun,
+		&recurring.CreatedAt,
+		&recurring.DeletedAt,
+	)
+	return recurring, err
+}
+
+const getRecurringForUpdate = `--name: GetRecurringForUpdate :one
+SELECT * FROM recurring_transactions
+WHERE recurring_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE`
+
+// GetRecurringForUpdate returns the recurring transaction and locks its row until the surrounding database
+// transaction ends
+func (q *Queries) GetRecurringForUpdate(ctx context.Context, id model.RecurringID) (model.RecurringTransaction, error) {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> GetRecurringForUpdate()").Debug()
+	row := q.db.QueryRowContext(ctx, getRecurringForUpdate, id)
+	var recurring model.RecurringTransaction
+	err := row.Scan(
+		&recurring.ID,
+		&recurring.UserID,
+		&recurring.AccountID,
+		&recurring.CategoryID,
+		&recurring.MerchantID,
+		&recurring.Name,
+		&recurring.TransactionType,
+		&recurring.Amount,
+		&recurring.Notes,
+		&recurring.Rule,
+		&recurring.StartDate,
+		&recurring.EndDate,
+		pq.Array(&recurring.SkipDates),
+		&recurring.NextRun,
+		&recurring.CreatedAt,
+		&recurring.DeletedAt,
+	)
+	return recurring, err
+}
+
+const listRecurring = `--name: ListRecurring :many
+SELECT * FROM recurring_transactions
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY created_at, recurring_id
+LIMIT  $2
+OFFSET $3`
+
+type ListRecurringParams struct {
+	UserID model.UserID `json:"user_id"`
+	Limit  int32        `json:"limit"`
+	Offset int32        `json:"offset"`
+}
+
+func (q *Queries) ListRecurring(ctx context.Context, args ListRecurringParams) ([]model.RecurringTransaction, error) {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> ListRecurring()").Debug()
+	rows, err := q.db.QueryContext(ctx, listRecurring, args.UserID, args.Limit, args.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var recurrings []model.RecurringTransaction
+	for rows.Next() {
+		var recurring model.RecurringTransaction
+		err = rows.Scan(
+			&recurring.ID,
+			&recurring.UserID,
+			&recurring.AccountID,
+			&recurring.CategoryID,
+			&recurring.MerchantID,
+			&recurring.Name,
+			&recurring.TransactionType,
+			&recurring.Amount,
+			&recurring.Notes,
+			&recurring.Rule,
+			&recurring.StartDate,
+			&recurring.EndDate,
+			pq.Array(&recurring.SkipDates),
+			&recurring.NextRun,
+			&recurring.CreatedAt,
+			&recurring.DeletedAt,
+		)
+		recurrings = append(recurrings, recurring)
+	}
+	return recurrings, err
+}
+
+const listDueRecurring = `--name: ListDueRecurring :many
+SELECT r.recurring_id FROM recurring_transactions r
+JOIN accounts a ON a.account_id = r.account_id
+JOIN categories c ON c.category_id = r.category_id
+WHERE r.deleted_at = '0001-01-01 00:00:00Z'
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+AND c.deleted_at = '0001-01-01 00:00:00Z'
+AND r.next_run > '0001-01-01 00:00:00Z'
+AND r.next_run <= $1
+ORDER BY r.next_run
+LIMIT $2`
+
+type ListDueRecurringParams struct {
+	Now   time.Time `json:"now"`
+	Limit int32     `json:"limit"`
+}
+
+// ListDueRecurring returns the recurring transactions of every user that have an occurrence due by now, those on
+// a deleted account or category are left out as they cannot be posted
+func (q *Queries) ListDueRecurring(ctx context.Context, args ListDueRecurringParams) ([]model.RecurringID, error) {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> ListDueRecurring()").Debug()
+	rows, err := q.db.QueryContext(ctx, listDueRecurring, args.Now, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var ids []model.RecurringID
+	for rows.Next() {
+		var id model.RecurringID
+		err = rows.Scan(
+			&id,
+		)
+		ids = append(ids, id)
+	}
+	return ids, err
+}
+
+const setRecurringNextRun = `--name: SetRecurringNextRun :exec
+UPDATE recurring_transactions SET next_run = $2
+WHERE recurring_id = $1`
+
+type SetRecurringNextRunParams struct {
+	RecurringID model.RecurringID `json:"recurring_id"`
+	NextRun     time.Time         `json:"next_run"`
+}
+
+func (q *Queries) SetRecurringNextRun(ctx context.Context, args SetRecurringNextRunParams) error {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> SetRecurringNextRun()").Debug()
+	_, err := q.db.ExecContext(ctx, setRecurringNextRun, args.RecurringID, args.NextRun)
+	return err
+}
+
+const deleteRecurring = `--name: DeleteRecurring :one
+UPDATE recurring_transactions SET deleted_at = now()
+WHERE recurring_id = $1
+  AND user_id = $2
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at`
+
+type DeleteRecurringParams struct {
+	RecurringID model.RecurringID `json:"recurring_id"`
+	UserID      model.UserID      `json:"user_id"`
+}
+
+func (q *Queries) DeleteRecurring(ctx context.Context, args DeleteRecurringParams) (time.Time, error) {
+	q.logs.WithField("func", "database/sqlc/recurring.go -> DeleteRecurring()").Debug()
+	row := q.db.QueryRowContext(ctx, deleteRecurring, args.RecurringID, args.UserID)
+	var recurring model.RecurringTransaction
+	err := row.Scan(
+		&recurring.DeletedAt,
+	)
+	return recurring.DeletedAt, err
+}
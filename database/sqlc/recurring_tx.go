@@ -0,0 +1,143 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrRecurringNotDue is returned when a recurring transaction has no occurrence due yet
+var ErrRecurringNotDue = errors.New("recurring transaction has no occurrence due")
+
+// CreateRecurringTx creates a recurring transaction on an account of the user, it is first due on the first
+// occurrence on or after its start date, so a start date in the past posts the occurrences already missed
+func (r SQLRepo) CreateRecurringTx(ctx context.Context, args CreateRecurringParams) (model.RecurringTransaction, error) {
+	r.logs.WithField("func", "database/sqlc/recurring_tx.go -> CreateRecurringTx()").Debug()
+	var recurring model.RecurringTransaction
+	err := r.execTx(ctx, func(q *Queries) error {
+		if err := q.checkCategories(ctx, args.UserID, args.CategoryID); err != nil {
+			return err
+		}
+		if err := q.checkMerchant(ctx, args.UserID, args.MerchantID); err != nil {
+			return err
+		}
+		if _, err := q.lockOwnedAccounts(ctx, args.UserID, args.AccountID); err != nil {
+			return err
+		}
+		var err error
+		args.NextRun, err = firstOccurrence(args.Rule, args.StartDate, args.EndDate, args.SkipDates, args.StartDate)
+		if err != nil {
+			return err
+		}
+		recurring, err = q.CreateRecurring(ctx, args)
+		return err
+	})
+	return recurring, err
+}
+
+// UpdateRecurringTx updates a recurring transaction of the user. Occurrences already posted are not posted
+// again, it is next due on the first occurrence of the updated rule on or after the one it was due on
+func (r SQLRepo) UpdateRecurringTx(ctx context.Context, args UpdateRecurringParams) (model.RecurringTransaction, error) {
+	r.logs.WithField("func", "database/sqlc/recurring_tx.go -> UpdateRecurringTx()").Debug()
+	var recurring model.RecurringTransaction
+	err := r.execTx(ctx, func(q *Queries) error {
+		old, err := q.GetRecurringForUpdate(ctx, args.RecurringID)
+		if err != nil {
+			return err
+		}
+		if old.UserID != args.UserID {
+			return sql.ErrNoRows
+		}
+		if err = q.checkCategories(ctx, args.UserID, args.CategoryID); err != nil {
+			return err
+		}
+		if err = q.checkMerchant(ctx, args.UserID, args.MerchantID); err != nil {
+			return err
+		}
+		if _, err = q.lockOwnedAccounts(ctx, args.UserID, args.AccountID); err != nil {
+			return err
+		}
+		// a recurring transaction with no occurrences left only picks up again from now
+		from := old.NextRun
+		if from.IsZero() {
+			from = time.Now()
+		}
+		args.NextRun, err = firstOccurrence(args.Rule, args.StartDate, args.EndDate, args.SkipDates, from)
+		if err != nil {
+			return err
+		}
+		recurring, err = q.UpdateRecurring(ctx, args)
+		return err
+	})
+	return recurring, err
+}
+
+// firstOccurrence returns the first occurrence of a rule on or after from, or the zero time when it has none
+func firstOccurrence(rule string, start, end time.Time, skipDates []string, from time.Time) (time.Time, error) {
+	template := model.RecurringTransaction{
+		Rule:      rule,
+		StartDate: start,
+		EndDate:   end,
+		SkipDates: skipDates,
+	}
+	dates, err := template.Occurrences(from, 1)
+	if err != nil || len(dates) == 0 {
+		return time.Time{}, err
+	}
+	return dates[0], nil
+}
+
+type PostRecurringParams struct {
+	RecurringID model.RecurringID `json:"recurring_id"`
+	// Now is the time occurrences have to be due by to be posted
+	Now time.Time `json:"now"`
+}
+
+// PostRecurringTx posts the occurrence a recurring transaction is next due on, through the same path a
+// transaction created by a user takes with the rules of the user applied, and moves it on to the occurrence after. The recurring transaction
+// stays locked while this happens so an occurrence is never posted twice
+func (r SQLRepo) PostRecurringTx(ctx context.Context, args PostRecurringParams) (model.Transaction, error) {
+	r.logs.WithField("func", "database/sqlc/recurring_tx.go -> PostRecurringTx()").Debug()
+	var transaction model.Transaction
+	err := r.execTx(ctx, func(q *Queries) error {
+		recurring, err := q.GetRecurringForUpdate(ctx, args.RecurringID)
+		if err != nil {
+			return err
+		}
+		if recurring.NextRun.IsZero() || recurring.NextRun.After(args.Now) {
+			return ErrRecurringNotDue
+		}
+		rules, err := q.ListRules(ctx, recurring.UserID)
+		if err != nil {
+			return err
+		}
+		posting := CreateTransactionParams{
+			UserID:          recurring.UserID,
+			AccountID:       recurring.AccountID,
+			CategoryID:      recurring.CategoryID,
+			Name:            recurring.Name,
+			TransactionType: recurring.TransactionType,
+			Amount:          recurring.Amount,
+			Notes:           recurring.Notes,
+			Date:            recurring.NextRun,
+			MerchantID:      recurring.MerchantID,
+		}
+		// the rules of the user fill in the merchant and notes the recurring transaction leaves out
+		ApplyTransactionRules(rules, &posting)
+		transaction, err = q.createTransaction(ctx, posting)
+		if err != nil {
+			return err
+		}
+		next, err := recurring.NextOccurrence(recurring.NextRun)
+		if err != nil {
+			return err
+		}
+		return q.SetRecurringNextRun(ctx, SetRecurringNextRunParams{
+			RecurringID: recurring.ID,
+			NextRun:     next,
+		})
+	})
+	return transaction, err
+}
@@ -2,11 +2,14 @@ package database
 
 import (
 	"FiberFinanceAPI/utils"
+	"context"
 	"database/sql"
+	"fmt"
 )
 
 type Repo interface {
 	QueryInterface
+	txQuery
 }
 
 type SQLRepo struct {
@@ -20,3 +23,20 @@ func NewRepo(db *sql.DB, log *utils.StandardLogger) Repo {
 		db:      db,
 	}
 }
+
+// execTx executes fn within a database transaction, it is rolled back if fn returns an error
+func (r SQLRepo) execTx(ctx context.Context, fn func(*Queries) error) error {
+	r.logs.WithField("func", "database/sqlc/repo.go -> execTx()").Debug()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = fn(r.WithTx(tx))
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+	"time"
+)
+
+const summaryReport = `--name: SummaryReport :many
+WITH periods AS (
+    SELECT generate_series(date_trunc($4, $2::timestamp), $3::timestamp - '1 microsecond'::interval, $5::interval)::date AS period
+), daily AS (
+    SELECT t.currency, t.date::date AS day, COUNT(*) AS transaction_count,
+    COALESCE(SUM(t.amount) FILTER (WHERE t.transaction_type = 'income'), 0)::BIGINT AS income,
+    COALESCE(SUM(t.amount) FILTER (WHERE t.transaction_type = 'expense'), 0)::BIGINT AS expense
+    FROM transactions t
+    WHERE t.user_id = $1
+    AND t.deleted_at = '0001-01-01 00:00:00Z'
+    AND t.transaction_type IN ('income', 'expense')
+    AND t.date >= $2
+    AND t.date < $3
+    GROUP BY t.currency, day
+), converted AS (
+    SELECT date_trunc($4, d.day::timestamp)::date AS period, d.transaction_count,
+    CASE WHEN d.currency = $6 THEN d.income ELSE convert_amount(d.income, d.currency, $6, d.day) END AS income,
+    CASE WHEN d.currency = $6 THEN d.expense ELSE convert_amount(d.expense, d.currency, $6, d.day) END AS expense
+    FROM daily d
+)
+SELECT p.period, COALESCE(SUM(c.transaction_count), 0)::BIGINT,
+COALESCE(SUM(c.income), 0)::BIGINT,
+COALESCE(SUM(c.expense), 0)::BIGINT,
+COUNT(c.period) FILTER (WHERE c.income IS NULL OR c.expense IS NULL)
+FROM periods p
+LEFT JOIN converted c ON c.period = p.period
+GROUP BY p.period
+ORDER BY p.period`
+
+// SummaryReportParams breaks the transactions of a user from From up to To into periods of Interval
+type SummaryReportParams struct {
+	UserID   model.UserID         `json:"user_id"`
+	From     time.Time            `json:"from"`
+	To       time.Time            `json:"to"`
+	Interval model.ReportInterval `json:"interval"`
+	// BaseCurrency is the currency the totals are converted to, at the rate of the day of each transaction. Amounts
+	// no rate is known for are left out of the totals
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
+}
+
+// SummaryReport totals the income and expense of a user in every period, periods without transactions included
+func (q *Queries) SummaryReport(ctx context.Context, args SummaryReportParams) ([]model.PeriodSummary, error) {
+	q.logs.WithField("func", "database/sqlc/report.go -> SummaryReport()").Debug()
+	rows, err := q.db.QueryContext(ctx, summaryReport, args.UserID, args.From, args.To, args.Interval,
+		args.Interval.Step(), args.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var periods []model.PeriodSummary
+	for rows.Next() {
+		var period model.PeriodSummary
+		err = rows.Scan(
+			&period.PeriodStart,
+			&period.TransactionCount,
+			&period.Income,
+			&period.Expense,
+			&period.MissingRates,
+		)
+		period.SetNet()
+		periods = append(periods, period)
+	}
+	return periods, err
+}
+
+const categorySpendReport = `--name: CategorySpendReport :many
+WITH RECURSIVE ancestors AS (
+    SELECT c.category_id, c.category_id AS ancestor_id
+    FROM categories c
+    WHERE c.user_id = $1
+    UNION
+    SELECT a.category_id, p.category_id
+    FROM ancestors a
+    JOIN categories child ON child.category_id = a.ancestor_id
+    JOIN categories p ON p.category_id::text = child.parent_id AND p.user_id = $1
+), daily AS (
+    SELECT ca.category_id, t.currency, t.date::date AS day, t.date >= $2 AS current, SUM(ca.amount)::BIGINT AS amount
+    FROM transactions t
+    JOIN transaction_category_amounts ca ON ca.transaction_id = t.transaction_id
+    WHERE t.user_id = $1
+    AND t.deleted_at = '0001-01-01 00:00:00Z'
+    AND t.transaction_type = $5
+    AND t.date >= $4
+    AND t.date < $3
+    GROUP BY ca.category_id, t.currency, day, current
+), direct AS (
+    SELECT d.category_id, d.current,
+    CASE WHEN d.currency = $6 THEN d.amount ELSE convert_amount(d.amount, d.currency, $6, d.day) END AS amount
+    FROM daily d
+)
+SELECT c.category_id, c.parent_id, c.name, c.deleted_at <> '0001-01-01 00:00:00Z',
+COALESCE(SUM(d.amount) FILTER (WHERE d.current AND a.category_id = c.category_id), 0)::BIGINT,
+COALESCE(SUM(d.amount) FILTER (WHERE d.current), 0)::BIGINT AS amount,
+COALESCE(SUM(d.amount) FILTER (WHERE NOT d.current), 0)::BIGINT,
+COUNT(d.category_id) FILTER (WHERE d.amount IS NULL)
+FROM categories c
+JOIN ancestors a ON a.ancestor_id = c.category_id
+JOIN direct d ON d.category_id = a.category_id
+WHERE c.user_id = $1
+GROUP BY c.category_id
+UNION ALL
+SELECT NULL, '', '', FALSE,
+COALESCE(SUM(d.amount) FILTER (WHERE d.current), 0)::BIGINT,
+COALESCE(SUM(d.amount) FILTER (WHERE d.current), 0)::BIGINT,
+COALESCE(SUM(d.amount) FILTER (WHERE NOT d.current), 0)::BIGINT,
+COUNT(*) FILTER (WHERE d.amount IS NULL)
+FROM direct d
+WHERE d.category_id IS NULL
+HAVING COUNT(*) > 0
+ORDER BY amount DESC, name`
+
+// CategorySpendReportParams compares the transactions of a type from From up to To with those of the period of
+// the same length before it, from PreviousFrom
+type CategorySpendReportParams struct {
+	UserID          model.UserID          `json:"user_id"`
+	From            time.Time             `json:"from"`
+	To              time.Time             `json:"to"`
+	PreviousFrom    time.Time             `json:"previous_from"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+	// BaseCurrency is the currency the totals are converted to, at the rate of the day of each transaction. Amounts
+	// no rate is known for are left out of the totals
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
+}
+
+// CategorySpendReport totals the transactions of every category of the user that had any in either period, the
+// amount of a category rolls up those of all its subcategories
+func (q *Queries) CategorySpendReport(ctx context.Context, args CategorySpendReportParams) ([]model.CategorySpend, error) {
+	q.logs.WithField("func", "database/sqlc/report.go -> CategorySpendReport()").Debug()
+	rows, err := q.db.QueryContext(ctx, categorySpendReport, args.UserID, args.From, args.To, args.PreviousFrom,
+		args.TransactionType, args.BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var spends []model.CategorySpend
+	for rows.Next() {
+		var spend model.CategorySpend
+		err = rows.Scan(
+			&spend.CategoryID,
+			&spend.ParentID,
+			&spend.Name,
+			&spend.Deleted,
+			&spend.OwnAmount,
+			&spend.Amount,
+			&spend.PreviousAmount,
+			&spend.MissingRates,
+		)
+		spends = append(spends, spend)
+	}
+	return spends, err
+}
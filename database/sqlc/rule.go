@@ -0,0 +1,296 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"time"
+)
+
+const createRule = `--name: CreateRule :one
+INSERT INTO rules (user_id, name, position, conditions, category_id, merchant_id, notes)
+VALUES ($1, $2, COALESCE((SELECT MAX(position) + 1 FROM rules WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'), 1),
+        $3, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, $6)
+RETURNING rule_id, user_id, name, position, conditions, category_id, merchant_id, notes, created_at, deleted_at`
+
+type CreateRuleParams struct {
+	UserID     model.UserID         `json:"user_id"`
+	Name       string               `json:"name"`
+	Conditions model.RuleConditions `json:"conditions"`
+	model.RuleActions
+}
+
+// CreateRule adds a rule after the last rule of the user
+func (q *Queries) CreateRule(ctx context.Context, args CreateRuleParams) (model.Rule, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> CreateRule()").Debug()
+	row := q.db.QueryRowContext(ctx, createRule, args.UserID, args.Name, args.Conditions, args.CategoryID, args.MerchantID, args.Notes)
+	var rule model.Rule
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Position,
+		&rule.Conditions,
+		&rule.CategoryID,
+		&rule.MerchantID,
+		&rule.Notes,
+		&rule.CreatedAt,
+		&rule.DeletedAt,
+	)
+	return rule, err
+}
+
+const updateRule = `--name: UpdateRule :one
+UPDATE rules SET name = $2, conditions = $3, category_id = NULLIF($4, '')::uuid, merchant_id = NULLIF($5, '')::uuid, notes = $6
+WHERE rule_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING rule_id, user_id, name, position, conditions, category_id, merchant_id, notes, created_at, deleted_at`
+
+type UpdateRuleParams struct {
+	RuleID     model.RuleID         `json:"rule_id"`
+	Name       string               `json:"name"`
+	Conditions model.RuleConditions `json:"conditions"`
+	model.RuleActions
+}
+
+func (q *Queries) UpdateRule(ctx context.Context, args UpdateRuleParams) (model.Rule, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> UpdateRule()").Debug()
+	row := q.db.QueryRowContext(ctx, updateRule, args.RuleID, args.Name, args.Conditions, args.CategoryID, args.MerchantID, args.Notes)
+	var rule model.Rule
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Position,
+		&rule.Conditions,
+		&rule.CategoryID,
+		&rule.MerchantID,
+		&rule.Notes,
+		&rule.CreatedAt,
+		&rule.DeletedAt,
+	)
+	return rule, err
+}
+
+const getRuleByID = `--name: GetRuleByID :one
+SELECT rule_id, user_id, name, position, conditions, category_id, merchant_id, notes, created_at, deleted_at FROM rules
+WHERE rule_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1`
+
+func (q *Queries) GetRuleByID(ctx context.Context, id model.RuleID) (model.Rule, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> GetRuleByID()").Debug()
+	row := q.db.QueryRowContext(ctx, getRuleByID, id)
+	var rule model.Rule
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Position,
+		&rule.Conditions,
+		&rule.CategoryID,
+		&rule.MerchantID,
+		&rule.Notes,
+		&rule.CreatedAt,
+		&rule.DeletedAt,
+	)
+	return rule, err
+}
+
+const listRules = `--name: ListRules :many
+SELECT rule_id, user_id, name, position, conditions, category_id, merchant_id, notes, created_at, deleted_at FROM rules
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY position, created_at`
+
+// ListRules returns the rules of the user in the order they run
+func (q *Queries) ListRules(ctx context.Context, userID model.UserID) ([]model.Rule, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> ListRules()").Debug()
+	rows, err := q.db.QueryContext(ctx, listRules, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var rules []model.Rule
+	for rows.Next() {
+		var rule model.Rule
+		err = rows.Scan(
+			&rule.ID,
+			&rule.UserID,
+			&rule.Name,
+			&rule.Position,
+			&rule.Conditions,
+			&rule.CategoryID,
+			&rule.MerchantID,
+			&rule.Notes,
+			&rule.CreatedAt,
+			&rule.DeletedAt,
+		)
+		rules = append(rules, rule)
+	}
+	return rules, err
+}
+
+const setRulePosition = `--name: SetRulePosition :execrows
+UPDATE rules SET position = $3
+WHERE rule_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'`
+
+type SetRulePositionParams struct {
+	RuleID   model.RuleID `json:"rule_id"`
+	UserID   model.UserID `json:"user_id"`
+	Position int32        `json:"position"`
+}
+
+func (q *Queries) SetRulePosition(ctx context.Context, args SetRulePositionParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> SetRulePosition()").Debug()
+	result, err := q.db.ExecContext(ctx, setRulePosition, args.RuleID, args.UserID, args.Position)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRule = `--name: DeleteRule :one
+UPDATE rules SET deleted_at = now()
+WHERE rule_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at`
+
+type DeleteRuleParams struct {
+	RuleID model.RuleID `json:"rule_id"`
+	UserID model.UserID `json:"user_id"`
+}
+
+func (q *Queries) DeleteRule(ctx context.Context, args DeleteRuleParams) (time.Time, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> DeleteRule()").Debug()
+	row := q.db.QueryRowContext(ctx, deleteRule, args.RuleID, args.UserID)
+	var rule model.Rule
+	err := row.Scan(
+		&rule.DeletedAt,
+	)
+	return rule.DeletedAt, err
+}
+
+const reassignRuleCategory = `--name: ReassignRuleCategory :execrows
+UPDATE rules SET category_id = NULLIF($2, '')::uuid
+WHERE category_id = $1`
+
+// ReassignRuleCategory moves the category action of rules to another category, an empty ToCategoryID removes it
+func (q *Queries) ReassignRuleCategory(ctx context.Context, args ReassignCategoryParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> ReassignRuleCategory()").Debug()
+	result, err := q.db.ExecContext(ctx, reassignRuleCategory, args.FromCategoryID, args.ToCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reassignRuleMerchant = `--name: ReassignRuleMerchant :execrows
+UPDATE rules SET merchant_id = NULLIF($2, '')::uuid
+WHERE merchant_id = $1`
+
+// ReassignRuleMerchant moves the merchant action of rules to another merchant, an empty ToMerchantID removes it
+func (q *Queries) ReassignRuleMerchant(ctx context.Context, args ReassignMerchantParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> ReassignRuleMerchant()").Debug()
+	result, err := q.db.ExecContext(ctx, reassignRuleMerchant, args.FromMerchantID, args.ToMerchantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listRuleTransactions = `--name: ListRuleTransactions :many
+SELECT t.transaction_id, t.user_id, t.account_id, t.category_id, t.name, t.transaction_type, t.amount, t.notes, t.date,
+       t.created_at, t.deleted_at, t.transfer_id, t.merchant_id, t.currency, t.status
+FROM transactions t
+JOIN accounts a ON a.account_id = t.account_id
+WHERE t.user_id = $1
+AND t.transfer_id IS NULL
+AND t.status <> 'reconciled'
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND a.deleted_at = '0001-01-01 00:00:00Z'
+AND t.date >= $2
+AND ($3::timestamptz = '0001-01-01 00:00:00Z' OR t.date < $3)
+ORDER BY t.date, t.transaction_id`
+
+type ListRuleTransactionsParams struct {
+	UserID model.UserID `json:"user_id"`
+	From   time.Time    `json:"from"`
+	// To is the end of the period, excluded, the zero time leaves the period open
+	To time.Time `json:"to"`
+}
+
+// ListRuleTransactions returns the transactions of the user rules can be applied to, transfers and reconciled
+// transactions are left out
+func (q *Queries) ListRuleTransactions(ctx context.Context, args ListRuleTransactionsParams) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/rule.go -> ListRuleTransactions()").Debug()
+	rows, err := q.db.QueryContext(ctx, listRuleTransactions, args.UserID, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const applyRuleChange = `--name: ApplyRuleChange :exec
+WITH changed AS (
+    UPDATE transactions SET category_id = COALESCE(NULLIF($2, '')::uuid, category_id),
+        merchant_id = COALESCE(NULLIF($3, '')::uuid, merchant_id), notes = $4
+    WHERE transaction_id = $1
+    AND status <> 'reconciled'
+    AND deleted_at = '0001-01-01 00:00:00Z'
+    RETURNING transaction_id, category_id
+), moved AS (
+    SELECT p.transaction_id, p.user_id, p.ledger_account, p.category_id, c.category_id AS new_category_id,
+    SUM(p.amount) AS amount
+    FROM postings p
+    JOIN changed c ON c.transaction_id = p.transaction_id
+    WHERE p.category_id IS NOT NULL
+    AND p.category_id <> c.category_id
+    AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = c.transaction_id)
+    GROUP BY p.transaction_id, p.user_id, p.ledger_account, p.category_id, c.category_id
+    HAVING SUM(p.amount) <> 0
+)
+INSERT INTO postings (transaction_id, user_id, ledger_account, category_id, amount)
+SELECT transaction_id, user_id, ledger_account, category_id, -amount FROM moved
+UNION ALL
+SELECT transaction_id, user_id, ledger_account, new_category_id, amount FROM moved`
+
+// ApplyRuleChange gives a transaction the category, merchant and notes rules assigned it. Unless the transaction
+// is split, what it posted to its old category is reversed and posted again to the new one, postings are never
+// changed. Reconciled transactions are left as they are
+func (q *Queries) ApplyRuleChange(ctx context.Context, change model.RuleChange) error {
+	q.logs.WithField("func", "database/sqlc/rule.go -> ApplyRuleChange()").Debug()
+	_, err := q.db.ExecContext(ctx, applyRuleChange, change.TransactionID, change.CategoryID, change.MerchantID, change.Notes)
+	return err
+}
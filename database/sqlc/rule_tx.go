@@ -0,0 +1,147 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRuleNotFound is returned when rules are reordered with a rule that does not exist or belongs to another user
+var ErrRuleNotFound = errors.New("rule not found or deleted")
+
+type ReorderRulesParams struct {
+	UserID model.UserID `json:"user_id"`
+	// RuleIDs are the rules to run first, in order, the rules left out run after them in the order they had
+	RuleIDs []model.RuleID `json:"rule_ids"`
+}
+
+// ReorderRulesTx renumbers the rules of a user so they run in the requested order
+func (r SQLRepo) ReorderRulesTx(ctx context.Context, args ReorderRulesParams) ([]model.Rule, error) {
+	r.logs.WithField("func", "database/sqlc/rule_tx.go -> ReorderRulesTx()").Debug()
+	var rules []model.Rule
+	err := r.execTx(ctx, func(q *Queries) error {
+		current, err := q.ListRules(ctx, args.UserID)
+		if err != nil {
+			return err
+		}
+		owned := make(map[model.RuleID]bool, len(current))
+		for _, rule := range current {
+			owned[rule.ID] = true
+		}
+		order := make([]model.RuleID, 0, len(current))
+		listed := make(map[model.RuleID]bool, len(args.RuleIDs))
+		for _, id := range args.RuleIDs {
+			if !owned[id] {
+				return ErrRuleNotFound
+			}
+			if !listed[id] {
+				listed[id] = true
+				order = append(order, id)
+			}
+		}
+		for _, rule := range current {
+			if !listed[rule.ID] {
+				order = append(order, rule.ID)
+			}
+		}
+		for i, id := range order {
+			_, err = q.SetRulePosition(ctx, SetRulePositionParams{RuleID: id, UserID: args.UserID, Position: int32(i + 1)})
+			if err != nil {
+				return err
+			}
+		}
+		rules, err = q.ListRules(ctx, args.UserID)
+		return err
+	})
+	return rules, err
+}
+
+type ApplyRulesParams struct {
+	UserID model.UserID `json:"user_id"`
+	// Rules are applied in the order given
+	Rules []model.Rule `json:"rules"`
+	// From and To limit the transactions to a period, To is excluded and the zero time leaves it open
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Overwrite replaces the category, merchant and notes transactions already have
+	Overwrite bool `json:"overwrite"`
+	// Commit writes the changes, without it the changes that would be made are only returned
+	Commit bool `json:"commit"`
+}
+
+// ApplyRulesTx applies rules to the existing transactions of a user, transfers and reconciled transactions are
+// left as they are
+func (r SQLRepo) ApplyRulesTx(ctx context.Context, args ApplyRulesParams) ([]model.RuleChange, error) {
+	r.logs.WithField("func", "database/sqlc/rule_tx.go -> ApplyRulesTx()").Debug()
+	var changes []model.RuleChange
+	err := r.execTx(ctx, func(q *Queries) error {
+		transactions, err := q.ListRuleTransactions(ctx, ListRuleTransactionsParams{
+			UserID: args.UserID,
+			From:   args.From,
+			To:     args.To,
+		})
+		if err != nil {
+			return err
+		}
+		if err = q.attachSplits(ctx, transactions); err != nil {
+			return err
+		}
+		changes = model.RuleChanges(args.Rules, transactions, args.Overwrite)
+		if !args.Commit {
+			return nil
+		}
+		for _, change := range changes {
+			if err = q.ApplyRuleChange(ctx, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return changes, err
+}
+
+// attachSplits reads the splits of the transactions into them
+func (q *Queries) attachSplits(ctx context.Context, transactions []model.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	ids := make([]model.TransactionID, 0, len(transactions))
+	for _, transaction := range transactions {
+		ids = append(ids, transaction.ID)
+	}
+	splits, err := q.ListSplitsByTransactionIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byTransaction := make(map[model.TransactionID][]model.TransactionSplit)
+	for _, split := range splits {
+		byTransaction[split.TransactionID] = append(byTransaction[split.TransactionID], split)
+	}
+	for i := range transactions {
+		transactions[i].Splits = byTransaction[transactions[i].ID]
+	}
+	return nil
+}
+
+// ApplyTransactionRules gives a transaction being created the category, merchant and notes it leaves out from
+// the first matching rules that have them, the same way whether the user, an import or a recurring transaction
+// creates it
+func ApplyTransactionRules(rules []model.Rule, args *CreateTransactionParams) {
+	transaction := model.Transaction{
+		AccountID:       args.AccountID,
+		CategoryID:      args.CategoryID,
+		Name:            args.Name,
+		TransactionType: args.TransactionType,
+		Amount:          args.Amount,
+		Notes:           args.Notes,
+		MerchantID:      args.MerchantID,
+		Splits:          make([]model.TransactionSplit, len(args.Splits)),
+	}
+	if len(model.ApplyRules(rules, &transaction, false)) == 0 {
+		return
+	}
+	args.CategoryID = transaction.CategoryID
+	args.MerchantID = transaction.MerchantID
+	args.Notes = transaction.Notes
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+	"github.com/lib/pq"
+	"time"
+)
+
+const createSignupTemplate = `--name: CreateSignupTemplate :one
+INSERT INTO signup_templates (locale, categories, account_name, account_type, currency)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING template_id, locale, categories, account_name, account_type, currency, created_at, deleted_at`
+
+type CreateSignupTemplateParams struct {
+	Locale     string                   `json:"locale"`
+	Categories model.TemplateCategories `json:"categories"`
+	// AccountName is the account created in the base currency of the user, none is created when it is empty
+	AccountName string            `json:"account_name"`
+	AccountType model.AccountType `json:"account_type"`
+	// Currency is the base currency of users of the locale who do not give one
+	Currency utils.CurrencyCode `json:"currency"`
+}
+
+func (q *Queries) CreateSignupTemplate(ctx context.Context, args CreateSignupTemplateParams) (model.SignupTemplate, error) {
+	q.logs.WithField("func", "database/sqlc/signup_template.go -> CreateSignupTemplate()").Debug()
+	row := q.db.QueryRowContext(ctx, createSignupTemplate, args.Locale, args.Categories, args.AccountName, args.AccountType,
+		args.Currency)
+	var template model.SignupTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.Locale,
+		&template.Categories,
+		&template.AccountName,
+		&template.AccountType,
+		&template.Currency,
+		&template.CreatedAt,
+		&template.DeletedAt,
+	)
+	return template, err
+}
+
+const updateSignupTemplate = `--name: UpdateSignupTemplate :one
+UPDATE signup_templates SET locale = $2, categories = $3, account_name = $4, account_type = $5, currency = $6
+WHERE template_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING template_id, locale, categories, account_name, account_type, currency, created_at, deleted_at`
+
+type UpdateSignupTemplateParams struct {
+	TemplateID  model.SignupTemplateID   `json:"template_id"`
+	Locale      string                   `json:"locale"`
+	Categories  model.TemplateCategories `json:"categories"`
+	AccountName string                   `json:"account_name"`
+	AccountType model.AccountType        `json:"account_type"`
+	Currency    utils.CurrencyCode       `json:"currency"`
+}
+
+func (q *Queries) UpdateSignupTemplate(ctx context.Context, args UpdateSignupTemplateParams) (model.SignupTemplate, error) {
+	q.logs.WithField("func", "database/sqlc/signup_template.go -> UpdateSignupTemplate()").Debug()
+	row := q.db.QueryRowContext(ctx, updateSignupTemplate, args.TemplateID, args.Locale, args.Categories, args.AccountName, args.AccountType,
+		args.Currency)
+	var template model.SignupTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.Locale,
+		&template.Categories,
+		&template.AccountName,
+		&template.AccountType,
+		&template.Currency,
+		&template.CreatedAt,
+		&template.DeletedAt,
+	)
+	return template, err
+}
+
+const getSignupTemplateByID = `--name: GetSignupTemplateByID :one
+SELECT template_id, locale, categories, account_name, account_type, currency, created_at, deleted_at FROM signup_templates
+WHERE template_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1`
+
+func (q *Queries) GetSignupTemplateByID(ctx context.Context, id model.SignupTemplateID) (model.SignupTemplate, error) {
+	q.logs.WithField("func", "database/sqlc/signup_template.go -> GetSignupTemplateByID()").Debug()
+	row := q.db.QueryRowContext(ctx, getSignupTemplateByID, id)
+	var template model.SignupTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.Locale,
+		&template.Categories,
+		&template.AccountName,
+		&template.AccountType,
+		&template.Currency,
+		&template.CreatedAt,
+		&template.DeletedAt,
+	)
+	return template, err
+}
+
+const getSignupTemplateForLocale = `--name: GetSignupTemplateForLocale :one
+SELECT template_id, locale, categories, account_name, account_type, currency, created_at, deleted_at FROM signup_templates
+WHERE lower(locale) = ANY($1::varchar[])
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY array_position($1::varchar[], lower(locale)::varchar)
+LIMIT 1`
+
+// GetSignupTemplateForLocale returns the template of the first of the lower cased locales that has one
+func (q *Queries) GetSignupTemplateForLocale(ctx context.Context, locales []string) (model.SignupTemplate, error) {
+	q.logs.WithField("func", "database/sqlc/signup_template.go -> GetSignupTemplateForLocale()").Debug()
+	row := q.db.QueryRowContext(ctx, getSignupTemplateForLocale, pq.Array(locales))
+	var template model.SignupTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.Locale,
+		&template.Categories,
+		&template.AccountName,
+		&template.AccountType,
+		&template.Currency,
+		&template.CreatedAt,
+		&template.DeletedAt,
+	)
+	return template, err
+}
+
+const listSignupTemplates = `--name: ListSignupTemplates :many
+SELECT template_id, locale, categories, account_name, account_type, currency, created_at, deleted_at FROM signup_templates
+WHERE deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY locale`
+
+func (q *Queries) ListSignupTemplates(ctx context.Context) ([]model.SignupTemplate, error) {
+	q.logs.WithField("func", "database/sqlc/signup_template.go -> ListSignupTemplates()").Debug()
+	rows, err := q.db.QueryContext(ctx, listSignupTemplates)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var templates []model.SignupTemplate
+	for rows.Next() {
+		var template model.SignupTemplate
+		err = rows.Scan(
+			&template.ID,
+			&template.Locale,
+			&template.Categories,
+			&template.AccountName,
+			&template.AccountType,
+			&template.Currency,
+			&template.CreatedAt,
+			&template.DeletedAt,
+		)
+		templates = append(templates, template)
+	}
+	return templates, err
+}
+
+const deleteSignupTemplate = `--name: DeleteSignupTemplate :one
+UPDATE signup_templates SET deleted_at = now()
+WHERE template_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at`
+
+func (q *Queries) DeleteSignupTemplate(ctx context.Context, id model.SignupTemplateID) (time.Time, error) {
+	q.logs.WithField("func", "database/sqlc/signup_template.go -> DeleteSignupTemplate()").Debug()
+	row := q.db.QueryRowContext(ctx, deleteSignupTemplate, id)
+	var template model.SignupTemplate
+	err := row.Scan(
+		&template.DeletedAt,
+	)
+	return template.DeletedAt, err
+}
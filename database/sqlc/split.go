@@ -0,0 +1,111 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"github.com/lib/pq"
+)
+
+const createSplit = `--name: CreateSplit :one
+INSERT INTO transaction_splits (transaction_id, category_id, amount, notes)
+VALUES ($1, $2, $3, $4)
+RETURNING split_id, transaction_id, category_id, amount, notes, created_at`
+
+type CreateSplitParams struct {
+	TransactionID model.TransactionID `json:"transaction_id"`
+	CategoryID    model.CategoryID    `json:"category_id"`
+	Amount        int64               `json:"amount"`
+	Notes         string              `json:"notes"`
+}
+
+func (q *Queries) CreateSplit(ctx context.Context, args CreateSplitParams) (model.TransactionSplit, error) {
+	q.logs.WithField("func", "database/sqlc/split.go -> CreateSplit()").Debug()
+	row := q.db.QueryRowContext(ctx, createSplit, args.TransactionID, args.CategoryID, args.Amount, args.Notes)
+	var split model.TransactionSplit
+	err := row.Scan(
+		&split.ID,
+		&split.TransactionID,
+		&split.CategoryID,
+		&split.Amount,
+		&split.Notes,
+		&split.CreatedAt,
+	)
+	return split, err
+}
+
+const listSplitsByTransactionID = `--name: ListSplitsByTransactionID :many
+SELECT * FROM transaction_splits
+WHERE transaction_id = $1
+ORDER BY amount DESC, split_id`
+
+func (q *Queries) ListSplitsByTransactionID(ctx context.Context, id model.TransactionID) ([]model.TransactionSplit, error) {
+	q.logs.WithField("func", "database/sqlc/split.go -> ListSplitsByTransactionID()").Debug()
+	rows, err := q.db.QueryContext(ctx, listSplitsByTransactionID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var splits []model.TransactionSplit
+	for rows.Next() {
+		var split model.TransactionSplit
+		err = rows.Scan(
+			&split.ID,
+			&split.TransactionID,
+			&split.CategoryID,
+			&split.Amount,
+			&split.Notes,
+			&split.CreatedAt,
+		)
+		splits = append(splits, split)
+	}
+	return splits, err
+}
+
+const listSplitsByTransactionIDs = `--name: ListSplitsByTransactionIDs :many
+SELECT * FROM transaction_splits
+WHERE transaction_id = ANY($1::uuid[])
+ORDER BY transaction_id, amount DESC, split_id`
+
+// ListSplitsByTransactionIDs returns the splits of several transactions at once
+func (q *Queries) ListSplitsByTransactionIDs(ctx context.Context, ids []model.TransactionID) ([]model.TransactionSplit, error) {
+	q.logs.WithField("func", "database/sqlc/split.go -> ListSplitsByTransactionIDs()").Debug()
+	transactionIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		transactionIDs = append(transactionIDs, string(id))
+	}
+	rows, err := q.db.QueryContext(ctx, listSplitsByTransactionIDs, pq.Array(transactionIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var splits []model.TransactionSplit
+	for rows.Next() {
+		var split model.TransactionSplit
+		err = rows.Scan(
+			&split.ID,
+			&split.TransactionID,
+			&split.CategoryID,
+			&split.Amount,
+			&split.Notes,
+			&split.CreatedAt,
+		)
+		splits = append(splits, split)
+	}
+	return splits, err
+}
+
+const deleteSplitsByTransactionID = `--name: DeleteSplitsByTransactionID :exec
+DELETE FROM transaction_splits
+WHERE transaction_id = $1`
+
+func (q *Queries) DeleteSplitsByTransactionID(ctx context.Context, id model.TransactionID) error {
+	q.logs.WithField("func", "database/sqlc/split.go -> DeleteSplitsByTransactionID()").Debug()
+	_, err := q.db.ExecContext(ctx, deleteSplitsByTransactionID, id)
+	return err
+}
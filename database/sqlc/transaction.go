@@ -2,14 +2,17 @@ package database
 
 import (
 	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
 	"context"
+	"strings"
 	"time"
+	"unicode"
 )
 
 const createTransaction = `--name: CreateTransaction :one
-INSERT INTO transactions (user_id, account_id, category_id, name, transaction_type, amount, notes, date)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at, deleted_at`
+INSERT INTO transactions (user_id, account_id, category_id, name, transaction_type, amount, notes, date, transfer_id, merchant_id, status)
+VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid, NULLIF($10, '')::uuid, COALESCE(NULLIF($11, ''), 'pending'))
+RETURNING transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at, deleted_at, transfer_id, merchant_id, currency, status`
 
 type CreateTransactionParams struct {
 	UserID          model.UserID          `json:"user_id"`
@@ -20,13 +23,22 @@ type CreateTransactionParams struct {
 	Amount          int64                 `json:"amount"`
 	Notes           string                `json:"notes"`
 	Date            time.Time             `json:"date"`
+	TransferID      model.TransferID      `json:"transfer_id"`
+	MerchantID      model.MerchantID      `json:"merchant_id"`
+	// Currency is the currency the amounts were given in, they are taken in the currency of the account when it
+	// is left out
+	Currency utils.CurrencyCode `json:"currency"`
+	// Status is pending when it is left out
+	Status model.TransactionStatus `json:"status"`
+	// Splits are written by CreateTransactionTx after the transaction is created
+	Splits []CreateSplitParams `json:"splits"`
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, args CreateTransactionParams) (model.Transaction, error) {
 	q.logs.WithField("func", "database/sqlc/transaction.go -> CreateTransaction()").Debug()
 
 	row := q.db.QueryRowContext(ctx, createTransaction, args.UserID, args.AccountID, args.CategoryID, args.Name,
-		args.TransactionType, args.Amount, args.Notes, args.Date)
+		args.TransactionType, args.Amount, args.Notes, args.Date, args.TransferID, args.MerchantID, args.Status)
 	var transaction model.Transaction
 	err := row.Scan(
 		&transaction.ID,
@@ -40,20 +52,28 @@ func (q *Queries) CreateTransaction(ctx context.Context, args CreateTransactionP
 		&transaction.Date,
 		&transaction.CreatedAt,
 		&transaction.DeletedAt,
+		&transaction.TransferID,
+		&transaction.MerchantID,
+		&transaction.Currency,
+		&transaction.Status,
 	)
 	return transaction, err
 }
 
 const updateTransaction = `--name: UpdateTransaction :one
-UPDATE transactions SET account_id = $2, 
-category_id = $3, 
-name = $4, 
-transaction_type = $5,  
-amount = $6,
-notes = $7,
-date = $8
+UPDATE transactions SET account_id = $3, 
+category_id = $4, 
+name = $5, 
+transaction_type = $6,  
+amount = $7,
+notes = $8,
+date = $9,
+merchant_id = NULLIF($10, '')::uuid,
+status = CASE WHEN status = 'reconciled' THEN 'cleared' ELSE status END
 WHERE transaction_id = $1
-RETURNING transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at, deleted_at`
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at, deleted_at, transfer_id, merchant_id, currency, status`
 
 type UpdateTransactionParams struct {
 	TransactionID   model.TransactionID   `json:"transaction_id"`
@@ -65,12 +85,21 @@ type UpdateTransactionParams struct {
 	Amount          int64                 `json:"amount"`
 	Notes           string                `json:"notes"`
 	Date            time.Time             `json:"date"`
+	MerchantID      model.MerchantID      `json:"merchant_id"`
+	// Currency is the currency the amounts were given in, they are taken in the currency of the account when it
+	// is left out
+	Currency utils.CurrencyCode `json:"currency"`
+	// Unlock lets a reconciled transaction be updated, it is cleared again as it no longer matches the statement
+	// it was reconciled with
+	Unlock bool `json:"unlock"`
+	// Splits replace the splits of the transaction in UpdateTransactionTx
+	Splits []CreateSplitParams `json:"splits"`
 }
 
 func (q *Queries) UpdateTransaction(ctx context.Context, args UpdateTransactionParams) (model.Transaction, error) {
 	q.logs.WithField("func", "database/sqlc/transaction.go -> UpdateTransaction()").Debug()
 	row := q.db.QueryRowContext(ctx, updateTransaction, args.TransactionID, args.UserID, args.AccountID, args.CategoryID, args.Name,
-		args.TransactionType, args.Amount, args.Notes, args.Date)
+		args.TransactionType, args.Amount, args.Notes, args.Date, args.MerchantID)
 	var transaction model.Transaction
 	err := row.Scan(
 		&transaction.ID,
@@ -84,6 +113,10 @@ func (q *Queries) UpdateTransaction(ctx context.Context, args UpdateTransactionP
 		&transaction.Date,
 		&transaction.CreatedAt,
 		&transaction.DeletedAt,
+		&transaction.TransferID,
+		&transaction.MerchantID,
+		&transaction.Currency,
+		&transaction.Status,
 	)
 	return transaction, err
 }
@@ -110,32 +143,71 @@ func (q *Queries) GetTransactionByID(ctx context.Context, id model.TransactionID
 		&transaction.Date,
 		&transaction.CreatedAt,
 		&transaction.DeletedAt,
+		&transaction.TransferID,
+		&transaction.MerchantID,
+		&transaction.Currency,
+		&transaction.Status,
 	)
 	return transaction, err
 
 }
 
+const getTransactionForUpdate = `--name: GetTransactionForUpdate :one
+SELECT * FROM transactions
+WHERE transaction_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE`
+
+// GetTransactionForUpdate returns the transaction and locks its row until the surrounding database transaction ends
+func (q *Queries) GetTransactionForUpdate(ctx context.Context, id model.TransactionID) (model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> GetTransactionForUpdate()").Debug()
+	row := q.db.QueryRowContext(ctx, getTransactionForUpdate, id)
+	var transaction model.Transaction
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.UserID,
+		&transaction.AccountID,
+		&transaction.CategoryID,
+		&transaction.Name,
+		&transaction.TransactionType,
+		&transaction.Amount,
+		&transaction.Notes,
+		&transaction.Date,
+		&transaction.CreatedAt,
+		&transaction.DeletedAt,
+		&transaction.TransferID,
+		&transaction.MerchantID,
+		&transaction.Currency,
+		&transaction.Status,
+	)
+	return transaction, err
+}
+
 const listTXByUserID = `--name: ListTransactionsByUserID :many
 SELECT * FROM transactions
 WHERE user_id = $1
 AND deleted_at = '0001-01-01 00:00:00Z'
-AND date > $4
-AND date < $5
-ORDER BY transaction_id
-LIMIT  $2
-OFFSET $3`
+AND date > $3
+AND date < $4
+AND ($6::text = '' OR (date, transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transaction_id DESC
+LIMIT  $2`
 
+// ListTxByUserIDParams lists the transactions of a user, the latest first. Cursor is the last transaction of
+// the previous page, the zero Cursor starts from the latest
 type ListTxByUserIDParams struct {
 	UserID model.UserID `json:"user_id"`
 	Limit  int32        `json:"limit"`
-	Offset int32        `json:"offset"`
 	From   time.Time    `json:"from"`
 	To     time.Time    `json:"to"`
+	Cursor model.Cursor `json:"cursor"`
 }
 
 func (q *Queries) ListTransactionsByUserID(ctx context.Context, args ListTxByUserIDParams) ([]model.Transaction, error) {
 	q.logs.WithField("func", "database/sqlc/transaction.go -> ListTransactionsByUserID()").Debug()
-	rows, err := q.db.QueryContext(ctx, listTXByUserID, args.UserID, args.Limit, args.Offset, args.From, args.To)
+	rows, err := q.db.QueryContext(ctx, listTXByUserID, args.UserID, args.Limit, args.From, args.To, args.Cursor.Date,
+		args.Cursor.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +230,10 @@ func (q *Queries) ListTransactionsByUserID(ctx context.Context, args ListTxByUse
 			&transaction.Date,
 			&transaction.CreatedAt,
 			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
 		)
 		transactions = append(transactions, transaction)
 	}
@@ -168,23 +244,24 @@ const listTXByAccountID = `--name: ListTransactionsByAccountID :many
 SELECT * FROM transactions
 WHERE account_id = $1
 AND deleted_at = '0001-01-01 00:00:00Z'
-AND date > $4
-AND date < $5
-ORDER BY transaction_id
-LIMIT  $2
-OFFSET $3`
+AND date > $3
+AND date < $4
+AND ($6::text = '' OR (date, transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transaction_id DESC
+LIMIT  $2`
 
 type ListTxByAccountIDParams struct {
 	AccountID model.AccountID `json:"account_id"`
 	Limit     int32           `json:"limit"`
-	Offset    int32           `json:"offset"`
 	From      time.Time       `json:"from"`
 	To        time.Time       `json:"to"`
+	Cursor    model.Cursor    `json:"cursor"`
 }
 
 func (q *Queries) ListTransactionsByAccountID(ctx context.Context, args ListTxByAccountIDParams) ([]model.Transaction, error) {
 	q.logs.WithField("func", "database/sqlc/transaction.go -> ListTransactionsByAccountID()").Debug()
-	rows, err := q.db.QueryContext(ctx, listTXByAccountID, args.AccountID, args.Limit, args.Offset, args.From, args.To)
+	rows, err := q.db.QueryContext(ctx, listTXByAccountID, args.AccountID, args.Limit, args.From, args.To,
+		args.Cursor.Date, args.Cursor.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +284,10 @@ func (q *Queries) ListTransactionsByAccountID(ctx context.Context, args ListTxBy
 			&transaction.Date,
 			&transaction.CreatedAt,
 			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
 		)
 		transactions = append(transactions, transaction)
 	}
@@ -214,26 +295,33 @@ func (q *Queries) ListTransactionsByAccountID(ctx context.Context, args ListTxBy
 }
 
 const listTXByCategoryID = `--name: ListTransactionsByCategoryID :many
-SELECT * FROM transactions
-WHERE category_id = $1
-AND deleted_at = '0001-01-01 00:00:00Z'
-AND date > $4
-AND date < $5
-ORDER BY transaction_id
-LIMIT  $2
-OFFSET $3`
+SELECT t.transaction_id, t.user_id, t.account_id, t.category_id, t.name, t.transaction_type, SUM(ca.amount) AS amount,
+t.notes, t.date, t.created_at, t.deleted_at, t.transfer_id, t.merchant_id, t.currency, t.status
+FROM transactions t
+JOIN transaction_category_amounts ca ON ca.transaction_id = t.transaction_id
+WHERE ca.category_id = $1
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND t.date > $3
+AND t.date < $4
+AND ($6::text = '' OR (t.date, t.transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+GROUP BY t.transaction_id
+ORDER BY t.date DESC, t.transaction_id DESC
+LIMIT  $2`
 
+// ListTransactionsByCategoryID returns the transactions in a category, the amount of a split transaction is only
+// the part split to the category
 type ListTxByCategoryIDParams struct {
 	CategoryID model.CategoryID `json:"category_id"`
 	Limit      int32            `json:"limit"`
-	Offset     int32            `json:"offset"`
 	From       time.Time        `json:"from"`
 	To         time.Time        `json:"to"`
+	Cursor     model.Cursor     `json:"cursor"`
 }
 
 func (q *Queries) ListTransactionsByCategoryID(ctx context.Context, args ListTxByCategoryIDParams) ([]model.Transaction, error) {
 	q.logs.WithField("func", "database/sqlc/transaction.go -> ListTransactionsByCategoryID()").Debug()
-	rows, err := q.db.QueryContext(ctx, listTXByCategoryID, args.CategoryID, args.Limit, args.Offset, args.From, args.To)
+	rows, err := q.db.QueryContext(ctx, listTXByCategoryID, args.CategoryID, args.Limit, args.From, args.To,
+		args.Cursor.Date, args.Cursor.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -256,6 +344,10 @@ func (q *Queries) ListTransactionsByCategoryID(ctx context.Context, args ListTxB
 			&transaction.Date,
 			&transaction.CreatedAt,
 			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
 		)
 		transactions = append(transactions, transaction)
 	}
@@ -277,3 +369,390 @@ func (q *Queries) DeleteTransaction(ctx context.Context, id model.TransactionID)
 	)
 	return transaction.DeletedAt, err
 }
+
+const listTXByTransferID = `--name: ListTransactionsByTransferID :many
+SELECT * FROM transactions
+WHERE transfer_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY transaction_type`
+
+// ListTransactionsByTransferID returns the transfer_out and transfer_in legs of a transfer in that order
+func (q *Queries) ListTransactionsByTransferID(ctx context.Context, id model.TransferID) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> ListTransactionsByTransferID()").Debug()
+	rows, err := q.db.QueryContext(ctx, listTXByTransferID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const deleteTXByTransferID = `--name: DeleteTransactionsByTransferID :many
+UPDATE transactions SET deleted_at = now()
+WHERE transfer_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at, deleted_at, transfer_id, merchant_id, currency, status`
+
+// DeleteTransactionsByTransferID deletes the legs of a transfer and returns them
+func (q *Queries) DeleteTransactionsByTransferID(ctx context.Context, id model.TransferID) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> DeleteTransactionsByTransferID()").Debug()
+	rows, err := q.db.QueryContext(ctx, deleteTXByTransferID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const listTXByMerchantID = `--name: ListTransactionsByMerchantID :many
+SELECT * FROM transactions
+WHERE merchant_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $3
+AND date < $4
+AND ($6::text = '' OR (date, transaction_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transaction_id DESC
+LIMIT  $2`
+
+type ListTxByMerchantIDParams struct {
+	MerchantID model.MerchantID `json:"merchant_id"`
+	Limit      int32            `json:"limit"`
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	Cursor     model.Cursor     `json:"cursor"`
+}
+
+func (q *Queries) ListTransactionsByMerchantID(ctx context.Context, args ListTxByMerchantIDParams) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> ListTransactionsByMerchantID()").Debug()
+	rows, err := q.db.QueryContext(ctx, listTXByMerchantID, args.MerchantID, args.Limit, args.From, args.To,
+		args.Cursor.Date, args.Cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const countTXByMerchantID = `--name: CountTransactionsByMerchantID :one
+SELECT COUNT(*) FROM transactions
+WHERE merchant_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'`
+
+func (q *Queries) CountTransactionsByMerchantID(ctx context.Context, id model.MerchantID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> CountTransactionsByMerchantID()").Debug()
+	row := q.db.QueryRowContext(ctx, countTXByMerchantID, id)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTXByUserID = `--name: CountTransactionsByUserID :one
+SELECT COUNT(*) FROM transactions
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $2
+AND date < $3`
+
+// CountTransactionsByUserID counts the transactions ListTransactionsByUserID pages through
+func (q *Queries) CountTransactionsByUserID(ctx context.Context, args ListTxByUserIDParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> CountTransactionsByUserID()").Debug()
+	row := q.db.QueryRowContext(ctx, countTXByUserID, args.UserID, args.From, args.To)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTXByAccountID = `--name: CountTransactionsByAccountID :one
+SELECT COUNT(*) FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $2
+AND date < $3`
+
+// CountTransactionsByAccountID counts the transactions ListTransactionsByAccountID pages through
+func (q *Queries) CountTransactionsByAccountID(ctx context.Context, args ListTxByAccountIDParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> CountTransactionsByAccountID()").Debug()
+	row := q.db.QueryRowContext(ctx, countTXByAccountID, args.AccountID, args.From, args.To)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTXByCategoryID = `--name: CountTransactionsByCategoryID :one
+SELECT COUNT(DISTINCT t.transaction_id) FROM transactions t
+JOIN transaction_category_amounts ca ON ca.transaction_id = t.transaction_id
+WHERE ca.category_id = $1
+AND t.deleted_at = '0001-01-01 00:00:00Z'
+AND t.date > $2
+AND t.date < $3`
+
+// CountTransactionsByCategoryID counts the transactions ListTransactionsByCategoryID pages through
+func (q *Queries) CountTransactionsByCategoryID(ctx context.Context, args ListTxByCategoryIDParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> CountTransactionsByCategoryID()").Debug()
+	row := q.db.QueryRowContext(ctx, countTXByCategoryID, args.CategoryID, args.From, args.To)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countMerchantTransactions = `--name: CountMerchantTransactions :one
+SELECT COUNT(*) FROM transactions
+WHERE merchant_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $2
+AND date < $3`
+
+// CountMerchantTransactions counts the transactions ListTransactionsByMerchantID pages through
+func (q *Queries) CountMerchantTransactions(ctx context.Context, args ListTxByMerchantIDParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> CountMerchantTransactions()").Debug()
+	row := q.db.QueryRowContext(ctx, countMerchantTransactions, args.MerchantID, args.From, args.To)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const reassignMerchant = `--name: ReassignMerchant :execrows
+UPDATE transactions SET merchant_id = $2
+WHERE merchant_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'`
+
+type ReassignMerchantParams struct {
+	FromMerchantID model.MerchantID `json:"from_merchant_id"`
+	ToMerchantID   model.MerchantID `json:"to_merchant_id"`
+}
+
+// ReassignMerchant moves the transactions of one merchant to another and returns how many were moved
+func (q *Queries) ReassignMerchant(ctx context.Context, args ReassignMerchantParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> ReassignMerchant()").Debug()
+	result, err := q.db.ExecContext(ctx, reassignMerchant, args.FromMerchantID, args.ToMerchantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchTransactions = `--name: SearchTransactions :many
+WITH RECURSIVE tree AS (
+    SELECT c.category_id FROM categories c
+    WHERE c.category_id = NULLIF($3::text, '')::uuid AND c.user_id = $1
+    UNION
+    SELECT c.category_id FROM categories c
+    JOIN tree ON c.parent_id = tree.category_id::text
+    WHERE c.deleted_at = '0001-01-01 00:00:00Z'
+)
+SELECT t.transaction_id, t.user_id, t.account_id, t.category_id, t.name, t.transaction_type, t.amount, t.notes,
+       t.date, t.created_at, t.deleted_at, t.transfer_id, t.merchant_id, t.currency, t.status
+FROM transactions t
+WHERE t.user_id = $1
+  AND t.deleted_at = '0001-01-01 00:00:00Z'
+  AND ($2::text = '' OR t.account_id = NULLIF($2::text, '')::uuid)
+  AND ($3::text = '' OR EXISTS (SELECT 1 FROM transaction_category_amounts ca
+                                JOIN tree ON tree.category_id = ca.category_id
+                                WHERE ca.transaction_id = t.transaction_id))
+  AND ($4::text = '' OR t.merchant_id = NULLIF($4::text, '')::uuid)
+  AND ($5::text = '' OR t.transaction_type::text = $5)
+  AND ($6::bigint IS NULL OR t.amount >= $6)
+  AND ($7::bigint IS NULL OR t.amount <= $7)
+  AND ($8::timestamptz = '0001-01-01 00:00:00Z' OR t.date >= $8)
+  AND ($9::timestamptz = '0001-01-01 00:00:00Z' OR t.date < $9)
+  AND ($10::text = '' OR to_tsvector('simple', t.name || ' ' || t.notes) @@ to_tsquery('simple', $10))
+  AND ($14::text = '' OR CASE
+      WHEN $11::text = 'amount' AND $12::boolean
+          THEN (t.amount, t.date, t.transaction_id) < ($16::bigint, $15::timestamptz, NULLIF($14::text, '')::uuid)
+      WHEN $11::text = 'amount'
+          THEN (t.amount, t.date, t.transaction_id) > ($16::bigint, $15::timestamptz, NULLIF($14::text, '')::uuid)
+      WHEN $12::boolean
+          THEN (t.date, t.transaction_id) < ($15::timestamptz, NULLIF($14::text, '')::uuid)
+      ELSE (t.date, t.transaction_id) > ($15::timestamptz, NULLIF($14::text, '')::uuid)
+  END)
+ORDER BY CASE WHEN $11::text = 'amount' AND NOT $12::boolean THEN t.amount END,
+         CASE WHEN $11::text = 'amount' AND $12::boolean THEN t.amount END DESC,
+         CASE WHEN NOT $12::boolean THEN t.date END,
+         CASE WHEN $12::boolean THEN t.date END DESC,
+         CASE WHEN NOT $12::boolean THEN t.transaction_id END,
+         CASE WHEN $12::boolean THEN t.transaction_id END DESC
+LIMIT $13`
+
+type SearchTransactionsParams struct {
+	UserID          model.UserID          `json:"user_id"`
+	AccountID       model.AccountID       `json:"account_id"`
+	CategoryID      model.CategoryID      `json:"category_id"`
+	MerchantID      model.MerchantID      `json:"merchant_id"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+	// MinAmount and MaxAmount bound the amount in minor units, nil leaves a bound out
+	MinAmount *int64    `json:"min_amount"`
+	MaxAmount *int64    `json:"max_amount"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	// Query is matched against the words of the name and notes, each of its words as a prefix
+	Query string `json:"query"`
+	// Sort orders by date or amount, then by date and ID in the same direction
+	Sort       model.TransactionSort `json:"sort"`
+	Descending bool                  `json:"descending"`
+	Limit      int32                 `json:"limit"`
+	Cursor     model.Cursor          `json:"cursor"`
+}
+
+// SearchTransactions returns the live transactions of the user passing every filter that is set, in the order
+// of Sort after Cursor. A category matches the transactions filed under it or any of its subcategories, split
+// ones included
+func (q *Queries) SearchTransactions(ctx context.Context, args SearchTransactionsParams) ([]model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> SearchTransactions()").Debug()
+	rows, err := q.db.QueryContext(ctx, searchTransactions, args.UserID, args.AccountID, args.CategoryID,
+		args.MerchantID, args.TransactionType, args.MinAmount, args.MaxAmount, args.From, args.To,
+		prefixQuery(args.Query), args.Sort, args.Descending, args.Limit, args.Cursor.ID, args.Cursor.Date,
+		args.Cursor.Amount)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transactions []model.Transaction
+	for rows.Next() {
+		var transaction model.Transaction
+		err = rows.Scan(
+			&transaction.ID,
+			&transaction.UserID,
+			&transaction.AccountID,
+			&transaction.CategoryID,
+			&transaction.Name,
+			&transaction.TransactionType,
+			&transaction.Amount,
+			&transaction.Notes,
+			&transaction.Date,
+			&transaction.CreatedAt,
+			&transaction.DeletedAt,
+			&transaction.TransferID,
+			&transaction.MerchantID,
+			&transaction.Currency,
+			&transaction.Status,
+		)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, err
+}
+
+const countSearchTransactions = `--name: CountSearchTransactions :one
+WITH RECURSIVE tree AS (
+    SELECT c.category_id FROM categories c
+    WHERE c.category_id = NULLIF($3::text, '')::uuid AND c.user_id = $1
+    UNION
+    SELECT c.category_id FROM categories c
+    JOIN tree ON c.parent_id = tree.category_id::text
+    WHERE c.deleted_at = '0001-01-01 00:00:00Z'
+)
+SELECT COUNT(*)
+FROM transactions t
+WHERE t.user_id = $1
+  AND t.deleted_at = '0001-01-01 00:00:00Z'
+  AND ($2::text = '' OR t.account_id = NULLIF($2::text, '')::uuid)
+  AND ($3::text = '' OR EXISTS (SELECT 1 FROM transaction_category_amounts ca
+                                JOIN tree ON tree.category_id = ca.category_id
+                                WHERE ca.transaction_id = t.transaction_id))
+  AND ($4::text = '' OR t.merchant_id = NULLIF($4::text, '')::uuid)
+  AND ($5::text = '' OR t.transaction_type::text = $5)
+  AND ($6::bigint IS NULL OR t.amount >= $6)
+  AND ($7::bigint IS NULL OR t.amount <= $7)
+  AND ($8::timestamptz = '0001-01-01 00:00:00Z' OR t.date >= $8)
+  AND ($9::timestamptz = '0001-01-01 00:00:00Z' OR t.date < $9)
+  AND ($10::text = '' OR to_tsvector('simple', t.name || ' ' || t.notes) @@ to_tsquery('simple', $10))`
+
+// CountSearchTransactions counts the transactions SearchTransactions pages through
+func (q *Queries) CountSearchTransactions(ctx context.Context, args SearchTransactionsParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction.go -> CountSearchTransactions()").Debug()
+	row := q.db.QueryRowContext(ctx, countSearchTransactions, args.UserID, args.AccountID, args.CategoryID,
+		args.MerchantID, args.TransactionType, args.MinAmount, args.MaxAmount, args.From, args.To,
+		prefixQuery(args.Query))
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+// prefixQuery turns the words of a search into a tsquery matching names and notes holding words starting with
+// every one of them. Anything but letters and digits is dropped so the search cannot break the tsquery syntax
+func prefixQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := range words {
+		words[i] += ":*"
+	}
+	return strings.Join(words, " & ")
+}
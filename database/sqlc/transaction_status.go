@@ -0,0 +1,59 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+)
+
+const updateTransactionStatus = `--name: UpdateTransactionStatus :one
+UPDATE transactions SET status = $2
+WHERE transaction_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING transaction_id, user_id, account_id, category_id, name, transaction_type, amount, notes, date, created_at, deleted_at, transfer_id, merchant_id, currency, status`
+
+type UpdateTransactionStatusParams struct {
+	TransactionID model.TransactionID     `json:"transaction_id"`
+	Status        model.TransactionStatus `json:"status"`
+}
+
+// UpdateTransactionStatus moves a transaction to another status, the status has no effect on the account balance
+func (q *Queries) UpdateTransactionStatus(ctx context.Context, args UpdateTransactionStatusParams) (model.Transaction, error) {
+	q.logs.WithField("func", "database/sqlc/transaction_status.go -> UpdateTransactionStatus()").Debug()
+	row := q.db.QueryRowContext(ctx, updateTransactionStatus, args.TransactionID, args.Status)
+	var transaction model.Transaction
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.UserID,
+		&transaction.AccountID,
+		&transaction.CategoryID,
+		&transaction.Name,
+		&transaction.TransactionType,
+		&transaction.Amount,
+		&transaction.Notes,
+		&transaction.Date,
+		&transaction.CreatedAt,
+		&transaction.DeletedAt,
+		&transaction.TransferID,
+		&transaction.MerchantID,
+		&transaction.Currency,
+		&transaction.Status,
+	)
+	return transaction, err
+}
+
+const getPendingAmount = `--name: GetPendingAmount :one
+SELECT COALESCE(SUM(CASE WHEN transaction_type IN ('expense', 'transfer_out') THEN -amount ELSE amount END), 0)::bigint
+FROM transactions
+WHERE account_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND status = 'pending'`
+
+// GetPendingAmount returns the effect the pending transactions of an account have on its balance, the cleared
+// balance is the balance without it
+func (q *Queries) GetPendingAmount(ctx context.Context, id model.AccountID) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transaction_status.go -> GetPendingAmount()").Debug()
+	row := q.db.QueryRowContext(ctx, getPendingAmount, id)
+	var amount int64
+	err := row.Scan(&amount)
+	return amount, err
+}
@@ -0,0 +1,348 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrAccountNotFound is returned when a transaction is written against an account that does not exist
+	ErrAccountNotFound = errors.New("account not found or deleted")
+	// ErrAccountNotOwned is returned when a transaction is written against an account of another user
+	ErrAccountNotOwned = errors.New("account does not belong to user")
+	// ErrTransferLeg is returned when a transaction that is part of a transfer is changed on its own
+	ErrTransferLeg = errors.New("transaction is part of a transfer, change it through the transfer")
+	// ErrSplitsUnbalanced is returned when the splits of a transaction do not add up to its amount
+	ErrSplitsUnbalanced = errors.New("split amounts do not add up to the transaction amount")
+	// ErrTransactionReconciled is returned when a reconciled transaction is changed without unlocking it
+	ErrTransactionReconciled = errors.New("transaction is reconciled, unlock it to change it")
+	// ErrAmountCurrency is returned when an amount is given in a currency other than the currency of its account
+	ErrAmountCurrency = errors.New("amount is not in the currency of the account")
+)
+
+type DeleteTransactionParams struct {
+	TransactionID model.TransactionID `json:"transaction_id"`
+	UserID        model.UserID        `json:"user_id"`
+	// Unlock lets a reconciled transaction be deleted
+	Unlock bool `json:"unlock"`
+}
+
+type SetTransactionStatusParams struct {
+	UserID         model.UserID            `json:"user_id"`
+	TransactionIDs []model.TransactionID   `json:"transaction_ids"`
+	Status         model.TransactionStatus `json:"status"`
+	// Unlock lets reconciled transactions be moved back to another status
+	Unlock bool `json:"unlock"`
+}
+
+// CreateTransactionTx creates a transaction and applies its amount to the account balance
+func (r SQLRepo) CreateTransactionTx(ctx context.Context, args CreateTransactionParams) (model.Transaction, error) {
+	r.logs.WithField("func", "database/sqlc/transaction_tx.go -> CreateTransactionTx()").Debug()
+	var transaction model.Transaction
+	err := r.execTx(ctx, func(q *Queries) error {
+		var err error
+		transaction, err = q.createTransaction(ctx, args)
+		return err
+	})
+	return transaction, err
+}
+
+// UpdateTransactionTx reverses the effect the old transaction had on its account and applies the updated one
+func (r SQLRepo) UpdateTransactionTx(ctx context.Context, args UpdateTransactionParams) (model.Transaction, error) {
+	r.logs.WithField("func", "database/sqlc/transaction_tx.go -> UpdateTransactionTx()").Debug()
+	var transaction model.Transaction
+	err := r.execTx(ctx, func(q *Queries) error {
+		if err := checkSplits(args.Amount, args.Splits); err != nil {
+			return err
+		}
+		if err := q.checkCategories(ctx, args.UserID, transactionCategories(args.CategoryID, args.Splits)...); err != nil {
+			return err
+		}
+		if err := q.checkMerchant(ctx, args.UserID, args.MerchantID); err != nil {
+			return err
+		}
+		old, err := q.getOwnedTransactionForUpdate(ctx, args.TransactionID, args.UserID)
+		if err != nil {
+			return err
+		}
+		if err = checkUnlocked(old, args.Unlock); err != nil {
+			return err
+		}
+		accounts, err := q.lockOwnedAccounts(ctx, args.UserID, old.AccountID, args.AccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkCurrency(accounts[args.AccountID], args.Currency); err != nil {
+			return err
+		}
+		if err = q.reverseTransaction(ctx, old); err != nil {
+			return err
+		}
+		if err = q.DeleteSplitsByTransactionID(ctx, old.ID); err != nil {
+			return err
+		}
+		transaction, err = q.UpdateTransaction(ctx, args)
+		if err != nil {
+			return err
+		}
+		if transaction.Splits, err = q.createSplits(ctx, transaction.ID, args.Splits); err != nil {
+			return err
+		}
+		return q.applyTransaction(ctx, transaction)
+	})
+	return transaction, err
+}
+
+// DeleteTransactionTx deletes a transaction and reverses the effect it had on its account
+func (r SQLRepo) DeleteTransactionTx(ctx context.Context, args DeleteTransactionParams) (time.Time, error) {
+	r.logs.WithField("func", "database/sqlc/transaction_tx.go -> DeleteTransactionTx()").Debug()
+	var deletedAt time.Time
+	err := r.execTx(ctx, func(q *Queries) error {
+		old, err := q.getOwnedTransactionForUpdate(ctx, args.TransactionID, args.UserID)
+		if err != nil {
+			return err
+		}
+		if err = checkUnlocked(old, args.Unlock); err != nil {
+			return err
+		}
+		if _, err = q.lockOwnedAccounts(ctx, args.UserID, old.AccountID); err != nil {
+			return err
+		}
+		deletedAt, err = q.DeleteTransaction(ctx, old.ID)
+		if err != nil {
+			return err
+		}
+		return q.reverseTransaction(ctx, old)
+	})
+	return deletedAt, err
+}
+
+// SetTransactionStatusTx moves transactions of the user to a status, transfer legs included as the status does not
+// change their balances. A transaction of another user fails the whole change as not found
+func (r SQLRepo) SetTransactionStatusTx(ctx context.Context, args SetTransactionStatusParams) ([]model.Transaction, error) {
+	r.logs.WithField("func", "database/sqlc/transaction_tx.go -> SetTransactionStatusTx()").Debug()
+	var transactions []model.Transaction
+	err := r.execTx(ctx, func(q *Queries) error {
+		// the transactions are locked in a consistent order to avoid deadlocks
+		ids := append([]model.TransactionID(nil), args.TransactionIDs...)
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for i, id := range ids {
+			if i > 0 && id == ids[i-1] {
+				continue
+			}
+			transaction, err := q.GetTransactionForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			if transaction.UserID != args.UserID {
+				return sql.ErrNoRows
+			}
+			if transaction.Status == args.Status {
+				transactions = append(transactions, transaction)
+				continue
+			}
+			if err = checkUnlocked(transaction, args.Unlock); err != nil {
+				return err
+			}
+			transaction, err = q.UpdateTransactionStatus(ctx, UpdateTransactionStatusParams{
+				TransactionID: id,
+				Status:        args.Status,
+			})
+			if err != nil {
+				return err
+			}
+			transactions = append(transactions, transaction)
+		}
+		return nil
+	})
+	return transactions, err
+}
+
+// createTransaction creates a transaction with its splits and applies it to the account balance, it must run
+// inside a database transaction
+func (q *Queries) createTransaction(ctx context.Context, args CreateTransactionParams) (model.Transaction, error) {
+	if err := checkSplits(args.Amount, args.Splits); err != nil {
+		return model.Transaction{}, err
+	}
+	if err := q.checkCategories(ctx, args.UserID, transactionCategories(args.CategoryID, args.Splits)...); err != nil {
+		return model.Transaction{}, err
+	}
+	if err := q.checkMerchant(ctx, args.UserID, args.MerchantID); err != nil {
+		return model.Transaction{}, err
+	}
+	accounts, err := q.lockOwnedAccounts(ctx, args.UserID, args.AccountID)
+	if err != nil {
+		return model.Transaction{}, err
+	}
+	if err = checkCurrency(accounts[args.AccountID], args.Currency); err != nil {
+		return model.Transaction{}, err
+	}
+	transaction, err := q.CreateTransaction(ctx, args)
+	if err != nil {
+		return model.Transaction{}, err
+	}
+	if transaction.Splits, err = q.createSplits(ctx, transaction.ID, args.Splits); err != nil {
+		return model.Transaction{}, err
+	}
+	return transaction, q.applyTransaction(ctx, transaction)
+}
+
+// getOwnedTransactionForUpdate locks the transaction, a transaction of another user is reported as not found
+// and transfer legs can only be changed through their transfer
+func (q *Queries) getOwnedTransactionForUpdate(ctx context.Context, id model.TransactionID, userID model.UserID) (model.Transaction, error) {
+	transaction, err := q.GetTransactionForUpdate(ctx, id)
+	if err != nil {
+		return model.Transaction{}, err
+	}
+	if transaction.UserID != userID {
+		return model.Transaction{}, sql.ErrNoRows
+	}
+	if transaction.TransferID != "" {
+		return model.Transaction{}, ErrTransferLeg
+	}
+	return transaction, nil
+}
+
+// checkUnlocked checks a transaction can be changed, a reconciled transaction only can when it is unlocked
+func checkUnlocked(transaction model.Transaction, unlock bool) error {
+	if transaction.Status == model.Reconciled && !unlock {
+		return ErrTransactionReconciled
+	}
+	return nil
+}
+
+// lockOwnedAccounts locks the accounts in a consistent order to avoid deadlocks and checks the user owns them
+func (q *Queries) lockOwnedAccounts(ctx context.Context, userID model.UserID, ids ...model.AccountID) (map[model.AccountID]model.Account, error) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	accounts := make(map[model.AccountID]model.Account, len(ids))
+	for _, id := range ids {
+		if _, ok := accounts[id]; ok {
+			continue
+		}
+		account, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrAccountNotFound
+			}
+			return nil, err
+		}
+		if account.UserID != userID {
+			return nil, ErrAccountNotOwned
+		}
+		accounts[id] = account
+	}
+	return accounts, nil
+}
+
+// checkCurrency checks an amount given in currency can be posted to the account, an amount given without a
+// currency is taken to be in the currency of the account
+func checkCurrency(account model.Account, currency utils.CurrencyCode) error {
+	if currency != "" && currency != account.Currency {
+		return ErrAmountCurrency
+	}
+	return nil
+}
+
+// checkSplits checks the splits of a transaction, if it has any, add up to its amount
+func checkSplits(amount int64, splits []CreateSplitParams) error {
+	if len(splits) == 0 {
+		return nil
+	}
+	var total int64
+	for _, split := range splits {
+		total += split.Amount
+	}
+	if total != amount {
+		return ErrSplitsUnbalanced
+	}
+	return nil
+}
+
+// transactionCategories returns the category of a transaction along with the categories of its splits
+func transactionCategories(id model.CategoryID, splits []CreateSplitParams) []model.CategoryID {
+	ids := []model.CategoryID{id}
+	for _, split := range splits {
+		ids = append(ids, split.CategoryID)
+	}
+	return ids
+}
+
+// createSplits splits the transaction across the categories of the splits
+func (q *Queries) createSplits(ctx context.Context, id model.TransactionID, args []CreateSplitParams) ([]model.TransactionSplit, error) {
+	var splits []model.TransactionSplit
+	for _, arg := range args {
+		arg.TransactionID = id
+		split, err := q.CreateSplit(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, split)
+	}
+	return splits, nil
+}
+
+// applyTransaction adds the transaction amount to the balance of its account and journals it
+func (q *Queries) applyTransaction(ctx context.Context, transaction model.Transaction) error {
+	amount := transaction.TransactionType.SignedAmount(transaction.Amount)
+	account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		AccountID: transaction.AccountID,
+		Amount:    amount,
+	})
+	if err != nil {
+		return err
+	}
+	return q.postTransaction(ctx, transaction, account)
+}
+
+// reverseTransaction removes the transaction amount from the balance of its account and journals the reversal of
+// its entry
+func (q *Queries) reverseTransaction(ctx context.Context, transaction model.Transaction) error {
+	_, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+		AccountID: transaction.AccountID,
+		Amount:    -transaction.TransactionType.SignedAmount(transaction.Amount),
+	})
+	if err != nil {
+		return err
+	}
+	return q.ReversePostingsByTransactionID(ctx, transaction.ID)
+}
+
+// postTransaction writes the journal entry of a transaction, the account it was made on is one side and
+// the income, expense or transfer ledger the other with a posting per split category
+func (q *Queries) postTransaction(ctx context.Context, transaction model.Transaction, account model.Account) error {
+	amount := transaction.TransactionType.SignedAmount(transaction.Amount)
+	postings := []CreatePostingParams{
+		{
+			LedgerAccount: account.Type.Ledger(),
+			AccountID:     account.AccountID,
+			Amount:        amount,
+		},
+	}
+	if len(transaction.Splits) == 0 {
+		postings = append(postings, CreatePostingParams{
+			LedgerAccount: transaction.TransactionType.Ledger(),
+			CategoryID:    transaction.CategoryID,
+			Amount:        -amount,
+		})
+	}
+	for _, split := range transaction.Splits {
+		postings = append(postings, CreatePostingParams{
+			LedgerAccount: transaction.TransactionType.Ledger(),
+			CategoryID:    split.CategoryID,
+			Amount:        -transaction.TransactionType.SignedAmount(split.Amount),
+		})
+	}
+	for _, posting := range postings {
+		posting.TransactionID = transaction.ID
+		posting.UserID = transaction.UserID
+		if _, err := q.CreatePosting(ctx, posting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+	"time"
+)
+
+const createTransfer = `--name: CreateTransfer :one
+INSERT INTO transfers (user_id, from_account_id, to_account_id, amount, notes, date)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING transfer_id, user_id, from_account_id, to_account_id, amount, notes, date, created_at, deleted_at`
+
+type CreateTransferParams struct {
+	UserID        model.UserID    `json:"user_id"`
+	FromAccountID model.AccountID `json:"from_account_id"`
+	ToAccountID   model.AccountID `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Notes         string          `json:"notes"`
+	Date          time.Time       `json:"date"`
+	// Currency is the currency the amount was given in, it is taken in the currency of the accounts when it is
+	// left out
+	Currency utils.CurrencyCode `json:"currency"`
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, args CreateTransferParams) (model.Transfer, error) {
+	q.logs.WithField("func", "database/sqlc/transfer.go -> CreateTransfer()").Debug()
+	row := q.db.QueryRowContext(ctx, createTransfer, args.UserID, args.FromAccountID, args.ToAccountID, args.Amount,
+		args.Notes, args.Date)
+	var transfer model.Transfer
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.UserID,
+		&transfer.FromAccountID,
+		&transfer.ToAccountID,
+		&transfer.Amount,
+		&transfer.Notes,
+		&transfer.Date,
+		&transfer.CreatedAt,
+		&transfer.DeletedAt,
+	)
+	return transfer, err
+}
+
+const updateTransfer = `--name: UpdateTransfer :one
+UPDATE transfers SET from_account_id = $3,
+to_account_id = $4,
+amount = $5,
+notes = $6,
+date = $7
+WHERE transfer_id = $1
+AND user_id = $2
+AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING transfer_id, user_id, from_account_id, to_account_id, amount, notes, date, created_at, deleted_at`
+
+type UpdateTransferParams struct {
+	TransferID    model.TransferID `json:"transfer_id"`
+	UserID        model.UserID     `json:"user_id"`
+	FromAccountID model.AccountID  `json:"from_account_id"`
+	ToAccountID   model.AccountID  `json:"to_account_id"`
+	Amount        int64            `json:"amount"`
+	Notes         string           `json:"notes"`
+	Date          time.Time        `json:"date"`
+	// Currency is the currency the amount was given in, it is taken in the currency of the accounts when it is
+	// left out
+	Currency utils.CurrencyCode `json:"currency"`
+	// Unlock lets a transfer with a reconciled leg be updated, its new legs are pending as they no longer match
+	// the statement the old ones were reconciled with
+	Unlock bool `json:"unlock"`
+}
+
+func (q *Queries) UpdateTransfer(ctx context.Context, args UpdateTransferParams) (model.Transfer, error) {
+	q.logs.WithField("func", "database/sqlc/transfer.go -> UpdateTransfer()").Debug()
+	row := q.db.QueryRowContext(ctx, updateTransfer, args.TransferID, args.UserID, args.FromAccountID, args.ToAccountID,
+		args.Amount, args.Notes, args.Date)
+	var transfer model.Transfer
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.UserID,
+		&transfer.FromAccountID,
+		&transfer.ToAccountID,
+		&transfer.Amount,
+		&transfer.Notes,
+		&transfer.Date,
+		&transfer.CreatedAt,
+		&transfer.DeletedAt,
+	)
+	return transfer, err
+}
+
+const getTransfer = `--name: GetTransferByID :one
+SELECT * FROM transfers
+WHERE transfer_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1`
+
+func (q *Queries) GetTransferByID(ctx context.Context, id model.TransferID) (model.Transfer, error) {
+	q.logs.WithField("func", "database/sqlc/transfer.go -> GetTransferByID()").Debug()
+	row := q.db.QueryRowContext(ctx, getTransfer, id)
+	var transfer model.Transfer
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.UserID,
+		&transfer.FromAccountID,
+		&transfer.ToAccountID,
+		&transfer.Amount,
+		&transfer.Notes,
+		&transfer.Date,
+		&transfer.CreatedAt,
+		&transfer.DeletedAt,
+	)
+	return transfer, err
+}
+
+const getTransferForUpdate = `--name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE transfer_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+LIMIT 1
+FOR NO KEY UPDATE`
+
+// GetTransferForUpdate returns the transfer and locks its row until the surrounding database transaction ends
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id model.TransferID) (model.Transfer, error) {
+	q.logs.WithField("func", "database/sqlc/transfer.go -> GetTransferForUpdate()").Debug()
+	row := q.db.QueryRowContext(ctx, getTransferForUpdate, id)
+	var transfer model.Transfer
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.UserID,
+		&transfer.FromAccountID,
+		&transfer.ToAccountID,
+		&transfer.Amount,
+		&transfer.Notes,
+		&transfer.Date,
+		&transfer.CreatedAt,
+		&transfer.DeletedAt,
+	)
+	return transfer, err
+}
+
+const listTransfers = `--name: ListTransfers :many
+SELECT * FROM transfers
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $3
+AND date < $4
+AND ($6::text = '' OR (date, transfer_id) < ($5::timestamptz, NULLIF($6::text, '')::uuid))
+ORDER BY date DESC, transfer_id DESC
+LIMIT  $2`
+
+type ListTransfersParams struct {
+	UserID model.UserID `json:"user_id"`
+	Limit  int32        `json:"limit"`
+	From   time.Time    `json:"from"`
+	To     time.Time    `json:"to"`
+	Cursor model.Cursor `json:"cursor"`
+}
+
+func (q *Queries) ListTransfers(ctx context.Context, args ListTransfersParams) ([]model.Transfer, error) {
+	q.logs.WithField("func", "database/sqlc/transfer.go -> ListTransfers()").Debug()
+	rows, err := q.db.QueryContext(ctx, listTransfers, args.UserID, args.Limit, args.From, args.To, args.Cursor.Date,
+		args.Cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+		q.logs.WithError(err).Warn()
+	}()
+	var transfers []model.Transfer
+	for rows.Next() {
+		var transfer model.Transfer
+		err = rows.Scan(
+			&transfer.ID,
+			&transfer.UserID,
+			&transfer.FromAccountID,
+			&transfer.ToAccountID,
+			&transfer.Amount,
+			&transfer.Notes,
+			&transfer.Date,
+			&transfer.CreatedAt,
+			&transfer.DeletedAt,
+		)
+		transfers = append(transfers, transfer)
+	}
+	return transfers, err
+}
+
+const countTransfers = `--name: CountTransfers :one
+SELECT COUNT(*) FROM transfers
+WHERE user_id = $1
+AND deleted_at = '0001-01-01 00:00:00Z'
+AND date > $2
+AND date < $3`
+
+// CountTransfers counts the transfers ListTransfers pages through
+func (q *Queries) CountTransfers(ctx context.Context, args ListTransfersParams) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/transfer.go -> CountTransfers()").Debug()
+	row := q.db.QueryRowContext(ctx, countTransfers, args.UserID, args.From, args.To)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteTransfer = `--name: DeleteTransfer :one
+UPDATE transfers SET deleted_at = now()
+WHERE transfer_id = $1
+  AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING deleted_at`
+
+func (q *Queries) DeleteTransfer(ctx context.Context, id model.TransferID) (time.Time, error) {
+	q.logs.WithField("func", "database/sqlc/transfer.go -> DeleteTransfer()").Debug()
+	row := q.db.QueryRowContext(ctx, deleteTransfer, id)
+	var transfer model.Transfer
+	err := row.Scan(
+		&transfer.DeletedAt,
+	)
+	return transfer.DeletedAt, err
+}
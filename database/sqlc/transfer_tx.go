@@ -0,0 +1,169 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCurrencyMismatch is returned when a transfer is made between accounts of different currencies
+var ErrCurrencyMismatch = errors.New("accounts do not have the same currency")
+
+type DeleteTransferParams struct {
+	TransferID model.TransferID `json:"transfer_id"`
+	UserID     model.UserID     `json:"user_id"`
+	// Unlock lets a transfer with a reconciled leg be deleted
+	Unlock bool `json:"unlock"`
+}
+
+// CreateTransferTx creates a transfer with its two legs and moves the amount between the account balances
+func (r SQLRepo) CreateTransferTx(ctx context.Context, args CreateTransferParams) (model.Transfer, error) {
+	r.logs.WithField("func", "database/sqlc/transfer_tx.go -> CreateTransferTx()").Debug()
+	var transfer model.Transfer
+	err := r.execTx(ctx, func(q *Queries) error {
+		accounts, err := q.lockTransferAccounts(ctx, args.UserID, args.FromAccountID, args.ToAccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkCurrency(accounts[args.FromAccountID], args.Currency); err != nil {
+			return err
+		}
+		transfer, err = q.CreateTransfer(ctx, args)
+		if err != nil {
+			return err
+		}
+		transfer.Transactions, err = q.createTransferLegs(ctx, transfer, accounts)
+		return err
+	})
+	return transfer, err
+}
+
+// UpdateTransferTx replaces the legs of a transfer, reversing the old legs before the new ones are applied
+func (r SQLRepo) UpdateTransferTx(ctx context.Context, args UpdateTransferParams) (model.Transfer, error) {
+	r.logs.WithField("func", "database/sqlc/transfer_tx.go -> UpdateTransferTx()").Debug()
+	var transfer model.Transfer
+	err := r.execTx(ctx, func(q *Queries) error {
+		old, err := q.getOwnedTransferForUpdate(ctx, args.TransferID, args.UserID)
+		if err != nil {
+			return err
+		}
+		accounts, err := q.lockTransferAccounts(ctx, args.UserID, args.FromAccountID, args.ToAccountID, old.FromAccountID, old.ToAccountID)
+		if err != nil {
+			return err
+		}
+		if err = checkCurrency(accounts[args.FromAccountID], args.Currency); err != nil {
+			return err
+		}
+		if err = q.removeTransferLegs(ctx, old.ID, args.Unlock); err != nil {
+			return err
+		}
+		transfer, err = q.UpdateTransfer(ctx, args)
+		if err != nil {
+			return err
+		}
+		transfer.Transactions, err = q.createTransferLegs(ctx, transfer, accounts)
+		return err
+	})
+	return transfer, err
+}
+
+// DeleteTransferTx deletes a transfer with its legs and moves the amount back between the account balances
+func (r SQLRepo) DeleteTransferTx(ctx context.Context, args DeleteTransferParams) (time.Time, error) {
+	r.logs.WithField("func", "database/sqlc/transfer_tx.go -> DeleteTransferTx()").Debug()
+	var deletedAt time.Time
+	err := r.execTx(ctx, func(q *Queries) error {
+		old, err := q.getOwnedTransferForUpdate(ctx, args.TransferID, args.UserID)
+		if err != nil {
+			return err
+		}
+		if _, err = q.lockOwnedAccounts(ctx, args.UserID, old.FromAccountID, old.ToAccountID); err != nil {
+			return err
+		}
+		if err = q.removeTransferLegs(ctx, old.ID, args.Unlock); err != nil {
+			return err
+		}
+		deletedAt, err = q.DeleteTransfer(ctx, old.ID)
+		return err
+	})
+	return deletedAt, err
+}
+
+// getOwnedTransferForUpdate locks the transfer, a transfer of another user is reported as not found
+func (q *Queries) getOwnedTransferForUpdate(ctx context.Context, id model.TransferID, userID model.UserID) (model.Transfer, error) {
+	transfer, err := q.GetTransferForUpdate(ctx, id)
+	if err != nil {
+		return model.Transfer{}, err
+	}
+	if transfer.UserID != userID {
+		return model.Transfer{}, sql.ErrNoRows
+	}
+	return transfer, nil
+}
+
+// lockTransferAccounts locks every account the transfer touches, the first two are the accounts
+// the transfer is made between and must share a currency
+func (q *Queries) lockTransferAccounts(ctx context.Context, userID model.UserID, from, to model.AccountID, others ...model.AccountID) (map[model.AccountID]model.Account, error) {
+	accounts, err := q.lockOwnedAccounts(ctx, userID, append([]model.AccountID{from, to}, others...)...)
+	if err != nil {
+		return nil, err
+	}
+	if accounts[from].Currency != accounts[to].Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	return accounts, nil
+}
+
+// createTransferLegs records the transfer as a transfer_out transaction on the source account and
+// a transfer_in transaction on the destination account
+func (q *Queries) createTransferLegs(ctx context.Context, transfer model.Transfer, accounts map[model.AccountID]model.Account) ([]model.Transaction, error) {
+	legs := []CreateTransactionParams{
+		{
+			AccountID:       transfer.FromAccountID,
+			Name:            fmt.Sprintf("Transfer to %s", accounts[transfer.ToAccountID].Name),
+			TransactionType: model.TransferOut,
+		},
+		{
+			AccountID:       transfer.ToAccountID,
+			Name:            fmt.Sprintf("Transfer from %s", accounts[transfer.FromAccountID].Name),
+			TransactionType: model.TransferIn,
+		},
+	}
+	transactions := make([]model.Transaction, 0, len(legs))
+	for _, leg := range legs {
+		leg.UserID = transfer.UserID
+		leg.Amount = transfer.Amount
+		leg.Notes = transfer.Notes
+		leg.Date = transfer.Date
+		leg.TransferID = transfer.ID
+		transaction, err := q.CreateTransaction(ctx, leg)
+		if err != nil {
+			return nil, err
+		}
+		if err = q.applyTransaction(ctx, transaction); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// removeTransferLegs deletes the legs of a transfer and reverses their effect on the account balances, a transfer
+// with a reconciled leg is left as it is unless it is unlocked
+func (q *Queries) removeTransferLegs(ctx context.Context, id model.TransferID, unlock bool) error {
+	transactions, err := q.DeleteTransactionsByTransferID(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		if err = checkUnlocked(transaction, unlock); err != nil {
+			return err
+		}
+		if err = q.reverseTransaction(ctx, transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
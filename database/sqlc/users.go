@@ -2,24 +2,26 @@ package database
 
 import (
 	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
 	"context"
 	"time"
 )
 
 const createUser = `--name: CreateUser :one
-INSERT INTO users (email, password_hash)
-VALUES ($1, $2)
-RETURNING user_id, email, password_hash, password_changed_at, created_at, deleted_at  
+INSERT INTO users (email, password_hash, base_currency)
+VALUES ($1, $2, $3)
+RETURNING user_id, email, password_hash, password_changed_at, created_at, deleted_at, base_currency
 `
 
 type CreateUserParams struct {
-	Email        string `json:"email"`
-	PasswordHash string `json:"password_hash"`
+	Email        string             `json:"email"`
+	PasswordHash string             `json:"password_hash"`
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, args CreateUserParams) (model.User, error) {
 	q.logs.WithField("func", "database/sqlc/users.go -> CreateUser()").Debug()
-	row := q.db.QueryRowContext(ctx, createUser, args.Email, args.PasswordHash)
+	row := q.db.QueryRowContext(ctx, createUser, args.Email, args.PasswordHash, args.BaseCurrency)
 	var user model.User
 	err := row.Scan(
 		&user.ID,
@@ -28,6 +30,7 @@ func (q *Queries) CreateUser(ctx context.Context, args CreateUserParams) (model.
 		&user.PasswordChangedAt,
 		&user.CreatedAt,
 		&user.DeletedAt,
+		&user.BaseCurrency,
 	)
 	return user, err
 }
@@ -49,6 +52,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id model.UserID) (model.User,
 		&user.PasswordChangedAt,
 		&user.CreatedAt,
 		&user.DeletedAt,
+		&user.BaseCurrency,
 	)
 	return user, err
 }
@@ -70,6 +74,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (model.User,
 		&user.PasswordChangedAt,
 		&user.CreatedAt,
 		&user.DeletedAt,
+		&user.BaseCurrency,
 	)
 	return user, err
 }
@@ -77,19 +82,20 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (model.User,
 const listUsers = `--name: ListUsers :many
 SELECT * FROM users
 WHERE deleted_at = '0001-01-01 00:00:00Z'
-ORDER BY user_id
+AND ($3::text = '' OR (created_at, user_id) > ($2::timestamptz, NULLIF($3::text, '')::uuid))
+ORDER BY created_at, user_id
 LIMIT $1
-OFFSET $2
 `
 
+// ListUserParams lists users in the order they signed up, Cursor is the last user of the previous page
 type ListUserParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Limit  int32        `json:"limit"`
+	Cursor model.Cursor `json:"cursor"`
 }
 
 func (q *Queries) ListUsers(ctx context.Context, args ListUserParams) ([]model.User, error) {
 	q.logs.WithField("func", "database/sqlc/user.go -> ListUsers()").Debug()
-	rows, err := q.db.QueryContext(ctx, listUsers, args.Limit, args.Offset)
+	rows, err := q.db.QueryContext(ctx, listUsers, args.Limit, args.Cursor.Date, args.Cursor.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -107,12 +113,26 @@ func (q *Queries) ListUsers(ctx context.Context, args ListUserParams) ([]model.U
 			&user.PasswordChangedAt,
 			&user.CreatedAt,
 			&user.DeletedAt,
+			&user.BaseCurrency,
 		)
 		users = append(users, user)
 	}
 	return users, err
 }
 
+const countUsers = `--name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at = '0001-01-01 00:00:00Z'`
+
+// CountUsers counts the users ListUsers pages through
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	q.logs.WithField("func", "database/sqlc/user.go -> CountUsers()").Debug()
+	row := q.db.QueryRowContext(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 type UpdatePasswordParams struct {
 	UserID       model.UserID `json:"user_id"`
 	HashPassword string       `json:"hash_password"`
@@ -134,6 +154,45 @@ func (q Queries) UpdatePassword(ctx context.Context, args UpdatePasswordParams)
 	return user.PasswordChangedAt, err
 }
 
+type UpdateBaseCurrencyParams struct {
+	UserID       model.UserID       `json:"user_id"`
+	BaseCurrency utils.CurrencyCode `json:"base_currency"`
+}
+
+const updateBaseCurrency = `--name: UpdateBaseCurrency :one
+UPDATE users SET base_currency = $2
+WHERE user_id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING user_id, email, password_hash, password_changed_at, created_at, deleted_at, base_currency`
+
+func (q *Queries) UpdateBaseCurrency(ctx context.Context, args UpdateBaseCurrencyParams) (model.User, error) {
+	q.logs.WithField("func", "database/sqlc/user.go -> UpdateBaseCurrency()").Debug()
+	row := q.db.QueryRowContext(ctx, updateBaseCurrency, args.UserID, args.BaseCurrency)
+	var user model.User
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.PasswordChangedAt,
+		&user.CreatedAt,
+		&user.DeletedAt,
+		&user.BaseCurrency,
+	)
+	return user, err
+}
+
+const setDefaultBaseCurrency = `--name: SetDefaultBaseCurrency :exec
+UPDATE users SET base_currency = $2
+WHERE user_id = $1
+AND base_currency = ''
+AND deleted_at = '0001-01-01 00:00:00Z'`
+
+// SetDefaultBaseCurrency gives a user who has no base currency yet one, users who have one keep it
+func (q *Queries) SetDefaultBaseCurrency(ctx context.Context, args UpdateBaseCurrencyParams) error {
+	q.logs.WithField("func", "database/sqlc/user.go -> SetDefaultBaseCurrency()").Debug()
+	_, err := q.db.ExecContext(ctx, setDefaultBaseCurrency, args.UserID, args.BaseCurrency)
+	return err
+}
+
 const deleteUser = `--name: DeleteUser :exec
 UPDATE users SET deleted_at = now(),
 email = concat(email, '-DELETED-', uuid_generate_v4())
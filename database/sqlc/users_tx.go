@@ -0,0 +1,79 @@
+package database
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type SignupParams struct {
+	User CreateUserParams `json:"user"`
+	// Locale picks the signup template the user starts with, the default template is used when it has none
+	Locale string `json:"locale"`
+	// SkipTemplate creates the user with no categories or account
+	SkipTemplate bool `json:"skip_template"`
+}
+
+// CreateUserTx creates a user along with the categories and account of the signup template of their locale. A user
+// who gives no base currency takes the currency of the template, and the account is opened in the base currency of
+// the user, USD when neither has one. A user left without a base currency takes that of the first account they open
+func (r SQLRepo) CreateUserTx(ctx context.Context, args SignupParams) (model.User, error) {
+	r.logs.WithField("func", "database/sqlc/users_tx.go -> CreateUserTx()").Debug()
+	var user model.User
+	err := r.execTx(ctx, func(q *Queries) error {
+		var template model.SignupTemplate
+		if !args.SkipTemplate {
+			var err error
+			template, err = q.GetSignupTemplateForLocale(ctx, model.LocaleFallbacks(args.Locale))
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+		}
+		if args.User.BaseCurrency == "" {
+			args.User.BaseCurrency = template.Currency
+		}
+		// the account of the template needs a currency, otherwise the user takes that of their first account
+		if args.User.BaseCurrency == "" && template.AccountName != "" {
+			args.User.BaseCurrency = utils.USD
+		}
+		var err error
+		user, err = q.CreateUser(ctx, args.User)
+		if err != nil || template.ID == "" {
+			return err
+		}
+		if err = q.createTemplateCategories(ctx, user.ID, "", template.Categories); err != nil {
+			return err
+		}
+		if template.AccountName == "" {
+			return nil
+		}
+		_, err = q.CreateAccount(ctx, CreateAccountParams{
+			UserID:      user.ID,
+			AccountName: template.AccountName,
+			AccountType: template.AccountType,
+			Currency:    user.BaseCurrency,
+		})
+		return err
+	})
+	return user, err
+}
+
+// createTemplateCategories creates the categories of a template under parentID, each before its subcategories
+func (q *Queries) createTemplateCategories(ctx context.Context, userID model.UserID, parentID model.CategoryID, categories model.TemplateCategories) error {
+	for _, c := range categories {
+		category, err := q.CreateCategory(ctx, CreateCategoryParams{
+			ParentID: parentID,
+			UserID:   userID,
+			Name:     c.Name,
+		})
+		if err != nil {
+			return err
+		}
+		if err = q.createTemplateCategories(ctx, userID, category.ID, c.Categories); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package exporter
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	beancountDate     = "2006-01-02"
+	beancountTransfer = "Equity:Transfers"
+	beancountOpening  = "Equity:Opening-Balances"
+)
+
+// beancountWriter writes a Beancount ledger. Accounts are opened as Assets or Liabilities in their currency
+// and categories as Income or Expenses accounts the first time a transaction uses them. The stored balance of
+// every account is asserted at the end, padded from Equity:Opening-Balances when its transactions do not add
+// up to it, as happens when a balance was set by hand
+type beancountWriter struct {
+	w          io.Writer
+	categories map[model.CategoryID]model.Category
+	merchants  map[model.MerchantID]string
+	accounts   map[model.AccountID]model.Account
+	names      map[model.AccountID]string
+	totals     map[model.AccountID]int64
+	ledgers    map[string]string
+	used       map[string]bool
+	opened     time.Time
+	last       time.Time
+	exportedAt time.Time
+}
+
+func newBeancountWriter(w io.Writer) *beancountWriter {
+	return &beancountWriter{
+		w:          w,
+		categories: make(map[model.CategoryID]model.Category),
+		merchants:  make(map[model.MerchantID]string),
+		accounts:   make(map[model.AccountID]model.Account),
+		names:      make(map[model.AccountID]string),
+		totals:     make(map[model.AccountID]int64),
+		ledgers:    make(map[string]string),
+		used:       make(map[string]bool),
+	}
+}
+
+func (b *beancountWriter) Begin(data Data) error {
+	b.exportedAt = data.ExportedAt
+	for _, category := range data.Categories {
+		b.categories[category.ID] = category
+	}
+	for _, merchant := range data.Merchants {
+		b.merchants[merchant.ID] = merchant.Name
+	}
+	var out strings.Builder
+	fmt.Fprintf(&out, "; exported %s\n", data.ExportedAt.UTC().Format(time.RFC3339))
+	out.WriteString("option \"title\" \"FiberFinance export\"\n\n")
+
+	b.opened = data.Since
+	for _, account := range data.Accounts {
+		if b.opened.IsZero() || account.CreatedAt.Before(b.opened) {
+			b.opened = account.CreatedAt
+		}
+	}
+	if b.opened.IsZero() {
+		b.opened = data.ExportedAt
+	}
+	opened := b.opened.Format(beancountDate)
+	fmt.Fprintf(&out, "%s open %s\n", opened, beancountTransfer)
+	fmt.Fprintf(&out, "%s open %s\n", opened, beancountOpening)
+	for _, account := range data.Accounts {
+		root := "Assets"
+		if account.Type.Ledger() == model.LedgerLiability {
+			root = "Liabilities"
+		}
+		name := b.unique(root + ":" + beancountComponent(account.Name))
+		b.accounts[account.AccountID] = account
+		b.names[account.AccountID] = name
+		fmt.Fprintf(&out, "%s open %s %s\n", opened, name, account.Currency)
+	}
+	out.WriteString("\n")
+	_, err := io.WriteString(b.w, out.String())
+	return err
+}
+
+func (b *beancountWriter) Transactions(transactions []model.Transaction) error {
+	var out strings.Builder
+	for _, t := range transactions {
+		account, ok := b.accounts[t.AccountID]
+		if !ok {
+			return fmt.Errorf("transaction %s is on unknown account %s", t.ID, t.AccountID)
+		}
+		date := t.Date.Format(beancountDate)
+		if t.Date.After(b.last) {
+			b.last = t.Date
+		}
+
+		type posting struct {
+			account string
+			amount  int64
+		}
+		signed := t.TransactionType.SignedAmount(t.Amount)
+		b.totals[t.AccountID] += signed
+		postings := []posting{{b.names[t.AccountID], signed}}
+		if len(t.Splits) == 0 {
+			postings = append(postings, posting{b.ledger(&out, date, t.TransactionType, t.CategoryID), -signed})
+		}
+		for _, split := range t.Splits {
+			postings = append(postings, posting{
+				b.ledger(&out, date, t.TransactionType, split.CategoryID),
+				-t.TransactionType.SignedAmount(split.Amount),
+			})
+		}
+
+		fmt.Fprintf(&out, "%s *", date)
+		if payee, ok := b.merchants[t.MerchantID]; ok {
+			fmt.Fprintf(&out, " %s", beancountString(payee))
+		}
+		fmt.Fprintf(&out, " %s\n", beancountString(t.Name))
+		fmt.Fprintf(&out, "  id: %s\n", beancountString(string(t.ID)))
+		if t.Notes != "" {
+			fmt.Fprintf(&out, "  notes: %s\n", beancountString(t.Notes))
+		}
+		for _, p := range postings {
+			fmt.Fprintf(&out, "  %s  %s %s\n", p.account, utils.NewMoney(p.amount, account.Currency).Value(), account.Currency)
+		}
+		out.WriteString("\n")
+	}
+	_, err := io.WriteString(b.w, out.String())
+	return err
+}
+
+func (b *beancountWriter) Close() error {
+	// balance assertions hold at the start of their day, so they go the day after the last transaction
+	asserted := b.exportedAt
+	if b.last.After(asserted) {
+		asserted = b.last
+	}
+	asserted = asserted.AddDate(0, 0, 1)
+	ids := make([]model.AccountID, 0, len(b.accounts))
+	for id := range b.accounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return b.names[ids[i]] < b.names[ids[j]] })
+
+	var out strings.Builder
+	for _, id := range ids {
+		account := b.accounts[id]
+		if b.totals[id] != account.Balance {
+			fmt.Fprintf(&out, "%s pad %s %s\n", b.opened.Format(beancountDate), b.names[id], beancountOpening)
+		}
+		fmt.Fprintf(&out, "%s balance %s  %s %s\n",
+			asserted.Format(beancountDate), b.names[id], utils.NewMoney(account.Balance, account.Currency).Value(), account.Currency)
+	}
+	_, err := io.WriteString(b.w, out.String())
+	return err
+}
+
+// ledger returns the Beancount account the other side of a transaction is posted to, opening it on date
+// the first time it is used
+func (b *beancountWriter) ledger(out *strings.Builder, date string, transactionType model.TransactionType, id model.CategoryID) string {
+	var root string
+	switch transactionType.Ledger() {
+	case model.LedgerIncome:
+		root = "Income"
+	case model.LedgerExpense:
+		root = "Expenses"
+	default:
+		return beancountTransfer
+	}
+	key := root + ":" + string(id)
+	if name, ok := b.ledgers[key]; ok {
+		return name
+	}
+	path := categoryPath(b.categories, id)
+	if len(path) == 0 {
+		path = []string{"Uncategorized"}
+	}
+	components := make([]string, 0, len(path)+1)
+	components = append(components, root)
+	for _, name := range path {
+		components = append(components, beancountComponent(name))
+	}
+	name := b.unique(strings.Join(components, ":"))
+	b.ledgers[key] = name
+	fmt.Fprintf(out, "%s open %s\n\n", date, name)
+	return name
+}
+
+// unique returns name, with a number added when another account already has it
+func (b *beancountWriter) unique(name string) string {
+	candidate := name
+	for i := 2; b.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	b.used[candidate] = true
+	return candidate
+}
+
+// beancountComponent turns a name into a component of a Beancount account name, which may only hold
+// letters, digits and dashes and must start with a capital letter or a digit
+func beancountComponent(name string) string {
+	var component []rune
+	dash := false
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			dash = true
+			continue
+		}
+		if dash && len(component) > 0 {
+			component = append(component, '-')
+		}
+		dash = false
+		component = append(component, r)
+	}
+	if len(component) == 0 {
+		return "X"
+	}
+	component[0] = unicode.ToUpper(component[0])
+	if !unicode.IsUpper(component[0]) && !unicode.IsDigit(component[0]) {
+		return "X" + string(component)
+	}
+	return string(component)
+}
+
+// beancountString quotes s as a Beancount string
+func beancountString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	return `"` + s + `"`
+}
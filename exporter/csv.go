@@ -0,0 +1,131 @@
+package exporter
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"time"
+)
+
+// csvWriter writes a zip with accounts.csv, categories.csv, merchants.csv, transactions.csv and splits.csv.
+// Zip entries are written one after the other, so the splits are held back until the transactions are done
+type csvWriter struct {
+	zip    *zip.Writer
+	entry  io.Writer
+	out    *csv.Writer
+	splits *csv.Writer
+	buf    bytes.Buffer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	c := &csvWriter{zip: zip.NewWriter(w)}
+	c.splits = csv.NewWriter(&c.buf)
+	return c
+}
+
+func (c *csvWriter) Begin(data Data) error {
+	accounts := [][]string{{"account_id", "account_name", "account_type", "balance", "currency", "created_at"}}
+	for _, a := range data.Accounts {
+		accounts = append(accounts, []string{
+			string(a.AccountID), a.Name, string(a.Type), utils.NewMoney(a.Balance, a.Currency).Value(), string(a.Currency), formatTime(a.CreatedAt),
+		})
+	}
+	categories := [][]string{{"category_id", "parent_id", "name", "created_at"}}
+	for _, cat := range data.Categories {
+		categories = append(categories, []string{string(cat.ID), string(cat.ParentID), cat.Name, formatTime(cat.CreatedAt)})
+	}
+	merchants := [][]string{{"merchant_id", "name", "created_at"}}
+	for _, m := range data.Merchants {
+		merchants = append(merchants, []string{string(m.ID), m.Name, formatTime(m.CreatedAt)})
+	}
+	for _, file := range []struct {
+		name    string
+		records [][]string
+	}{
+		{"accounts.csv", accounts},
+		{"categories.csv", categories},
+		{"merchants.csv", merchants},
+	} {
+		if err := c.create(file.name); err != nil {
+			return err
+		}
+		if err := c.out.WriteAll(file.records); err != nil {
+			return err
+		}
+	}
+	if err := c.create("transactions.csv"); err != nil {
+		return err
+	}
+	if err := c.splits.Write([]string{"split_id", "transaction_id", "category_id", "amount", "notes"}); err != nil {
+		return err
+	}
+	return c.out.Write([]string{
+		"transaction_id", "date", "account_id", "category_id", "merchant_id", "transfer_id",
+		"transaction_type", "amount", "currency", "name", "notes", "created_at",
+	})
+}
+
+func (c *csvWriter) Transactions(transactions []model.Transaction) error {
+	for _, t := range transactions {
+		err := c.out.Write([]string{
+			string(t.ID), formatTime(t.Date), string(t.AccountID), string(t.CategoryID), string(t.MerchantID),
+			string(t.TransferID), string(t.TransactionType), utils.NewMoney(t.Amount, t.Currency).Value(), string(t.Currency), t.Name, t.Notes,
+			formatTime(t.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+		for _, s := range t.Splits {
+			err = c.splits.Write([]string{
+				string(s.ID), string(s.TransactionID), string(s.CategoryID), utils.NewMoney(s.Amount, t.Currency).Value(), s.Notes,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	c.out.Flush()
+	return c.out.Error()
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.create("splits.csv"); err != nil {
+		return err
+	}
+	c.splits.Flush()
+	if err := c.splits.Error(); err != nil {
+		return err
+	}
+	if _, err := c.buf.WriteTo(c.entry); err != nil {
+		return err
+	}
+	return c.zip.Close()
+}
+
+// create starts the next file of the zip, flushing what is left of the one before
+func (c *csvWriter) create(name string) error {
+	if c.out != nil {
+		c.out.Flush()
+		if err := c.out.Error(); err != nil {
+			return err
+		}
+	}
+	entry, err := c.zip.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	c.entry = entry
+	c.out = csv.NewWriter(entry)
+	return nil
+}
+
+// formatTime formats t as RFC 3339, the zero time as an empty field
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,89 @@
+// Package exporter writes everything a user has stored in the formats a user can take elsewhere
+package exporter
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"errors"
+	"io"
+	"time"
+)
+
+// Format is the file format of an export
+type Format string
+
+const (
+	// CSV is a zip holding a CSV file per kind of record
+	CSV  Format = "csv"
+	JSON Format = "json"
+	// Beancount is the plain-text double-entry ledger read by beancount and fava
+	Beancount Format = "beancount"
+)
+
+// ErrUnsupportedFormat is returned when an export is asked for in a format we cannot write
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Data is what an export starts with, the transactions follow in pages
+type Data struct {
+	Accounts   []model.Account
+	Categories []model.Category
+	Merchants  []model.Merchant
+	// Since is the date of the first transaction, accounts are opened on it when they were created later
+	Since      time.Time
+	ExportedAt time.Time
+}
+
+// Writer writes an export. Begin is called once, Transactions once per page of transactions in date order
+// and Close once all of them were written
+type Writer interface {
+	Begin(data Data) error
+	Transactions(transactions []model.Transaction) error
+	Close() error
+}
+
+// NewWriter returns a Writer writing an export in format to w
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w), nil
+	case JSON:
+		return newJSONWriter(w), nil
+	case Beancount:
+		return newBeancountWriter(w), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// ContentType returns the media type of an export in format
+func ContentType(format Format) string {
+	switch format {
+	case CSV:
+		return "application/zip"
+	case JSON:
+		return "application/json"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// Extension returns the file extension of an export in format
+func Extension(format Format) string {
+	if format == CSV {
+		return "zip"
+	}
+	return string(format)
+}
+
+// categoryPath returns the names of the category and its parents, root first
+func categoryPath(categories map[model.CategoryID]model.Category, id model.CategoryID) []string {
+	var path []string
+	seen := make(map[model.CategoryID]bool)
+	for id != "" && !seen[id] {
+		category, ok := categories[id]
+		if !ok {
+			break
+		}
+		seen[id] = true
+		path = append([]string{category.Name}, path...)
+		id = category.ParentID
+	}
+	return path
+}
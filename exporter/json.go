@@ -0,0 +1,71 @@
+package exporter
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonWriter writes a single JSON object, the transactions array is streamed a page at a time
+type jsonWriter struct {
+	w     io.Writer
+	first bool
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: w, first: true}
+}
+
+func (j *jsonWriter) Begin(data Data) error {
+	// nil slices are written as [] instead of null
+	if data.Accounts == nil {
+		data.Accounts = []model.Account{}
+	}
+	if data.Categories == nil {
+		data.Categories = []model.Category{}
+	}
+	if data.Merchants == nil {
+		data.Merchants = []model.Merchant{}
+	}
+	head, err := json.Marshal(struct {
+		ExportedAt time.Time        `json:"exported_at"`
+		Accounts   []model.Account  `json:"accounts"`
+		Categories []model.Category `json:"categories"`
+		Merchants  []model.Merchant `json:"merchants"`
+	}{
+		ExportedAt: data.ExportedAt,
+		Accounts:   data.Accounts,
+		Categories: data.Categories,
+		Merchants:  data.Merchants,
+	})
+	if err != nil {
+		return err
+	}
+	// leave the object open for the transactions
+	head = append(head[:len(head)-1], `,"transactions":[`...)
+	_, err = j.w.Write(head)
+	return err
+}
+
+func (j *jsonWriter) Transactions(transactions []model.Transaction) error {
+	for _, transaction := range transactions {
+		b, err := json.Marshal(transaction)
+		if err != nil {
+			return err
+		}
+		if !j.first {
+			b = append([]byte{','}, b...)
+		}
+		j.first = false
+		if _, err = j.w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	_, err := io.WriteString(j.w, "]}\n")
+	return err
+}
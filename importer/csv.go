@@ -0,0 +1,200 @@
+package importer
+
+import (
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SignConvention is how a CSV statement tells money in from money out
+type SignConvention string
+
+const (
+	// Signed amounts are negative when money left the account, the usual for bank accounts
+	Signed SignConvention = "signed"
+	// Inverted amounts are positive when money left the account, the usual for credit cards
+	Inverted SignConvention = "inverted"
+	// DebitCredit statements have a column for money out and another for money in
+	DebitCredit SignConvention = "debit_credit"
+)
+
+// DefaultDateFormat is the layout of CSV dates when a mapping does not give one
+const DefaultDateFormat = "2006-01-02"
+
+// ErrInvalidMapping is returned when a CSV mapping does not match the statement it is used on
+var ErrInvalidMapping = errors.New("invalid csv mapping")
+
+// CSVMapping tells which column of a CSV statement holds what, columns are matched by their header
+type CSVMapping struct {
+	Date string `json:"date"`
+	// DateFormat is a Go time layout such as 02/01/2006
+	DateFormat string `json:"date_format"`
+	// Amount is the column of signed amounts, used unless Sign is DebitCredit
+	Amount string `json:"amount"`
+	// Debit and Credit are the money out and money in columns when Sign is DebitCredit
+	Debit       string         `json:"debit"`
+	Credit      string         `json:"credit"`
+	Sign        SignConvention `json:"sign"`
+	Description string         `json:"description"`
+	// Delimiter defaults to a comma
+	Delimiter string `json:"delimiter"`
+}
+
+// Validate checks the mapping names the columns its sign convention needs
+func (m CSVMapping) Validate() error {
+	if m.Date == "" || m.Description == "" {
+		return fmt.Errorf("%w: date and description columns are required", ErrInvalidMapping)
+	}
+	switch m.Sign {
+	case "", Signed, Inverted:
+		if m.Amount == "" {
+			return fmt.Errorf("%w: amount column is required", ErrInvalidMapping)
+		}
+	case DebitCredit:
+		if m.Debit == "" || m.Credit == "" {
+			return fmt.Errorf("%w: debit and credit columns are required", ErrInvalidMapping)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported sign convention %q", ErrInvalidMapping, m.Sign)
+	}
+	if len([]rune(m.Delimiter)) > 1 {
+		return fmt.Errorf("%w: delimiter must be a single character", ErrInvalidMapping)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, mappings are saved as JSON
+func (m CSVMapping) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+// Scan implements sql.Scanner
+func (m *CSVMapping) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	}
+	return fmt.Errorf("cannot scan %T into CSVMapping", src)
+}
+
+// parseCSV reads a CSV statement with a header row using the mapping of opts
+func parseCSV(r io.Reader, opts Options) (Statement, error) {
+	m := opts.Mapping
+	if err := m.Validate(); err != nil {
+		return Statement{}, err
+	}
+	if m.DateFormat == "" {
+		m.DateFormat = DefaultDateFormat
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if m.Delimiter != "" {
+		reader.Comma = []rune(m.Delimiter)[0]
+	}
+	header, err := reader.Read()
+	if err != nil {
+		return Statement{}, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	column := func(name string) (int, error) {
+		i, ok := columns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("%w: column %q not found", ErrInvalidMapping, name)
+		}
+		return i, nil
+	}
+	dateCol, err := column(m.Date)
+	if err != nil {
+		return Statement{}, err
+	}
+	descCol, err := column(m.Description)
+	if err != nil {
+		return Statement{}, err
+	}
+	var amountCol, debitCol, creditCol int
+	if m.Sign == DebitCredit {
+		if debitCol, err = column(m.Debit); err != nil {
+			return Statement{}, err
+		}
+		if creditCol, err = column(m.Credit); err != nil {
+			return Statement{}, err
+		}
+	} else if amountCol, err = column(m.Amount); err != nil {
+		return Statement{}, err
+	}
+
+	var statement Statement
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Statement{}, err
+		}
+		field := func(i int) string {
+			if i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		// blank lines and footers without a date are not entries
+		if field(dateCol) == "" {
+			continue
+		}
+		date, err := time.Parse(m.DateFormat, field(dateCol))
+		if err != nil {
+			return Statement{}, fmt.Errorf("row %d: %w", row, err)
+		}
+		var amount int64
+		switch m.Sign {
+		case DebitCredit:
+			var debit, credit int64
+			if s := field(debitCol); s != "" {
+				if debit, err = ParseAmount(s, opts.Currency); err != nil {
+					return Statement{}, fmt.Errorf("row %d: %w", row, err)
+				}
+			}
+			if s := field(creditCol); s != "" {
+				if credit, err = ParseAmount(s, opts.Currency); err != nil {
+					return Statement{}, fmt.Errorf("row %d: %w", row, err)
+				}
+			}
+			amount = abs(credit) - abs(debit)
+		default:
+			if amount, err = ParseAmount(field(amountCol), opts.Currency); err != nil {
+				return Statement{}, fmt.Errorf("row %d: %w", row, err)
+			}
+			if m.Sign == Inverted {
+				amount = -amount
+			}
+		}
+		if amount == 0 {
+			continue
+		}
+		statement.Lines = append(statement.Lines, Line{
+			Date:        date,
+			Amount:      amount,
+			Description: field(descCol),
+		})
+	}
+	return statement, nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
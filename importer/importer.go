@@ -0,0 +1,115 @@
+// Package importer parses bank and mobile money statements into lines that can be imported as transactions
+package importer
+
+import (
+	"FiberFinanceAPI/utils"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format is the file format of a statement
+type Format string
+
+const (
+	CSV Format = "csv"
+	OFX Format = "ofx"
+	// QFX is the OFX flavour Quicken downloads come in
+	QFX Format = "qfx"
+	QIF Format = "qif"
+	// MPesa is the CSV export of an M-Pesa statement and MPesaText the text extracted from its PDF
+	MPesa     Format = "mpesa"
+	MPesaText Format = "mpesa_text"
+)
+
+var (
+	// ErrUnsupportedFormat is returned when a statement is in a format we cannot parse
+	ErrUnsupportedFormat = errors.New("unsupported statement format")
+	// ErrInvalidAmount is returned when an amount in a statement cannot be parsed
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrCurrencyMismatch is returned when a statement says it is in a currency other than the account currency
+	ErrCurrencyMismatch = errors.New("statement is not in the currency of the account")
+)
+
+// Line is a single entry of a statement. Amount is in minor units, negative when money left the account
+type Line struct {
+	Date        time.Time `json:"date"`
+	Amount      int64     `json:"amount"`
+	Description string    `json:"description"`
+	// Payee is who the money went to or came from, when the statement tells us
+	Payee string `json:"payee,omitempty"`
+	// ExternalID is the identifier the bank gave the entry, when it gives one
+	ExternalID string `json:"external_id,omitempty"`
+	// Fee is set on the charges a statement lists as entries of their own
+	Fee bool `json:"fee,omitempty"`
+}
+
+// Balance is the balance a statement gives for the account on a date
+type Balance struct {
+	Amount int64     `json:"amount"`
+	Date   time.Time `json:"date"`
+}
+
+// Statement is what was parsed from a statement file, LedgerBalance is nil unless the format carries one
+type Statement struct {
+	Lines         []Line   `json:"lines"`
+	LedgerBalance *Balance `json:"ledger_balance,omitempty"`
+}
+
+// Options tell Parse how to read a statement
+type Options struct {
+	// Mapping is required for CSV statements
+	Mapping CSVMapping
+	// Currency is the account currency, amounts are read in its minor units
+	Currency utils.CurrencyCode
+	// DayFirst reads QIF dates as day/month/year instead of month/day/year
+	DayFirst bool
+}
+
+// Parse parses a statement in format
+func Parse(format Format, r io.Reader, opts Options) (Statement, error) {
+	switch Format(strings.ToLower(string(format))) {
+	case CSV:
+		return parseCSV(r, opts)
+	case OFX, QFX:
+		return parseOFX(r, opts)
+	case QIF:
+		return parseQIF(r, opts)
+	case MPesa:
+		return parseMPesaCSV(r, opts)
+	case MPesaText:
+		return parseMPesaText(r, opts)
+	}
+	return Statement{}, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+}
+
+// ParseAmount parses a decimal amount such as -1,234.56 or (1234.56) into the minor units of currency.
+// Thousand separators, spaces and a trailing CR or DR marker are allowed, amounts in brackets or marked DR
+// are negative
+func ParseAmount(s string, currency utils.CurrencyCode) (int64, error) {
+	raw := s
+	s = strings.TrimSpace(strings.NewReplacer(",", "", " ", "", "\u00a0", "").Replace(s))
+	negative := false
+	upper := strings.ToUpper(s)
+	switch {
+	case strings.HasSuffix(upper, "DR"):
+		negative = true
+		s = s[:len(s)-2]
+	case strings.HasSuffix(upper, "CR"):
+		s = s[:len(s)-2]
+	}
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = !negative
+		s = s[1 : len(s)-1]
+	}
+	money, err := utils.ParseMoney(s, currency)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, raw)
+	}
+	if negative {
+		return -money.Amount, nil
+	}
+	return money.Amount, nil
+}
@@ -0,0 +1,199 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MPesaTimeLayout is the layout of the completion time of M-Pesa statement entries
+const MPesaTimeLayout = "2006-01-02 15:04:05"
+
+// mpesaLocation is the time zone M-Pesa statements are written in
+var mpesaLocation = time.FixedZone("EAT", 3*60*60)
+
+var (
+	// ErrMPesaHeader is returned when an M-Pesa CSV statement has no header row
+	ErrMPesaHeader = errors.New("m-pesa statement header not found")
+
+	// mpesaEntry matches an entry of the text of an M-Pesa PDF statement, details can wrap onto the lines after
+	// the amounts so whatever follows them is part of the details too
+	mpesaEntry = regexp.MustCompile(`^([A-Z0-9]{10})\s+(\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2}:\d{2})\s+(.*?)\s*` +
+		`\b(Completed|Failed|Pending|Cancelled|Reversed)\s+(-?[\d,]+\.\d{2})\s+(-?[\d,]+\.\d{2})\s*(.*)$`)
+	mpesaReceipt = regexp.MustCompile(`^[A-Z0-9]{10}\s+\d{4}-\d{2}-\d{2}\s`)
+)
+
+// parseMPesaCSV reads the CSV export of an M-Pesa statement, anything above its header row is skipped
+func parseMPesaCSV(r io.Reader, opts Options) (Statement, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	columns := map[string]int{}
+	for len(columns) == 0 {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return Statement{}, ErrMPesaHeader
+		}
+		if err != nil {
+			return Statement{}, err
+		}
+		for i, name := range record {
+			name = strings.ToLower(strings.Join(strings.Fields(strings.Trim(name, "\ufeff .")), " "))
+			switch {
+			case strings.HasPrefix(name, "receipt"):
+				columns["receipt"] = i
+			case strings.HasPrefix(name, "completion"):
+				columns["time"] = i
+			case name == "details":
+				columns["details"] = i
+			case strings.Contains(name, "status"):
+				columns["status"] = i
+			case name == "paid in":
+				columns["paid_in"] = i
+			case strings.HasPrefix(strings.ReplaceAll(name, " ", ""), "withdraw"):
+				columns["withdrawn"] = i
+			}
+		}
+		if _, ok := columns["receipt"]; !ok {
+			columns = map[string]int{}
+		}
+	}
+	for _, column := range []string{"time", "details", "paid_in", "withdrawn"} {
+		if _, ok := columns[column]; !ok {
+			return Statement{}, fmt.Errorf("%w: no %s column", ErrMPesaHeader, column)
+		}
+	}
+
+	var statement Statement
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Statement{}, err
+		}
+		field := func(column string) string {
+			i, ok := columns[column]
+			if ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if field("receipt") == "" || field("time") == "" {
+			continue
+		}
+		if status := field("status"); status != "" && !strings.EqualFold(status, "completed") {
+			continue
+		}
+		amount, withdrawn := field("paid_in"), false
+		if amount == "" || amount == "0.00" {
+			amount, withdrawn = field("withdrawn"), true
+		}
+		line, err := mpesaLine(field("receipt"), field("time"), field("details"), amount, opts)
+		if err != nil {
+			return Statement{}, fmt.Errorf("row %d: %w", row, err)
+		}
+		// withdrawals are always money out, some exports leave the sign off
+		if withdrawn && line.Amount > 0 {
+			line.Amount = -line.Amount
+		}
+		if line.Amount != 0 {
+			statement.Lines = append(statement.Lines, line)
+		}
+	}
+	return statement, nil
+}
+
+// parseMPesaText reads the text extracted from an M-Pesa PDF statement, an entry is a line with its receipt
+// number, completion time, details, status, amount paid in or withdrawn and the balance after it
+func parseMPesaText(r io.Reader, opts Options) (Statement, error) {
+	var (
+		statement Statement
+		entries   []string
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.Join(strings.Fields(scanner.Text()), " ")
+		switch {
+		case mpesaReceipt.MatchString(text):
+			entries = append(entries, text)
+		case text == "" || len(entries) == 0:
+		case strings.HasPrefix(text, "Receipt No") || strings.HasPrefix(text, "Disclaimer") || strings.HasPrefix(text, "Page "):
+			// page headers and footers between entries
+		default:
+			entries[len(entries)-1] += " " + text
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Statement{}, err
+	}
+	for _, entry := range entries {
+		match := mpesaEntry.FindStringSubmatch(entry)
+		if match == nil {
+			return Statement{}, fmt.Errorf("cannot read m-pesa entry %q", entry)
+		}
+		if match[4] != "Completed" {
+			continue
+		}
+		details := strings.TrimSpace(match[3] + " " + match[7])
+		line, err := mpesaLine(match[1], match[2], details, match[5], opts)
+		if err != nil {
+			return Statement{}, err
+		}
+		if line.Amount != 0 {
+			statement.Lines = append(statement.Lines, line)
+		}
+	}
+	return statement, nil
+}
+
+// mpesaLine makes a statement line of an M-Pesa entry. Charges share the receipt number of the payment they
+// were charged for, so they are told apart by a suffix on their external identifier and marked as fees
+func mpesaLine(receipt, completed, details, amount string, opts Options) (Line, error) {
+	date, err := time.ParseInLocation(MPesaTimeLayout, strings.Join(strings.Fields(completed), " "), mpesaLocation)
+	if err != nil {
+		return Line{}, err
+	}
+	line := Line{
+		Date:        date,
+		Description: details,
+		ExternalID:  receipt,
+	}
+	if line.Amount, err = ParseAmount(amount, opts.Currency); err != nil {
+		return Line{}, err
+	}
+	// charge entries read like "Pay Bill Charge" or "Customer Transfer of Funds Charge"
+	if strings.HasSuffix(strings.ToLower(strings.TrimSpace(details)), " charge") {
+		line.Fee = true
+		line.ExternalID += "-charge"
+		// charges are always money out, some exports leave the sign off
+		if line.Amount > 0 {
+			line.Amount = -line.Amount
+		}
+		return line, nil
+	}
+	line.Payee = mpesaCounterparty(details)
+	return line, nil
+}
+
+// mpesaCounterparty returns who an M-Pesa entry was with, details read like
+// "Customer Transfer to 0712***345 - JOHN DOE" or "Pay Bill Online to 888880 - KPLC PREPAID Acc. 1234"
+func mpesaCounterparty(details string) string {
+	i := strings.Index(details, " - ")
+	if i < 0 {
+		return ""
+	}
+	name := details[i+3:]
+	for _, suffix := range []string{" Acc.", " via ", " Original conversation"} {
+		if j := strings.Index(name, suffix); j >= 0 {
+			name = name[:j]
+		}
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"FiberFinanceAPI/utils"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMPesaCSV(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, 6, day, hour, minute, 0, 0, mpesaLocation)
+	}
+	tests := []struct {
+		name    string
+		csv     string
+		want    []Line
+		wantErr error
+	}{
+		{
+			name: "paid in, withdrawn and charges",
+			csv: "\ufeffM-PESA STATEMENT\nCustomer Name:,JANE DOE\n\n" +
+				"Receipt No.,Completion Time,Details,Transaction Status,Paid In,Withdrawn,Balance\n" +
+				"PF11AB2CD3,2021-06-01 08:15:00,Customer Transfer to 0712***345 - JOHN DOE,Completed,,-500.00,1000.00\n" +
+				"PF11AB2CD3,2021-06-01 08:15:00,Customer Transfer of Funds Charge,Completed,,-7.00,993.00\n" +
+				"PF21EF4GH5,2021-06-02 13:40:00,Pay Bill Online to 888880 - KPLC PREPAID Acc. 1234,Completed,0.00,\"1,250.00\",\n" +
+				"PF31IJ6KL7,2021-06-03 18:05:00,Funds received from - MARY WANJIKU 0722***678,Completed,\"2,000.00\",,\n" +
+				"PF41MN8OP9,2021-06-04 09:00:00,Buy Goods Charge,Completed,,7.00,\n" +
+				"PF51QR0ST1,2021-06-05 10:00:00,Customer Transfer to 0712***345 - JOHN DOE,Failed,,-100.00,\n",
+			want: []Line{
+				{Date: at(1, 8, 15), Amount: -50000, Description: "Customer Transfer to 0712***345 - JOHN DOE", Payee: "JOHN DOE", ExternalID: "PF11AB2CD3"},
+				{Date: at(1, 8, 15), Amount: -700, Description: "Customer Transfer of Funds Charge", ExternalID: "PF11AB2CD3-charge", Fee: true},
+				{Date: at(2, 13, 40), Amount: -125000, Description: "Pay Bill Online to 888880 - KPLC PREPAID Acc. 1234", Payee: "KPLC PREPAID", ExternalID: "PF21EF4GH5"},
+				{Date: at(3, 18, 5), Amount: 200000, Description: "Funds received from - MARY WANJIKU 0722***678", Payee: "MARY WANJIKU 0722***678", ExternalID: "PF31IJ6KL7"},
+				{Date: at(4, 9, 0), Amount: -700, Description: "Buy Goods Charge", ExternalID: "PF41MN8OP9-charge", Fee: true},
+			},
+		},
+		{
+			name:    "no header",
+			csv:     "PF11AB2CD3,2021-06-01 08:15:00,Customer Transfer,Completed,,-500.00\n",
+			wantErr: ErrMPesaHeader,
+		},
+		{
+			name:    "header without amount columns",
+			csv:     "Receipt No.,Completion Time,Details,Transaction Status\n",
+			wantErr: ErrMPesaHeader,
+		},
+		{
+			name: "invalid amount",
+			csv: "Receipt No.,Completion Time,Details,Paid In,Withdrawn\n" +
+				"PF11AB2CD3,2021-06-01 08:15:00,Deposit,12.345,\n",
+			wantErr: ErrInvalidAmount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(MPesa, strings.NewReader(tt.csv), Options{Currency: utils.KES})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Lines, tt.want) {
+				t.Errorf("Parse() lines = %+v, want %+v", got.Lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMPesaText(t *testing.T) {
+	text := "M-PESA STATEMENT\n" +
+		"Receipt No Completion Time Details Transaction Status Paid In Withdrawn Balance\n" +
+		"PF11AB2CD3 2021-06-01 08:15:00 Customer Transfer to 0712***345 - Completed -500.00 1,000.00\n" +
+		"JOHN DOE\n" +
+		"Page 1 of 2\n" +
+		"PF11AB2CD3 2021-06-01 08:15:00 Customer Transfer of Funds Charge Completed -7.00 993.00\n" +
+		"PF21EF4GH5 2021-06-02 13:40:00 Funds received from - MARY WANJIKU Completed 2,000.00 2,993.00\n" +
+		"PF51QR0ST1 2021-06-05 10:00:00 Customer Transfer to 0712***345 - JOHN DOE Failed -100.00 2,993.00\n"
+	got, err := Parse(MPesaText, strings.NewReader(text), Options{Currency: utils.KES})
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	want := []Line{
+		{
+			Date:        time.Date(2021, 6, 1, 8, 15, 0, 0, mpesaLocation),
+			Amount:      -50000,
+			Description: "Customer Transfer to 0712***345 - JOHN DOE",
+			Payee:       "JOHN DOE",
+			ExternalID:  "PF11AB2CD3",
+		},
+		{
+			Date:        time.Date(2021, 6, 1, 8, 15, 0, 0, mpesaLocation),
+			Amount:      -700,
+			Description: "Customer Transfer of Funds Charge",
+			ExternalID:  "PF11AB2CD3-charge",
+			Fee:         true,
+		},
+		{
+			Date:        time.Date(2021, 6, 2, 13, 40, 0, 0, mpesaLocation),
+			Amount:      200000,
+			Description: "Funds received from - MARY WANJIKU",
+			Payee:       "MARY WANJIKU",
+			ExternalID:  "PF21EF4GH5",
+		},
+	}
+	if !reflect.DeepEqual(got.Lines, want) {
+		t.Errorf("Parse() lines = %+v, want %+v", got.Lines, want)
+	}
+}
+
+func TestMPesaCounterparty(t *testing.T) {
+	tests := []struct {
+		details string
+		want    string
+	}{
+		{details: "Customer Transfer to 0712***345 - JOHN DOE", want: "JOHN DOE"},
+		{details: "Pay Bill Online to 888880 - KPLC PREPAID Acc. 1234", want: "KPLC PREPAID"},
+		{details: "Merchant Payment to 123456 - SUPERMARKET LTD via API.", want: "SUPERMARKET LTD"},
+		{details: "Merchant Payment Online to 123456 - SHOP. Original conversation ID is ABC", want: "SHOP"},
+		{details: "Airtime Purchase", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.details, func(t *testing.T) {
+			if got := mpesaCounterparty(tt.details); got != tt.want {
+				t.Errorf("mpesaCounterparty(%q) = %q, want %q", tt.details, got, tt.want)
+			}
+		})
+	}
+}
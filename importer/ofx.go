@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"FiberFinanceAPI/utils"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ofxTag matches an OFX element and the text after it. OFX 1.x is SGML and leaves leaf elements unclosed,
+// OFX 2.x is XML and closes them, both read the same this way
+var ofxTag = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+// parseOFX reads the bank or credit card transactions of an OFX or QFX statement and its ledger balance, a
+// statement whose CURDEF is not the account currency is rejected
+func parseOFX(r io.Reader, opts Options) (Statement, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return Statement{}, err
+	}
+	var (
+		statement Statement
+		line      *Line
+		inBalance bool
+		balance   Balance
+	)
+	for _, match := range ofxTag.FindAllStringSubmatch(string(data), -1) {
+		closing, tag, value := match[1] == "/", strings.ToUpper(match[2]), strings.TrimSpace(match[3])
+		switch {
+		case tag == "STMTTRN" && !closing:
+			line = &Line{}
+		case tag == "STMTTRN" && closing:
+			if line == nil {
+				continue
+			}
+			if line.Date.IsZero() {
+				return Statement{}, fmt.Errorf("transaction %q has no DTPOSTED", line.ExternalID)
+			}
+			if line.Amount != 0 {
+				statement.Lines = append(statement.Lines, *line)
+			}
+			line = nil
+		case tag == "LEDGERBAL":
+			inBalance = !closing
+		case tag == "CURDEF" && !closing && value != "":
+			if opts.Currency != "" && utils.CurrencyCode(strings.ToUpper(value)) != opts.Currency {
+				return Statement{}, fmt.Errorf("%w: statement is in %s, the account in %s", ErrCurrencyMismatch, value, opts.Currency)
+			}
+		case closing || value == "":
+		case line != nil:
+			switch tag {
+			case "DTPOSTED":
+				if line.Date, err = parseOFXDate(value); err != nil {
+					return Statement{}, err
+				}
+			case "TRNAMT":
+				if line.Amount, err = parseOFXAmount(value, opts.Currency); err != nil {
+					return Statement{}, err
+				}
+			case "FITID":
+				line.ExternalID = value
+			case "NAME", "PAYEE":
+				line.Payee = value
+			case "MEMO":
+				line.Description = value
+			}
+		case inBalance:
+			switch tag {
+			case "BALAMT":
+				if balance.Amount, err = parseOFXAmount(value, opts.Currency); err != nil {
+					return Statement{}, err
+				}
+				statement.LedgerBalance = &balance
+			case "DTASOF":
+				if balance.Date, err = parseOFXDate(value); err != nil {
+					return Statement{}, err
+				}
+			}
+		}
+	}
+	for i := range statement.Lines {
+		// the name is who was paid, the memo only sometimes says more
+		if statement.Lines[i].Payee != "" {
+			if statement.Lines[i].Description == "" || statement.Lines[i].Description == statement.Lines[i].Payee {
+				statement.Lines[i].Description = statement.Lines[i].Payee
+			} else {
+				statement.Lines[i].Description = statement.Lines[i].Payee + " " + statement.Lines[i].Description
+			}
+		}
+	}
+	return statement, nil
+}
+
+// parseOFXAmount parses an OFX amount, some banks write the decimals after a comma
+func parseOFXAmount(s string, currency utils.CurrencyCode) (int64, error) {
+	if strings.Contains(s, ",") && !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	return ParseAmount(s, currency)
+}
+
+// parseOFXDate parses an OFX date, YYYYMMDD optionally followed by HHMMSS, milliseconds and a time zone
+// such as [-5:EST] or [+3]. Dates without a time zone are in UTC
+func parseOFXDate(s string) (time.Time, error) {
+	location := time.UTC
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		zone := strings.TrimSuffix(s[i+1:], "]")
+		s = s[:i]
+		name := ""
+		if j := strings.IndexByte(zone, ':'); j >= 0 {
+			zone, name = zone[:j], zone[j+1:]
+		}
+		hours, err := strconv.ParseFloat(zone, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid OFX date %q: %w", s, err)
+		}
+		location = time.FixedZone(name, int(hours*3600))
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+	layouts := map[int]string{8: "20060102", 12: "200601021504", 14: "20060102150405"}
+	layout, ok := layouts[len(s)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("invalid OFX date %q", s)
+	}
+	return time.ParseInLocation(layout, s, location)
+}
@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"FiberFinanceAPI/utils"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sgmlStatement is an OFX 1.x statement, its leaf elements are never closed
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>usd
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20210105120000.000[-5:EST]
+<TRNAMT>-12.34
+<FITID>1001
+<NAME>COFFEE SHOP
+<MEMO>Card purchase
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20210106
+<TRNAMT>1500,00
+<FITID>1002
+<NAME>EMPLOYER
+<MEMO>EMPLOYER
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>OTHER
+<DTPOSTED>20210107
+<TRNAMT>0.00
+<FITID>1003
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>2487.66
+<DTASOF>20210107
+</LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+// xmlStatement is an OFX 2.x statement, every element is closed
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CURDEF>USD</CURDEF>
+<BANKTRANLIST>
+<STMTTRN><DTPOSTED>20210201</DTPOSTED><TRNAMT>-5.00</TRNAMT><FITID>A1</FITID><MEMO>Fee</MEMO></STMTTRN>
+</BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>
+`
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		name    string
+		ofx     string
+		opts    Options
+		want    Statement
+		wantErr error
+	}{
+		{
+			name: "sgml",
+			ofx:  sgmlStatement,
+			opts: Options{Currency: utils.USD},
+			want: Statement{
+				Lines: []Line{
+					{
+						Date:        time.Date(2021, 1, 5, 12, 0, 0, 0, time.FixedZone("EST", -5*3600)),
+						Amount:      -1234,
+						Description: "COFFEE SHOP Card purchase",
+						Payee:       "COFFEE SHOP",
+						ExternalID:  "1001",
+					},
+					{
+						Date:        time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+						Amount:      150000,
+						Description: "EMPLOYER",
+						Payee:       "EMPLOYER",
+						ExternalID:  "1002",
+					},
+				},
+				LedgerBalance: &Balance{Amount: 248766, Date: time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "xml",
+			ofx:  xmlStatement,
+			opts: Options{Currency: utils.USD},
+			want: Statement{
+				Lines: []Line{{Date: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), Amount: -500, Description: "Fee", ExternalID: "A1"}},
+			},
+		},
+		{
+			name:    "statement in another currency",
+			ofx:     sgmlStatement,
+			opts:    Options{Currency: utils.KES},
+			wantErr: ErrCurrencyMismatch,
+		},
+		{
+			name:    "amounts are read in the account currency",
+			ofx:     strings.Replace(xmlStatement, "<CURDEF>USD</CURDEF>", "", 1),
+			opts:    Options{Currency: utils.UGX},
+			wantErr: ErrInvalidAmount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(OFX, strings.NewReader(tt.ofx), tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOFXTransactionWithoutDate(t *testing.T) {
+	ofx := "<OFX><STMTTRN><TRNAMT>-1.00<FITID>X</STMTTRN></OFX>"
+	if _, err := Parse(QFX, strings.NewReader(ofx), Options{Currency: utils.USD}); err == nil {
+		t.Fatal("Parse() of a transaction without DTPOSTED returned no error")
+	}
+}
+
+func TestParseOFXDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "20210105", want: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{value: "202101051230", want: time.Date(2021, 1, 5, 12, 30, 0, 0, time.UTC)},
+		{value: "20210105123045", want: time.Date(2021, 1, 5, 12, 30, 45, 0, time.UTC)},
+		{value: "20210105123045.123", want: time.Date(2021, 1, 5, 12, 30, 45, 0, time.UTC)},
+		{value: "20210105123045[-5:EST]", want: time.Date(2021, 1, 5, 17, 30, 45, 0, time.UTC)},
+		{value: "20210105123045.000[+3]", want: time.Date(2021, 1, 5, 9, 30, 45, 0, time.UTC)},
+		{value: "20210105000000[5.5:IST]", want: time.Date(2021, 1, 4, 18, 30, 0, 0, time.UTC)},
+		{value: "2021010", wantErr: true},
+		{value: "20211305", wantErr: true},
+		{value: "20210105[EST]", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseOFXDate(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseOFXDate(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOFXDate(%q) unexpected error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseOFXDate(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOFXAmount(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency utils.CurrencyCode
+		want     int64
+		wantErr  bool
+	}{
+		{value: "-12.34", currency: utils.USD, want: -1234},
+		{value: "12,34", currency: utils.USD, want: 1234},
+		{value: "1,234.50", currency: utils.USD, want: 123450},
+		{value: "1500", currency: utils.UGX, want: 1500},
+		{value: "15,5", currency: utils.UGX, wantErr: true},
+		{value: "abc", currency: utils.USD, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseOFXAmount(tt.value, tt.currency)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAmount) {
+					t.Fatalf("parseOFXAmount(%q) error = %v, want %v", tt.value, err, ErrInvalidAmount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOFXAmount(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseOFXAmount(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseQIF reads the transactions of a QIF bank, cash or credit card account, only records in a !Type: section
+// of an account are transactions, the records of other sections such as categories, classes and memorized
+// transactions are skipped. QIF dates are month first unless opts.DayFirst is set, and two digit years are taken to be
+// in this century
+func parseQIF(r io.Reader, opts Options) (Statement, error) {
+	var (
+		statement Statement
+		line      Line
+		memo      string
+		number    int
+		// inType is set inside a !Type: section of an account, records of !Account blocks describe an account and
+		// are skipped like those of the other sections
+		inType bool
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		code, value := text[0], strings.TrimSpace(text[1:])
+		if code != '!' && !inType {
+			continue
+		}
+		var err error
+		switch code {
+		case '!':
+			header := strings.ToLower(value)
+			switch {
+			case strings.HasPrefix(header, "type:"):
+				switch strings.TrimPrefix(header, "type:") {
+				case "bank", "cash", "ccard", "oth a", "oth l":
+					inType = true
+				default:
+					inType = false
+				}
+			case header == "account":
+				inType = false
+			}
+			line, memo = Line{}, ""
+		case 'D':
+			if line.Date, err = parseQIFDate(value, opts.DayFirst); err != nil {
+				return Statement{}, fmt.Errorf("line %d: %w", number, err)
+			}
+		case 'T', 'U':
+			if line.Amount, err = ParseAmount(value, opts.Currency); err != nil {
+				return Statement{}, fmt.Errorf("line %d: %w", number, err)
+			}
+		case 'P':
+			line.Payee = value
+		case 'M':
+			memo = value
+		case '^':
+			if line.Date.IsZero() {
+				return Statement{}, fmt.Errorf("line %d: transaction has no date", number)
+			}
+			line.Description = line.Payee
+			if line.Description == "" {
+				line.Description = memo
+			}
+			if line.Amount != 0 {
+				statement.Lines = append(statement.Lines, line)
+			}
+			line, memo = Line{}, ""
+		}
+	}
+	return statement, scanner.Err()
+}
+
+// parseQIFDate parses QIF dates such as 12/31/2021, 12/31'21, 12-31-21 or 1/ 5'21
+func parseQIFDate(s string, dayFirst bool) (time.Time, error) {
+	fields := strings.FieldsFunc(strings.ReplaceAll(s, " ", ""), func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '\''
+	})
+	if len(fields) != 3 {
+		return time.Time{}, fmt.Errorf("invalid QIF date %q", s)
+	}
+	var parts [3]int
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid QIF date %q", s)
+		}
+		parts[i] = n
+	}
+	month, day, year := parts[0], parts[1], parts[2]
+	if dayFirst {
+		month, day = day, month
+	}
+	if year < 100 {
+		year += 2000
+	}
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, fmt.Errorf("invalid QIF date %q", s)
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
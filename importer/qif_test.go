@@ -0,0 +1,134 @@
+package importer
+
+import (
+	"FiberFinanceAPI/utils"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQIF(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name    string
+		qif     string
+		opts    Options
+		want    []Line
+		wantErr bool
+	}{
+		{
+			name: "bank transactions",
+			qif: "\ufeff!Type:Bank\r\nD12/31/2021\r\nT-1,234.56\r\nPGrocer\r\nMWeekly shop\r\n^\r\n" +
+				"D1/ 5'22\r\nU250.00\r\nMSalary\r\n^\r\n",
+			opts: Options{Currency: utils.USD},
+			want: []Line{
+				{Date: date(2021, 12, 31), Amount: -123456, Payee: "Grocer", Description: "Grocer"},
+				{Date: date(2022, 1, 5), Amount: 25000, Description: "Salary"},
+			},
+		},
+		{
+			name: "day first dates",
+			qif:  "!Type:CCard\nD31/12/21\nT-10\nPShop\n^\n",
+			opts: Options{Currency: utils.USD, DayFirst: true},
+			want: []Line{{Date: date(2021, 12, 31), Amount: -1000, Payee: "Shop", Description: "Shop"}},
+		},
+		{
+			name: "zero amounts are left out",
+			qif:  "!Type:Cash\nD01/02/2021\nT0.00\nPNothing\n^\nD01/03/2021\nT5\nPSomething\n^\n",
+			opts: Options{Currency: utils.USD},
+			want: []Line{{Date: date(2021, 1, 3), Amount: 500, Payee: "Something", Description: "Something"}},
+		},
+		{
+			name: "account blocks are skipped",
+			qif: "!Option:AutoSwitch\n!Account\nNChecking\nTBank\nDMain account\n^\n!Clear:AutoSwitch\n" +
+				"!Type:Bank\nD03/01/2021\nT-20\nPCafe\n^\n",
+			opts: Options{Currency: utils.USD},
+			want: []Line{{Date: date(2021, 3, 1), Amount: -2000, Payee: "Cafe", Description: "Cafe"}},
+		},
+		{
+			name: "category and memorized sections are skipped",
+			qif: "!Type:Cat\nNGroceries\nDFood bought\nE\n^\n!Type:Memorized\nKE\nT-50.00\nPRent\n^\n" +
+				"!Type:Class\nNWork\n^\n!Type:Oth L\nD04/01/2021\nT-75\nPLoan\n^\n",
+			opts: Options{Currency: utils.USD},
+			want: []Line{{Date: date(2021, 4, 1), Amount: -7500, Payee: "Loan", Description: "Loan"}},
+		},
+		{
+			name:    "amounts are read in the account currency",
+			qif:     "!Type:Bank\nD04/01/2021\nT-75.50\nPLoan\n^\n",
+			opts:    Options{Currency: utils.UGX},
+			wantErr: true,
+		},
+		{
+			name:    "transaction without a date",
+			qif:     "!Type:Bank\nT-20\nPCafe\n^\n",
+			opts:    Options{Currency: utils.USD},
+			wantErr: true,
+		},
+		{
+			name:    "invalid date",
+			qif:     "!Type:Bank\nD13/01/2021\nT-20\n^\n",
+			opts:    Options{Currency: utils.USD},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(QIF, strings.NewReader(tt.qif), tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Lines, tt.want) {
+				t.Errorf("Parse() lines = %+v, want %+v", got.Lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQIFDate(t *testing.T) {
+	tests := []struct {
+		value    string
+		dayFirst bool
+		want     time.Time
+		wantErr  bool
+	}{
+		{value: "12/31/2021", want: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "12/31'21", want: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "12-31-21", want: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "1/ 5'21", want: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{value: "1.5.2021", want: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{value: "1/5/2021", dayFirst: true, want: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "31/12/2021", dayFirst: true, want: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "31/12/2021", wantErr: true},
+		{value: "12/32/2021", wantErr: true},
+		{value: "0/1/2021", wantErr: true},
+		{value: "12/31", wantErr: true},
+		{value: "Dec 31 2021", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseQIFDate(tt.value, tt.dayFirst)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseQIFDate(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseQIFDate(%q) unexpected error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseQIFDate(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
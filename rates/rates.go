@@ -0,0 +1,178 @@
+// Package rates reads the daily exchange rate files central banks publish, the ECB reference rates in
+// particular, into exchange rates that can be stored
+package rates
+
+import (
+	model "FiberFinanceAPI/database/models"
+	"FiberFinanceAPI/utils"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the file format of a rates file
+type Format string
+
+const (
+	// XML is the eurofxref XML the ECB publishes, days of Cube elements holding a rate per currency
+	XML Format = "xml"
+	// CSV is either the ECB layout, a Date column followed by a column per currency, or one rate per row
+	// with date, base, quote and rate columns
+	CSV Format = "csv"
+)
+
+// ECBBase is the currency the ECB quotes its reference rates against
+const ECBBase = utils.EUR
+
+var (
+	// ErrUnsupportedFormat is returned when a rates file is in a format we cannot parse
+	ErrUnsupportedFormat = errors.New("unsupported rates format")
+	// ErrInvalidRates is returned when a rates file cannot be parsed
+	ErrInvalidRates = errors.New("invalid rates file")
+)
+
+// dateLayouts are the layouts the dates of a rates file may come in, the daily ECB CSV spells out the month
+var dateLayouts = []string{"2006-01-02", "02 January 2006", "2 January 2006"}
+
+// Parse parses a rates file in format. The rates of files that do not name their base are quoted against base
+func Parse(format Format, r io.Reader, base utils.CurrencyCode) ([]model.ExchangeRate, error) {
+	switch Format(strings.ToLower(string(format))) {
+	case XML:
+		return parseXML(r, base)
+	case CSV:
+		return parseCSV(r, base)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+func parseXML(r io.Reader, base utils.CurrencyCode) ([]model.ExchangeRate, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRates, err)
+	}
+	var rates []model.ExchangeRate
+	for _, day := range envelope.Days {
+		date, err := parseDate(day.Time)
+		if err != nil {
+			return nil, err
+		}
+		for _, rate := range day.Rates {
+			exchangeRate, ok, err := newRate(base, rate.Currency, date, rate.Rate)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rates = append(rates, exchangeRate)
+			}
+		}
+	}
+	return rates, nil
+}
+
+func parseCSV(r io.Reader, base utils.CurrencyCode) ([]model.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRates, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidRates)
+	}
+	header := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	date, ok := header["date"]
+	if !ok {
+		return nil, fmt.Errorf("%w: no date column", ErrInvalidRates)
+	}
+	baseColumn, hasBase := header["base"]
+	quoteColumn, hasQuote := header["quote"]
+	rateColumn, hasRate := header["rate"]
+	long := hasBase && hasQuote && hasRate
+
+	var rates []model.ExchangeRate
+	for _, record := range records[1:] {
+		if len(record) <= date || strings.TrimSpace(record[date]) == "" {
+			continue
+		}
+		day, err := parseDate(record[date])
+		if err != nil {
+			return nil, err
+		}
+		if long {
+			if len(record) <= baseColumn || len(record) <= quoteColumn || len(record) <= rateColumn {
+				return nil, fmt.Errorf("%w: row for %s is short", ErrInvalidRates, record[date])
+			}
+			rowBase := utils.CurrencyCode(strings.ToUpper(strings.TrimSpace(record[baseColumn])))
+			exchangeRate, ok, err := newRate(rowBase, record[quoteColumn], day, record[rateColumn])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rates = append(rates, exchangeRate)
+			}
+			continue
+		}
+		for i, value := range record {
+			if i == date || i >= len(records[0]) {
+				continue
+			}
+			exchangeRate, ok, err := newRate(base, records[0][i], day, value)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rates = append(rates, exchangeRate)
+			}
+		}
+	}
+	return rates, nil
+}
+
+// newRate builds the rate of a quote currency, ok is false for the blank and N/A cells of days a currency
+// was not quoted
+func newRate(base utils.CurrencyCode, quote string, date time.Time, value string) (model.ExchangeRate, bool, error) {
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	value = strings.TrimSpace(value)
+	if quote == "" || quote == string(base) || value == "" || strings.EqualFold(value, "N/A") {
+		return model.ExchangeRate{}, false, nil
+	}
+	if base == "" {
+		return model.ExchangeRate{}, false, fmt.Errorf("%w: rate of %s on %s has no base", ErrInvalidRates, quote,
+			date.Format(dateLayouts[0]))
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate <= 0 {
+		return model.ExchangeRate{}, false, fmt.Errorf("%w: rate %q of %s on %s", ErrInvalidRates, value, quote,
+			date.Format(dateLayouts[0]))
+	}
+	return model.ExchangeRate{Base: base, Quote: utils.CurrencyCode(quote), Date: date, Rate: rate}, true, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: date %q", ErrInvalidRates, value)
+}
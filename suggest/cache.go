@@ -0,0 +1,60 @@
+package suggest
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache keeps the classifier trained for each user for a while, so suggestions do not retrain on every request.
+// Categories filed after a classifier was trained are learnt once it expires
+type Cache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// sweptAt is when expired entries were last dropped, classifiers of users who stopped asking are not kept
+	sweptAt time.Time
+}
+
+type cacheEntry struct {
+	classifier *Classifier
+	trainedAt  time.Time
+}
+
+// NewCache returns a Cache whose classifiers expire ttl after they were trained
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, entries: map[string]cacheEntry{}}
+}
+
+// Get returns the classifier of key unless it has expired by now
+func (c *Cache) Get(key string, now time.Time) (*Classifier, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.Sub(entry.trainedAt) >= c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.classifier, true
+}
+
+// Put keeps the classifier of key, trained at now. Entries that expired are dropped at most once every ttl
+func (c *Cache) Put(key string, classifier *Classifier, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.sweptAt) >= c.ttl {
+		for k, entry := range c.entries {
+			if now.Sub(entry.trainedAt) >= c.ttl {
+				delete(c.entries, k)
+			}
+		}
+		c.sweptAt = now
+	}
+	c.entries[key] = cacheEntry{classifier: classifier, trainedAt: now}
+}
+
+// Forget drops the classifier of key, it is trained again when it is next needed
+func (c *Cache) Forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
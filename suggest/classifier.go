@@ -0,0 +1,138 @@
+// Package suggest learns the categories a user files transactions under from their history and suggests
+// categories for new transaction descriptions with a multinomial naive Bayes classifier over their words
+package suggest
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// merchantPrefix marks the token a whole merchant name is learnt as, a merchant is a stronger hint than any
+// one word of its name
+const merchantPrefix = "@"
+
+// Example is a description and merchant a user filed under Label
+type Example struct {
+	Text     string
+	Merchant string
+	Label    string
+}
+
+// Suggestion is a label and the probability the classifier gives it
+type Suggestion struct {
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// Classifier is a multinomial naive Bayes classifier with add-one smoothing
+type Classifier struct {
+	documents  int
+	labels     map[string]*labelCounts
+	vocabulary map[string]bool
+}
+
+type labelCounts struct {
+	documents int
+	tokens    int
+	counts    map[string]int
+}
+
+// Train learns the labels of the examples, examples without a label or without any token are skipped
+func Train(examples []Example) *Classifier {
+	c := &Classifier{
+		labels:     map[string]*labelCounts{},
+		vocabulary: map[string]bool{},
+	}
+	for _, example := range examples {
+		tokens := Tokens(example.Text, example.Merchant)
+		if example.Label == "" || len(tokens) == 0 {
+			continue
+		}
+		label, ok := c.labels[example.Label]
+		if !ok {
+			label = &labelCounts{counts: map[string]int{}}
+			c.labels[example.Label] = label
+		}
+		c.documents++
+		label.documents++
+		for _, token := range tokens {
+			label.tokens++
+			label.counts[token]++
+			c.vocabulary[token] = true
+		}
+	}
+	return c
+}
+
+// Suggest returns up to n labels for a description and merchant, most probable first. Nothing is suggested
+// when none of their tokens were seen in training, the priors alone are no basis for a suggestion
+func (c *Classifier) Suggest(text, merchant string, n int) []Suggestion {
+	var known []string
+	for _, token := range Tokens(text, merchant) {
+		if c.vocabulary[token] {
+			known = append(known, token)
+		}
+	}
+	if len(known) == 0 || n <= 0 {
+		return nil
+	}
+	vocabulary := float64(len(c.vocabulary))
+	suggestions := make([]Suggestion, 0, len(c.labels))
+	max := math.Inf(-1)
+	for name, label := range c.labels {
+		score := math.Log(float64(label.documents) / float64(c.documents))
+		for _, token := range known {
+			score += math.Log((float64(label.counts[token]) + 1) / (float64(label.tokens) + vocabulary))
+		}
+		if score > max {
+			max = score
+		}
+		suggestions = append(suggestions, Suggestion{Label: name, Score: score})
+	}
+	// the log scores are turned into probabilities relative to the best one so they do not underflow
+	var total float64
+	for i := range suggestions {
+		suggestions[i].Score = math.Exp(suggestions[i].Score - max)
+		total += suggestions[i].Score
+	}
+	for i := range suggestions {
+		suggestions[i].Score /= total
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Label < suggestions[j].Label
+	})
+	if len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
+
+// Tokens returns the lower cased words of a description and merchant, along with the whole merchant name.
+// Single letters and words holding digits, such as references and M-Pesa codes, say nothing of the category
+// and are dropped
+func Tokens(text, merchant string) []string {
+	tokens := words(text)
+	tokens = append(tokens, words(merchant)...)
+	if name := strings.Join(words(merchant), " "); name != "" {
+		tokens = append(tokens, merchantPrefix+name)
+	}
+	return tokens
+}
+
+func words(text string) []string {
+	var tokens []string
+	for _, field := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(field)) < 2 || strings.IndexFunc(field, unicode.IsDigit) >= 0 {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
@@ -15,6 +15,7 @@ type Config struct {
 	TokenDuration            time.Duration `mapstructure:"TOKEN_DURATION"`
 	RefreshTokenDuration     time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
 	RefreshTokenSymmetricKey string        `mapstructure:"REFRESH_TOKEN_SYMMETRIC_KEY"`
+	RecurringInterval        time.Duration `mapstructure:"RECURRING_INTERVAL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -1,5 +1,8 @@
 package utils
 
+import "sort"
+
+// CurrencyCode is the ISO 4217 alphabetic code of a currency
 type CurrencyCode string
 
 const (
@@ -10,10 +13,215 @@ const (
 	TZS CurrencyCode = "TZS"
 )
 
+// DefaultMinorUnits is the number of decimal places assumed for a currency that is not in the registry
+const DefaultMinorUnits = 2
+
+// Currency is an ISO 4217 currency, amounts in it are kept in minor units, MinorUnits decimal places below
+// the major unit, e.g. cents for USD with 2 and whole shillings for UGX with 0
+type Currency struct {
+	Code       CurrencyCode `json:"code"`
+	Numeric    string       `json:"numeric"`
+	MinorUnits int          `json:"minor_units"`
+	Symbol     string       `json:"symbol"`
+}
+
+// currencies is the ISO 4217 list of active currencies and funds that have minor units, precious metals and
+// testing codes are left out as amounts in them cannot be kept in minor units
+var currencies = map[CurrencyCode]Currency{
+	"AED": {"AED", "784", 2, "د.إ"},
+	"AFN": {"AFN", "971", 2, "؋"},
+	"ALL": {"ALL", "008", 2, "L"},
+	"AMD": {"AMD", "051", 2, "֏"},
+	"ANG": {"ANG", "532", 2, "ƒ"},
+	"AOA": {"AOA", "973", 2, "Kz"},
+	"ARS": {"ARS", "032", 2, "$"},
+	"AUD": {"AUD", "036", 2, "A$"},
+	"AWG": {"AWG", "533", 2, "ƒ"},
+	"AZN": {"AZN", "944", 2, "₼"},
+	"BAM": {"BAM", "977", 2, "KM"},
+	"BBD": {"BBD", "052", 2, "Bds$"},
+	"BDT": {"BDT", "050", 2, "৳"},
+	"BGN": {"BGN", "975", 2, "лв"},
+	"BHD": {"BHD", "048", 3, "BD"},
+	"BIF": {"BIF", "108", 0, "FBu"},
+	"BMD": {"BMD", "060", 2, "$"},
+	"BND": {"BND", "096", 2, "B$"},
+	"BOB": {"BOB", "068", 2, "Bs."},
+	"BOV": {"BOV", "984", 2, "BOV"},
+	"BRL": {"BRL", "986", 2, "R$"},
+	"BSD": {"BSD", "044", 2, "$"},
+	"BTN": {"BTN", "064", 2, "Nu."},
+	"BWP": {"BWP", "072", 2, "P"},
+	"BYN": {"BYN", "933", 2, "Br"},
+	"BZD": {"BZD", "084", 2, "BZ$"},
+	"CAD": {"CAD", "124", 2, "CA$"},
+	"CDF": {"CDF", "976", 2, "FC"},
+	"CHE": {"CHE", "947", 2, "CHE"},
+	"CHF": {"CHF", "756", 2, "CHF"},
+	"CHW": {"CHW", "948", 2, "CHW"},
+	"CLF": {"CLF", "990", 4, "UF"},
+	"CLP": {"CLP", "152", 0, "$"},
+	"CNY": {"CNY", "156", 2, "¥"},
+	"COP": {"COP", "170", 2, "$"},
+	"COU": {"COU", "970", 2, "COU"},
+	"CRC": {"CRC", "188", 2, "₡"},
+	"CUP": {"CUP", "192", 2, "$"},
+	"CVE": {"CVE", "132", 2, "Esc"},
+	"CZK": {"CZK", "203", 2, "Kč"},
+	"DJF": {"DJF", "262", 0, "Fdj"},
+	"DKK": {"DKK", "208", 2, "kr"},
+	"DOP": {"DOP", "214", 2, "RD$"},
+	"DZD": {"DZD", "012", 2, "DA"},
+	"EGP": {"EGP", "818", 2, "E£"},
+	"ERN": {"ERN", "232", 2, "Nfk"},
+	"ETB": {"ETB", "230", 2, "Br"},
+	"EUR": {"EUR", "978", 2, "€"},
+	"FJD": {"FJD", "242", 2, "FJ$"},
+	"FKP": {"FKP", "238", 2, "£"},
+	"GBP": {"GBP", "826", 2, "£"},
+	"GEL": {"GEL", "981", 2, "₾"},
+	"GHS": {"GHS", "936", 2, "GH₵"},
+	"GIP": {"GIP", "292", 2, "£"},
+	"GMD": {"GMD", "270", 2, "D"},
+	"GNF": {"GNF", "324", 0, "FG"},
+	"GTQ": {"GTQ", "320", 2, "Q"},
+	"GYD": {"GYD", "328", 2, "G$"},
+	"HKD": {"HKD", "344", 2, "HK$"},
+	"HNL": {"HNL", "340", 2, "L"},
+	"HTG": {"HTG", "332", 2, "G"},
+	"HUF": {"HUF", "348", 2, "Ft"},
+	"IDR": {"IDR", "360", 2, "Rp"},
+	"ILS": {"ILS", "376", 2, "₪"},
+	"INR": {"INR", "356", 2, "₹"},
+	"IQD": {"IQD", "368", 3, "ID"},
+	"IRR": {"IRR", "364", 2, "﷼"},
+	"ISK": {"ISK", "352", 0, "kr"},
+	"JMD": {"JMD", "388", 2, "J$"},
+	"JOD": {"JOD", "400", 3, "JD"},
+	"JPY": {"JPY", "392", 0, "¥"},
+	"KES": {"KES", "404", 2, "KSh"},
+	"KGS": {"KGS", "417", 2, "som"},
+	"KHR": {"KHR", "116", 2, "៛"},
+	"KMF": {"KMF", "174", 0, "CF"},
+	"KPW": {"KPW", "408", 2, "₩"},
+	"KRW": {"KRW", "410", 0, "₩"},
+	"KWD": {"KWD", "414", 3, "KD"},
+	"KYD": {"KYD", "136", 2, "CI$"},
+	"KZT": {"KZT", "398", 2, "₸"},
+	"LAK": {"LAK", "418", 2, "₭"},
+	"LBP": {"LBP", "422", 2, "L£"},
+	"LKR": {"LKR", "144", 2, "Rs"},
+	"LRD": {"LRD", "430", 2, "L$"},
+	"LSL": {"LSL", "426", 2, "L"},
+	"LYD": {"LYD", "434", 3, "LD"},
+	"MAD": {"MAD", "504", 2, "DH"},
+	"MDL": {"MDL", "498", 2, "L"},
+	"MGA": {"MGA", "969", 2, "Ar"},
+	"MKD": {"MKD", "807", 2, "den"},
+	"MMK": {"MMK", "104", 2, "K"},
+	"MNT": {"MNT", "496", 2, "₮"},
+	"MOP": {"MOP", "446", 2, "MOP$"},
+	"MRU": {"MRU", "929", 2, "UM"},
+	"MUR": {"MUR", "480", 2, "₨"},
+	"MVR": {"MVR", "462", 2, "Rf"},
+	"MWK": {"MWK", "454", 2, "MK"},
+	"MXN": {"MXN", "484", 2, "MX$"},
+	"MXV": {"MXV", "979", 2, "MXV"},
+	"MYR": {"MYR", "458", 2, "RM"},
+	"MZN": {"MZN", "943", 2, "MT"},
+	"NAD": {"NAD", "516", 2, "N$"},
+	"NGN": {"NGN", "566", 2, "₦"},
+	"NIO": {"NIO", "558", 2, "C$"},
+	"NOK": {"NOK", "578", 2, "kr"},
+	"NPR": {"NPR", "524", 2, "Rs"},
+	"NZD": {"NZD", "554", 2, "NZ$"},
+	"OMR": {"OMR", "512", 3, "RO"},
+	"PAB": {"PAB", "590", 2, "B/."},
+	"PEN": {"PEN", "604", 2, "S/"},
+	"PGK": {"PGK", "598", 2, "K"},
+	"PHP": {"PHP", "608", 2, "₱"},
+	"PKR": {"PKR", "586", 2, "Rs"},
+	"PLN": {"PLN", "985", 2, "zł"},
+	"PYG": {"PYG", "600", 0, "₲"},
+	"QAR": {"QAR", "634", 2, "QR"},
+	"RON": {"RON", "946", 2, "lei"},
+	"RSD": {"RSD", "941", 2, "din"},
+	"RUB": {"RUB", "643", 2, "₽"},
+	"RWF": {"RWF", "646", 0, "FRw"},
+	"SAR": {"SAR", "682", 2, "SR"},
+	"SBD": {"SBD", "090", 2, "SI$"},
+	"SCR": {"SCR", "690", 2, "SR"},
+	"SDG": {"SDG", "938", 2, "SDG"},
+	"SEK": {"SEK", "752", 2, "kr"},
+	"SGD": {"SGD", "702", 2, "S$"},
+	"SHP": {"SHP", "654", 2, "£"},
+	"SLE": {"SLE", "925", 2, "Le"},
+	"SOS": {"SOS", "706", 2, "Sh"},
+	"SRD": {"SRD", "968", 2, "$"},
+	"SSP": {"SSP", "728", 2, "SS£"},
+	"STN": {"STN", "930", 2, "Db"},
+	"SVC": {"SVC", "222", 2, "₡"},
+	"SYP": {"SYP", "760", 2, "£S"},
+	"SZL": {"SZL", "748", 2, "E"},
+	"THB": {"THB", "764", 2, "฿"},
+	"TJS": {"TJS", "972", 2, "SM"},
+	"TMT": {"TMT", "934", 2, "m"},
+	"TND": {"TND", "788", 3, "DT"},
+	"TOP": {"TOP", "776", 2, "T$"},
+	"TRY": {"TRY", "949", 2, "₺"},
+	"TTD": {"TTD", "780", 2, "TT$"},
+	"TWD": {"TWD", "901", 2, "NT$"},
+	"TZS": {"TZS", "834", 2, "TSh"},
+	"UAH": {"UAH", "980", 2, "₴"},
+	"UGX": {"UGX", "800", 0, "USh"},
+	"USD": {"USD", "840", 2, "$"},
+	"USN": {"USN", "997", 2, "USN"},
+	"UYI": {"UYI", "940", 0, "UYI"},
+	"UYU": {"UYU", "858", 2, "$U"},
+	"UYW": {"UYW", "927", 4, "UYW"},
+	"UZS": {"UZS", "860", 2, "soʻm"},
+	"VED": {"VED", "926", 2, "Bs.D"},
+	"VES": {"VES", "928", 2, "Bs.S"},
+	"VND": {"VND", "704", 0, "₫"},
+	"VUV": {"VUV", "548", 0, "VT"},
+	"WST": {"WST", "882", 2, "WS$"},
+	"XAF": {"XAF", "950", 0, "FCFA"},
+	"XCD": {"XCD", "951", 2, "EC$"},
+	"XCG": {"XCG", "532", 2, "Cg"},
+	"XOF": {"XOF", "952", 0, "CFA"},
+	"XPF": {"XPF", "953", 0, "₣"},
+	"YER": {"YER", "886", 2, "﷼"},
+	"ZAR": {"ZAR", "710", 2, "R"},
+	"ZMW": {"ZMW", "967", 2, "ZK"},
+	"ZWG": {"ZWG", "924", 2, "ZiG"},
+}
+
+// LookupCurrency returns the ISO 4217 currency of a code
+func LookupCurrency(code CurrencyCode) (Currency, bool) {
+	currency, ok := currencies[code]
+	return currency, ok
+}
+
+// IsSupportedCurrency reports whether the code is an ISO 4217 currency amounts can be kept in
 func IsSupportedCurrency(currency CurrencyCode) bool {
-	switch currency {
-	case KES, USD, TZS, UGX, EUR:
-		return true
+	_, ok := currencies[currency]
+	return ok
+}
+
+// MinorUnits returns the number of decimal places of a currency, DefaultMinorUnits when it is not known
+func MinorUnits(code CurrencyCode) int {
+	if currency, ok := currencies[code]; ok {
+		return currency.MinorUnits
+	}
+	return DefaultMinorUnits
+}
+
+// Currencies returns every currency in the registry ordered by code
+func Currencies() []Currency {
+	list := make([]Currency, 0, len(currencies))
+	for _, currency := range currencies {
+		list = append(list, currency)
 	}
-	return false
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
 }
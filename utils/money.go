@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidMoney is returned when an amount cannot be read in the minor units of its currency
+var ErrInvalidMoney = errors.New("invalid amount for currency")
+
+// Money is an amount in the minor units of its currency
+type Money struct {
+	Amount   int64
+	Currency CurrencyCode
+}
+
+// NewMoney returns an amount in minor units of a currency as Money
+func NewMoney(amount int64, currency CurrencyCode) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney reads a decimal amount such as -1234.5 into the minor units of the currency, it fails when the
+// amount has more decimal places than the currency has minor units
+func ParseMoney(value string, currency CurrencyCode) (Money, error) {
+	units := MinorUnits(currency)
+	s := strings.TrimSpace(value)
+	negative := strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if whole == "" && fraction == "" || len(fraction) > units {
+		return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidMoney, value, units)
+	}
+	amount, err := strconv.ParseInt("0"+whole+fraction+strings.Repeat("0", units-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Value returns the amount in the major unit with the decimal places of the currency, e.g. -12.34 for
+// -1234 USD and 1500 for 1500 UGX
+func (m Money) Value() string {
+	units := MinorUnits(m.Currency)
+	sign := ""
+	value := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign = "-"
+		value = uint64(-m.Amount)
+	}
+	digits := strconv.FormatUint(value, 10)
+	if units <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+// String formats the amount for display with the currency symbol and thousand separators, e.g. -$1,234.56
+// and USh 1,500
+func (m Money) String() string {
+	value := m.Value()
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign, value = "-", value[1:]
+	}
+	whole, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		whole, fraction = value[:i], value[i:]
+	}
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	symbol := string(m.Currency)
+	if currency, ok := LookupCurrency(m.Currency); ok {
+		symbol = currency.Symbol
+	}
+	if symbol == "" {
+		return sign + grouped.String() + fraction
+	}
+	// symbols ending in a letter read better apart from the number, KSh 100.00 rather than KSh100.00
+	if last := []rune(symbol); unicode.IsLetter(last[len(last)-1]) {
+		symbol += " "
+	}
+	return sign + symbol + grouped.String() + fraction
+}
+
+type moneyJSON struct {
+	Amount    *int64       `json:"amount"`
+	Currency  CurrencyCode `json:"currency"`
+	Value     string       `json:"value,omitempty"`
+	Formatted string       `json:"formatted,omitempty"`
+}
+
+// MarshalJSON writes the amount in minor units along with its value and display formatting in the currency
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:    &m.Amount,
+		Currency:  m.Currency,
+		Value:     m.Value(),
+		Formatted: m.String(),
+	})
+}
+
+// UnmarshalJSON reads Money written by MarshalJSON, an object with a decimal value and its currency instead of an
+// amount in minor units, or a bare amount in minor units as amounts were written before Money
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return json.Unmarshal(data, &m.Amount)
+	}
+	var aux moneyJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.Currency = aux.Currency
+	switch {
+	case aux.Amount != nil:
+		m.Amount = *aux.Amount
+	case aux.Value != "":
+		// the minor units of the value depend on its currency, so it cannot be read without one
+		if aux.Currency == "" {
+			return fmt.Errorf("%w: %q has no currency", ErrInvalidMoney, aux.Value)
+		}
+		parsed, err := ParseMoney(aux.Value, aux.Currency)
+		if err != nil {
+			return err
+		}
+		m.Amount = parsed.Amount
+	default:
+		m.Amount = 0
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency CurrencyCode
+		want     int64
+		wantErr  bool
+	}{
+		{name: "cents", value: "12.34", currency: USD, want: 1234},
+		{name: "fewer decimals than minor units", value: "-12.5", currency: USD, want: -1250},
+		{name: "no decimals", value: "12", currency: USD, want: 1200},
+		{name: "plus sign", value: "+1", currency: USD, want: 100},
+		{name: "surrounding space", value: " 7.05 ", currency: USD, want: 705},
+		{name: "leading decimal point", value: ".5", currency: USD, want: 50},
+		{name: "zero minor units", value: "1500", currency: UGX, want: 1500},
+		{name: "three minor units", value: "1.234", currency: "BHD", want: 1234},
+		{name: "unknown currency uses default minor units", value: "1.23", currency: "XYZ", want: 123},
+		{name: "largest amount", value: "92233720368547758.07", currency: USD, want: 9223372036854775807},
+		{name: "smallest amount", value: "-92233720368547758.07", currency: USD, want: -9223372036854775807},
+		{name: "more decimals than minor units is not rounded", value: "12.345", currency: USD, wantErr: true},
+		{name: "decimals on a currency without minor units", value: "1.5", currency: UGX, wantErr: true},
+		{name: "overflow", value: "92233720368547758.08", currency: USD, wantErr: true},
+		{name: "overflow in whole units", value: "9223372036854775808", currency: UGX, wantErr: true},
+		{name: "double minus", value: "--5", currency: USD, wantErr: true},
+		{name: "minus plus", value: "-+5", currency: USD, wantErr: true},
+		{name: "plus minus", value: "+-5", currency: USD, wantErr: true},
+		{name: "empty", value: "", currency: USD, wantErr: true},
+		{name: "sign only", value: "-", currency: USD, wantErr: true},
+		{name: "decimal point only", value: ".", currency: USD, wantErr: true},
+		{name: "thousand separator", value: "1,234.56", currency: USD, wantErr: true},
+		{name: "two decimal points", value: "1.2.3", currency: USD, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMoney) {
+					t.Fatalf("ParseMoney(%q, %s) error = %v, want %v", tt.value, tt.currency, err, ErrInvalidMoney)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q, %s) unexpected error: %v", tt.value, tt.currency, err)
+			}
+			if got.Amount != tt.want || got.Currency != tt.currency {
+				t.Errorf("ParseMoney(%q, %s) = %+v, want %d %s", tt.value, tt.currency, got, tt.want, tt.currency)
+			}
+		})
+	}
+}
+
+func TestMoneyFormatting(t *testing.T) {
+	tests := []struct {
+		money     Money
+		value     string
+		formatted string
+	}{
+		{money: NewMoney(-123456, USD), value: "-1234.56", formatted: "-$1,234.56"},
+		{money: NewMoney(5, USD), value: "0.05", formatted: "$0.05"},
+		{money: NewMoney(0, USD), value: "0.00", formatted: "$0.00"},
+		{money: NewMoney(1500, UGX), value: "1500", formatted: "USh 1,500"},
+		{money: NewMoney(10000, KES), value: "100.00", formatted: "KSh 100.00"},
+		{money: NewMoney(1234, "BHD"), value: "1.234", formatted: "BD 1.234"},
+		{money: NewMoney(-9223372036854775808, UGX), value: "-9223372036854775808", formatted: "-USh 9,223,372,036,854,775,808"},
+		{money: NewMoney(100, "XYZ"), value: "1.00", formatted: "XYZ 1.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value+" "+string(tt.money.Currency), func(t *testing.T) {
+			if got := tt.money.Value(); got != tt.value {
+				t.Errorf("Value() = %q, want %q", got, tt.value)
+			}
+			if got := tt.money.String(); got != tt.formatted {
+				t.Errorf("String() = %q, want %q", got, tt.formatted)
+			}
+		})
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Money
+		wantErr error
+	}{
+		{name: "bare amount", data: `1234`, want: Money{Amount: 1234}},
+		{name: "amount", data: `{"amount": -1234, "currency": "USD"}`, want: NewMoney(-1234, USD)},
+		{name: "amount wins over value", data: `{"amount": 5, "currency": "USD", "value": "9.99"}`, want: NewMoney(5, USD)},
+		{name: "value", data: `{"value": "-12.5", "currency": "USD"}`, want: NewMoney(-1250, USD)},
+		{name: "value without minor units", data: `{"value": "1500", "currency": "UGX"}`, want: NewMoney(1500, UGX)},
+		{name: "currency only", data: `{"currency": "KES"}`, want: NewMoney(0, KES)},
+		{name: "value without currency", data: `{"value": "12.34"}`, wantErr: ErrInvalidMoney},
+		{name: "value with too many decimals", data: `{"value": "1.5", "currency": "UGX"}`, wantErr: ErrInvalidMoney},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Unmarshal(%s) error = %v, want %v", tt.data, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) unexpected error: %v", tt.data, err)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	for _, money := range []Money{NewMoney(-123456, USD), NewMoney(1500, UGX), NewMoney(0, KES)} {
+		data, err := json.Marshal(money)
+		if err != nil {
+			t.Fatalf("Marshal(%+v) unexpected error: %v", money, err)
+		}
+		var got Money
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s) unexpected error: %v", data, err)
+		}
+		if got != money {
+			t.Errorf("round trip of %+v = %+v", money, got)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a recurrence Rule repeats
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxRulePeriods stops Occurrences walking forever over a rule that can never produce a date on or after from
+const maxRulePeriods = 100000
+
+var (
+	// ErrInvalidRule is returned when a recurrence rule cannot be parsed
+	ErrInvalidRule = errors.New("invalid recurrence rule")
+
+	weekdays = map[string]time.Weekday{
+		"SU": time.Sunday,
+		"MO": time.Monday,
+		"TU": time.Tuesday,
+		"WE": time.Wednesday,
+		"TH": time.Thursday,
+		"FR": time.Friday,
+		"SA": time.Saturday,
+	}
+)
+
+// Rule is the subset of an RFC 5545 RRULE we support, e.g. FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=-1
+//
+//	FREQ        DAILY, WEEKLY, MONTHLY or YEARLY
+//	INTERVAL    number of periods between occurrences, defaults to 1
+//	BYDAY       comma separated weekdays (MO,TU,...) a weekly rule falls on
+//	BYMONTHDAY  day a monthly rule falls on, -1 is the last day of the month
+//	COUNT       number of occurrences after which the rule ends
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int
+	Count      int
+}
+
+// ParseRule parses a recurrence rule, an optional RRULE: prefix is allowed
+func ParseRule(rule string) (Rule, error) {
+	r := Rule{Interval: 1}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return Rule{}, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			switch r.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				return Rule{}, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, value)
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err != nil || r.Interval < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive number", ErrInvalidRule)
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err != nil || r.Count < 1 {
+				return Rule{}, fmt.Errorf("%w: COUNT must be a positive number", ErrInvalidRule)
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = strconv.Atoi(value)
+			if err != nil || r.ByMonthDay == 0 || r.ByMonthDay < -1 || r.ByMonthDay > 31 {
+				return Rule{}, fmt.Errorf("%w: BYMONTHDAY must be between 1 and 31 or -1", ErrInvalidRule)
+			}
+		case "BYDAY":
+			r.ByDay = nil
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return Rule{}, fmt.Errorf("%w: unsupported BYDAY %q", ErrInvalidRule, day)
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+			sort.Slice(r.ByDay, func(i, j int) bool { return r.ByDay[i] < r.ByDay[j] })
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+	}
+	if r.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if len(r.ByDay) > 0 && r.Freq != Weekly {
+		return Rule{}, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRule)
+	}
+	if r.ByMonthDay != 0 && r.Freq != Monthly {
+		return Rule{}, fmt.Errorf("%w: BYMONTHDAY is only supported with FREQ=MONTHLY", ErrInvalidRule)
+	}
+	return r, nil
+}
+
+// Occurrences returns up to limit occurrences of the rule starting at start that fall on or after from.
+// Occurrences keep the time of day of start, and monthly or yearly ones that land on a day the month does
+// not have, the 31st or the 29th of February, are moved to the last day of that month
+func (r Rule) Occurrences(start, from time.Time, limit int) []time.Time {
+	var dates []time.Time
+	count := 0
+	for period := 0; period < maxRulePeriods && len(dates) < limit; period++ {
+		for _, date := range r.period(start, period) {
+			if date.Before(start) {
+				continue
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return dates
+			}
+			if !date.Before(from) {
+				dates = append(dates, date)
+				if len(dates) == limit {
+					return dates
+				}
+			}
+		}
+	}
+	return dates
+}
+
+// period returns the dates the rule falls on in the nth period after start, in order
+func (r Rule) period(start time.Time, n int) []time.Time {
+	step := n * r.Interval
+	switch r.Freq {
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return []time.Time{start.AddDate(0, 0, 7*step)}
+		}
+		sunday := start.AddDate(0, 0, 7*step-int(start.Weekday()))
+		dates := make([]time.Time, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			dates = append(dates, sunday.AddDate(0, 0, int(day)))
+		}
+		return dates
+	case Monthly:
+		day := start.Day()
+		if r.ByMonthDay != 0 {
+			day = r.ByMonthDay
+		}
+		return []time.Time{dayOfMonth(start, start.Year(), start.Month()+time.Month(step), day)}
+	case Yearly:
+		return []time.Time{dayOfMonth(start, start.Year()+step, start.Month(), start.Day())}
+	}
+	return []time.Time{start.AddDate(0, 0, step)}
+}
+
+// dayOfMonth returns the day of the month at the time of day of t, clamped to the last day of the month,
+// day -1 is the last day. Months past December roll over into the following years
+func dayOfMonth(t time.Time, year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	if day == -1 || day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
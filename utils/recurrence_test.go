@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    Rule
+		wantErr bool
+	}{
+		{rule: "FREQ=DAILY", want: Rule{Freq: Daily, Interval: 1}},
+		{rule: "RRULE:FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1", want: Rule{Freq: Monthly, Interval: 2, ByMonthDay: -1}},
+		{rule: "freq=weekly;byday=fr,mo;count=3", want: Rule{Freq: Weekly, Interval: 1, ByDay: []time.Weekday{time.Monday, time.Friday}, Count: 3}},
+		{rule: " FREQ=YEARLY; ", want: Rule{Freq: Yearly, Interval: 1}},
+		{rule: "", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=HOURLY", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{rule: "FREQ=DAILY;COUNT=-1", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=-2", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{rule: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{rule: "FREQ=DAILY;UNTIL=20211231", wantErr: true},
+		{rule: "FREQ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseRule(tt.rule)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRule) {
+					t.Fatalf("ParseRule(%q) error = %v, want %v", tt.rule, err, ErrInvalidRule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRule(%q) unexpected error: %v", tt.rule, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRule(%q) = %+v, want %+v", tt.rule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleOccurrences(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		from  time.Time
+		limit int
+		want  []time.Time
+	}{
+		{
+			name:  "daily with interval",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: day(2021, 1, 30),
+			from:  day(2021, 1, 30),
+			limit: 3,
+			want:  []time.Time{day(2021, 1, 30), day(2021, 2, 2), day(2021, 2, 5)},
+		},
+		{
+			name:  "from skips earlier occurrences",
+			rule:  "FREQ=DAILY",
+			start: day(2021, 1, 1),
+			from:  day(2021, 1, 3),
+			limit: 2,
+			want:  []time.Time{day(2021, 1, 3), day(2021, 1, 4)},
+		},
+		{
+			name:  "weekly by day leaves out days before start",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,FR",
+			start: day(2021, 6, 2),
+			from:  day(2021, 6, 2),
+			limit: 3,
+			want:  []time.Time{day(2021, 6, 4), day(2021, 6, 7), day(2021, 6, 11)},
+		},
+		{
+			name:  "monthly on the 31st falls on the last day of shorter months",
+			rule:  "FREQ=MONTHLY",
+			start: day(2021, 1, 31),
+			from:  day(2021, 1, 31),
+			limit: 4,
+			want:  []time.Time{day(2021, 1, 31), day(2021, 2, 28), day(2021, 3, 31), day(2021, 4, 30)},
+		},
+		{
+			name:  "monthly on the last day across a year",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: day(2021, 11, 15),
+			from:  day(2021, 11, 15),
+			limit: 3,
+			want:  []time.Time{day(2021, 11, 30), day(2021, 12, 31), day(2022, 1, 31)},
+		},
+		{
+			name:  "yearly on the 29th of February",
+			rule:  "FREQ=YEARLY",
+			start: day(2020, 2, 29),
+			from:  day(2020, 2, 29),
+			limit: 3,
+			want:  []time.Time{day(2020, 2, 29), day(2021, 2, 28), day(2022, 2, 28)},
+		},
+		{
+			name:  "count ends the rule",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: day(2021, 3, 1),
+			from:  day(2021, 3, 1),
+			limit: 10,
+			want:  []time.Time{day(2021, 3, 1), day(2021, 3, 8), day(2021, 3, 15)},
+		},
+		{
+			name:  "count includes occurrences before from",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: day(2021, 3, 1),
+			from:  day(2021, 3, 3),
+			limit: 10,
+			want:  []time.Time{day(2021, 3, 3)},
+		},
+		{
+			name:  "count used up before from",
+			rule:  "FREQ=DAILY;COUNT=2",
+			start: day(2021, 3, 1),
+			from:  day(2021, 3, 5),
+			limit: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRule(%q) unexpected error: %v", tt.rule, err)
+			}
+			got := rule.Occurrences(tt.start, tt.from, tt.limit)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}